@@ -0,0 +1,155 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chi mounts the endpoints and the documentation of an api on a chi router
+package chi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/zc2638/swag"
+)
+
+// DefaultPrefix is the path prefix the documentation suite is mounted under by default
+const DefaultPrefix = "/docs"
+
+// Option configures Mount
+type Option func(c *config)
+
+type config struct {
+	prefix    string
+	mountedAt string
+	fallbacks bool
+	limits    bool
+}
+
+// Prefix mounts the documentation suite under the prefix instead of DefaultPrefix
+func Prefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// MountedAt is the path prefix the router is mounted under, e.g. with chi.Router.Route or chi.Router.Mount;
+// it prefixes the basePath of the served definition
+func MountedAt(prefix string) Option {
+	return func(c *config) {
+		c.mountedAt = prefix
+	}
+}
+
+// Fallbacks registers the fallbacks of the api: a 405 handler for the methods each path does not define,
+// and the 404 handler of the routes matching no path, see swag.API.Fallbacks
+func Fallbacks() Option {
+	return func(c *config) {
+		c.fallbacks = true
+	}
+}
+
+// Limits wraps the handler of each endpoint with swag.Limit,
+// enforcing its body size, timeout and file limits
+func Limits() Option {
+	return func(c *config) {
+		c.limits = true
+	}
+}
+
+// Mount registers the endpoints of the api on the router, whose path syntax is the swagger one,
+// and mounts the documentation suite of swag.Handlers under the prefix, e.g.
+//
+//	r.Route("/api", func(r chi.Router) {
+//		swagchi.Mount(r, api, swagchi.MountedAt("/api"))
+//	})
+//
+// The versioned variants of an operation share its route, see swag.API.VariantHandler.
+// The handler of an endpoint is a http.Handler, a http.HandlerFunc or a func(http.ResponseWriter, *http.Request);
+// an endpoint without handler, e.g. documentation only, answers 404, and Mount panics on any other handler
+func Mount(r chi.Router, api *swag.API, opts ...Option) {
+	c := config{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	api.WalkVersions(func(path, method string, endpoints map[string]*swag.Endpoint) {
+		r.Method(method, path, api.VariantHandler(endpoints, func(e *swag.Endpoint) http.Handler {
+			h := Handler(e)
+			if c.limits {
+				h = limit(e, h)
+			}
+			return h
+		}))
+	})
+
+	if c.fallbacks {
+		f := api.Fallbacks()
+		for _, p := range f.Paths {
+			for _, method := range p.Methods {
+				r.Method(method, p.Path, p.Handler)
+			}
+		}
+		r.NotFound(f.NotFound.ServeHTTP)
+	}
+
+	doc := api
+	if c.mountedAt != "" {
+		doc = api.Clone()
+		doc.BasePath = path.Join("/", c.mountedAt, api.BasePath)
+	}
+	// the mux is matched against the full path of the request, including the mount prefix
+	mux := http.NewServeMux()
+	doc.Handlers().Mount(mux, path.Join("/", c.mountedAt, c.prefix))
+	prefix := "/" + strings.Trim(c.prefix, "/")
+	r.Handle(prefix+"/*", mux)
+}
+
+// Handler returns the http.Handler serving the endpoint
+func Handler(e *swag.Endpoint) http.Handler {
+	switch h := e.Handler.(type) {
+	case nil:
+		return http.NotFoundHandler()
+	case http.Handler:
+		return h
+	case func(http.ResponseWriter, *http.Request):
+		return http.HandlerFunc(h)
+	}
+	panic(fmt.Sprintf("swag: unsupported handler %T of %s %s", e.Handler, e.Method, e.Path))
+}
+
+// limit wraps the handler with swag.Limit; once the endpoint has a timeout, the handler is served
+// with a copy of the route context, which chi reuses once the request is answered
+func limit(e *swag.Endpoint, h http.Handler) http.Handler {
+	limited := swag.Limit(e, h)
+	if e.Timeout <= 0 {
+		return limited
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			cp := chi.NewRouteContext()
+			cp.Routes = rctx.Routes
+			cp.RoutePath = rctx.RoutePath
+			cp.RouteMethod = rctx.RouteMethod
+			cp.RoutePatterns = append([]string(nil), rctx.RoutePatterns...)
+			cp.URLParams.Keys = append([]string(nil), rctx.URLParams.Keys...)
+			cp.URLParams.Values = append([]string(nil), rctx.URLParams.Values...)
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, cp))
+		}
+		limited.ServeHTTP(w, r)
+	})
+}
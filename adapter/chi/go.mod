@@ -0,0 +1,11 @@
+module github.com/zc2638/swag/adapter/chi
+
+go 1.16
+
+require (
+	github.com/go-chi/chi/v5 v5.0.12
+	github.com/stretchr/testify v1.7.1
+	github.com/zc2638/swag v1.0.0
+)
+
+replace github.com/zc2638/swag => ../..
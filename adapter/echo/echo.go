@@ -0,0 +1,160 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package echo registers the endpoints and the documentation of an api on an echo server
+package echo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/zc2638/swag"
+)
+
+// DefaultPrefix is the path prefix the documentation suite is mounted under by default
+const DefaultPrefix = "/docs"
+
+// Option configures Register
+type Option func(c *config)
+
+type config struct {
+	prefix    string
+	fallbacks bool
+	limits    bool
+}
+
+// Prefix mounts the documentation suite under the prefix instead of DefaultPrefix
+func Prefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// Fallbacks registers the fallbacks of the api: a 405 handler for the methods each path does not define,
+// and the 404 handler of the routes matching no path, see swag.API.Fallbacks
+func Fallbacks() Option {
+	return func(c *config) {
+		c.fallbacks = true
+	}
+}
+
+// Limits wraps the handler of each endpoint with swag.Limit, enforcing its body size, timeout and file limits;
+// the errors of the handlers are then answered by the HTTP error handler of the server within the limits
+func Limits() Option {
+	return func(c *config) {
+		c.limits = true
+	}
+}
+
+// Register registers the endpoints of the api on the server with their path converted to the echo syntax,
+// e.g. /pets/{id} as /pets/:id, and mounts the documentation suite of swag.Handlers under the prefix.
+// The versioned variants of an operation share its route, serving the variant returned by swag.API.Variant.
+// The handler of an endpoint is an echo.HandlerFunc, a func(echo.Context) error, a http.Handler or a http.HandlerFunc,
+// and an endpoint without handler, e.g. documentation only, answers 404; Register panics on any other handler
+func Register(e *echo.Echo, api *swag.API, opts ...Option) {
+	c := config{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	api.WalkVersions(func(path, method string, endpoints map[string]*swag.Endpoint) {
+		handlers := make(map[*swag.Endpoint]echo.HandlerFunc, len(endpoints))
+		for _, endpoint := range endpoints {
+			h := Handler(endpoint)
+			if c.limits {
+				h = limit(endpoint, h)
+			}
+			handlers[endpoint] = h
+		}
+		e.Add(method, swag.ColonPath(path), func(ctx echo.Context) error {
+			h, ok := handlers[api.Variant(ctx.Request(), endpoints)]
+			if !ok {
+				return echo.ErrNotFound
+			}
+			return h(ctx)
+		})
+	})
+
+	if c.fallbacks {
+		f := api.Fallbacks()
+		for _, p := range f.Paths {
+			h := echo.WrapHandler(p.Handler)
+			for _, method := range p.Methods {
+				e.Add(method, swag.ColonPath(p.Path), h)
+			}
+		}
+		e.RouteNotFound("/*", echo.WrapHandler(f.NotFound))
+	}
+
+	mux := http.NewServeMux()
+	api.Handlers().Mount(mux, c.prefix)
+	prefix := "/" + strings.Trim(c.prefix, "/")
+	e.GET(prefix+"/*", echo.WrapHandler(mux))
+}
+
+// Handler returns the echo.HandlerFunc serving the endpoint
+func Handler(e *swag.Endpoint) echo.HandlerFunc {
+	switch h := e.Handler.(type) {
+	case nil:
+		return echo.WrapHandler(http.NotFoundHandler())
+	case echo.HandlerFunc:
+		return h
+	case func(echo.Context) error:
+		return h
+	case http.HandlerFunc:
+		return echo.WrapHandler(h)
+	case func(http.ResponseWriter, *http.Request):
+		return echo.WrapHandler(http.HandlerFunc(h))
+	case http.Handler:
+		return echo.WrapHandler(h)
+	}
+	panic(fmt.Sprintf("swag: unsupported handler %T of %s %s", e.Handler, e.Method, e.Path))
+}
+
+type contextKey struct{}
+
+// limit wraps the handler with swag.Limit; once the endpoint has a timeout, the handler is served with a new context,
+// since it may outlive the request, writing to the buffer of swag.Limit
+func limit(e *swag.Endpoint, h echo.HandlerFunc) echo.HandlerFunc {
+	limited := swag.Limit(e, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := r.Context().Value(contextKey{}).(func(w http.ResponseWriter, r *http.Request) echo.Context)(w, r)
+		if err := h(c); err != nil {
+			c.Error(err)
+		}
+	}))
+	return func(c echo.Context) error {
+		serve := func(_ http.ResponseWriter, r *http.Request) echo.Context {
+			c.SetRequest(r)
+			return c
+		}
+		if e.Timeout > 0 {
+			server, path := c.Echo(), c.Path()
+			names := append([]string(nil), c.ParamNames()...)
+			values := append([]string(nil), c.ParamValues()...)
+			serve = func(w http.ResponseWriter, r *http.Request) echo.Context {
+				hc := server.NewContext(r, w)
+				hc.SetPath(path)
+				hc.SetParamNames(names...)
+				hc.SetParamValues(values...)
+				return hc
+			}
+		}
+		limited.ServeHTTP(c.Response(), c.Request().WithContext(context.WithValue(c.Request().Context(), contextKey{}, serve)))
+		return nil
+	}
+}
@@ -0,0 +1,11 @@
+module github.com/zc2638/swag/adapter/echo
+
+go 1.16
+
+require (
+	github.com/labstack/echo/v4 v4.11.4
+	github.com/stretchr/testify v1.8.4
+	github.com/zc2638/swag v1.0.0
+)
+
+replace github.com/zc2638/swag => ../..
@@ -0,0 +1,180 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fiber registers the endpoints and the documentation of an api on a fiber app
+package fiber
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/gofiber/fiber/v2/middleware/timeout"
+
+	"github.com/zc2638/swag"
+)
+
+// DefaultPrefix is the path prefix the documentation suite is mounted under by default
+const DefaultPrefix = "/docs"
+
+// Option configures Register
+type Option func(c *config)
+
+type config struct {
+	prefix    string
+	fallbacks bool
+	limits    bool
+}
+
+// Prefix mounts the documentation suite under the prefix instead of DefaultPrefix
+func Prefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// Fallbacks registers the fallbacks of the api: a 405 handler for the methods each path does not define,
+// and the 404 handler of the routes matching no path, see swag.API.Fallbacks;
+// since fiber matches the routes in their order, the api is then registered after the other routes
+func Fallbacks() Option {
+	return func(c *config) {
+		c.fallbacks = true
+	}
+}
+
+// Limits enforces the body size, timeout and file limits of each endpoint with swag.Limit:
+// the net/http handlers are wrapped with it, while the fiber.Handler endpoints are preceded by its body and file checks,
+// their timeout canceling the user context of the fiber.Ctx, answered with 408 once the handler returns its error
+func Limits() Option {
+	return func(c *config) {
+		c.limits = true
+	}
+}
+
+// Register registers the endpoints of the api on the app with their path converted to the fiber syntax,
+// e.g. /pets/{id} as /pets/:id, and mounts the documentation suite of swag.Handlers under the prefix.
+// The versioned variants of an operation share its route, serving the variant returned by swag.API.Variant.
+// The handler of an endpoint is a fiber.Handler, a http.Handler or a http.HandlerFunc,
+// the net/http handlers being served through the fiber adaptor; an endpoint without handler, e.g. documentation only,
+// answers 404, and Register panics on any other handler
+func Register(app *fiber.App, api *swag.API, opts ...Option) {
+	c := config{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	api.WalkVersions(func(path, method string, endpoints map[string]*swag.Endpoint) {
+		app.Add(method, swag.ColonPath(path), variants(api, endpoints, func(e *swag.Endpoint) []fiber.Handler {
+			if c.limits {
+				return limit(e)
+			}
+			return []fiber.Handler{Handler(e)}
+		})...)
+	})
+
+	mux := http.NewServeMux()
+	api.Handlers().Mount(mux, c.prefix)
+	prefix := "/" + strings.Trim(c.prefix, "/")
+	app.Get(prefix+"/*", adaptor.HTTPHandler(mux))
+
+	if c.fallbacks {
+		f := api.Fallbacks()
+		for _, p := range f.Paths {
+			h := adaptor.HTTPHandler(p.Handler)
+			for _, method := range p.Methods {
+				app.Add(method, swag.ColonPath(p.Path), h)
+			}
+		}
+		app.Use(adaptor.HTTPHandler(f.NotFound))
+	}
+}
+
+// Handler returns the fiber.Handler serving the endpoint
+func Handler(e *swag.Endpoint) fiber.Handler {
+	switch h := e.Handler.(type) {
+	case nil:
+		return adaptor.HTTPHandlerFunc(http.NotFound)
+	case fiber.Handler:
+		return h
+	case http.HandlerFunc:
+		return adaptor.HTTPHandlerFunc(h)
+	case func(http.ResponseWriter, *http.Request):
+		return adaptor.HTTPHandlerFunc(h)
+	case http.Handler:
+		return adaptor.HTTPHandler(h)
+	}
+	panic(fmt.Sprintf("swag: unsupported handler %T of %s %s", e.Handler, e.Method, e.Path))
+}
+
+type variantKey struct{}
+
+// variants returns the handlers of the route serving an operation given by swag.API.WalkVersions:
+// the first one selects the variant of the request, whose handlers are run while the others pass through,
+// and the last one answers 404 if there is no variant to serve
+func variants(api *swag.API, endpoints map[string]*swag.Endpoint, chain func(e *swag.Endpoint) []fiber.Handler) []fiber.Handler {
+	if e, ok := endpoints[""]; ok && len(endpoints) == 1 {
+		return chain(e)
+	}
+	handlers := []fiber.Handler{func(c *fiber.Ctx) error {
+		req, err := adaptor.ConvertRequest(c, false)
+		if err != nil {
+			return err
+		}
+		c.Locals(variantKey{}, api.Variant(req, endpoints))
+		return c.Next()
+	}}
+	for _, e := range endpoints {
+		for _, h := range chain(e) {
+			e, h := e, h
+			handlers = append(handlers, func(c *fiber.Ctx) error {
+				if c.Locals(variantKey{}) != e {
+					return c.Next()
+				}
+				return h(c)
+			})
+		}
+	}
+	return append(handlers, func(*fiber.Ctx) error {
+		return fiber.ErrNotFound
+	})
+}
+
+// limit returns the handlers serving the endpoint within its limits, see Limits
+func limit(e *swag.Endpoint) []fiber.Handler {
+	var h http.Handler
+	switch v := e.Handler.(type) {
+	case fiber.Handler:
+		// fasthttp reuses the context once the request is answered, so the handler can not outlive it
+		checks := *e
+		checks.Timeout = 0
+		if e.Timeout > 0 {
+			v = timeout.NewWithContext(v, e.Timeout)
+		}
+		return []fiber.Handler{
+			adaptor.HTTPMiddleware(func(next http.Handler) http.Handler {
+				return swag.Limit(&checks, next)
+			}),
+			v,
+		}
+	case http.Handler:
+		h = v
+	case func(http.ResponseWriter, *http.Request):
+		h = http.HandlerFunc(v)
+	default:
+		return []fiber.Handler{Handler(e)}
+	}
+	return []fiber.Handler{adaptor.HTTPHandler(swag.Limit(e, h))}
+}
@@ -0,0 +1,173 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fiber
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/endpoint"
+	"github.com/zc2638/swag/option"
+	"github.com/zc2638/swag/types"
+)
+
+func TestRegister(t *testing.T) {
+	api := swag.New(
+		option.BasePath("/v1"),
+		option.GlobalResponses(map[int]swag.Response{http.StatusNotFound: {Description: "no such resource"}}),
+		option.Endpoints(
+			endpoint.New(http.MethodGet, "/pets/{id}",
+				endpoint.Path("id", types.String, "the pet", true),
+				endpoint.Handler(func(c *fiber.Ctx) error {
+					return c.SendString(c.Params("id"))
+				}),
+			),
+			endpoint.New(http.MethodPost, "/pets",
+				endpoint.Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusCreated)
+				})),
+			),
+		),
+	)
+	app := fiber.New()
+	Register(app, api, Fallbacks())
+
+	do := func(method, target string) (*http.Response, string) {
+		resp, err := app.Test(httptest.NewRequest(method, target, nil))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, string(body)
+	}
+
+	resp, body := do(http.MethodGet, "/v1/pets/42")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "42", body)
+
+	resp, _ = do(http.MethodPost, "/v1/pets")
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, _ = do(http.MethodDelete, "/v1/pets/42")
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	assert.Equal(t, "GET", resp.Header.Get("Allow"))
+
+	resp, body = do(http.MethodGet, "/owners")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "no such resource\n", body)
+
+	resp, body = do(http.MethodGet, "/docs/swagger.json")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, `"/pets/{id}"`)
+
+	resp, _ = do(http.MethodGet, "/docs/ui/")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestHandler(t *testing.T) {
+	e := &swag.Endpoint{Method: http.MethodGet, Path: "/pets", Handler: "pets"}
+	assert.Panics(t, func() { Handler(e) })
+
+	// the endpoints without handler are documentation only
+	app := fiber.New()
+	Register(app, swag.New(option.Endpoints(endpoint.New(http.MethodGet, "/docs-only"))))
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/docs-only", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestRegister_Limits(t *testing.T) {
+	api := swag.New(
+		option.Endpoints(
+			endpoint.New(http.MethodPost, "/pets/{id}",
+				endpoint.Limits(4, time.Second),
+				endpoint.Handler(func(c *fiber.Ctx) error {
+					return c.Status(http.StatusCreated).SendString(c.Params("id"))
+				}),
+			),
+		),
+	)
+	app := fiber.New()
+	Register(app, api, Limits())
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/pets/42", strings.NewReader("{}")))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "42", string(body))
+
+	resp, err = app.Test(httptest.NewRequest(http.MethodPost, "/pets/42", strings.NewReader("too large")))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
+
+func TestRegister_Versions(t *testing.T) {
+	api := swag.New(
+		option.HeaderVersioning("Accept", "1", "2"),
+		option.Endpoints(
+			endpoint.New(http.MethodGet, "/pets/{id}", endpoint.Handler(func(c *fiber.Ctx) error {
+				return c.SendString("v1 " + c.Params("id"))
+			})),
+			endpoint.New(http.MethodGet, "/pets/{id}", endpoint.Version("2"), endpoint.Limits(4, time.Second),
+				endpoint.Handler(func(c *fiber.Ctx) error {
+					return c.SendString("v2 " + c.Params("id"))
+				}),
+			),
+			endpoint.New(http.MethodDelete, "/pets/{id}", endpoint.Version("2"), endpoint.Limits(4, time.Second),
+				endpoint.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				})),
+			),
+		),
+	)
+	app := fiber.New()
+	Register(app, api, Fallbacks(), Limits())
+
+	do := func(method, version string) (int, string) {
+		req := httptest.NewRequest(method, "/pets/42", nil)
+		if version != "" {
+			req.Header.Set("Accept", "application/json; version="+version)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	_, body := do(http.MethodGet, "")
+	assert.Equal(t, "v1 42", body)
+	_, body = do(http.MethodGet, "2")
+	assert.Equal(t, "v2 42", body)
+	code, _ := do(http.MethodDelete, "2")
+	assert.Equal(t, http.StatusNoContent, code)
+	code, _ = do(http.MethodDelete, "")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = do(http.MethodPut, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+}
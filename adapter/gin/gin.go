@@ -0,0 +1,205 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gin registers the endpoints and the documentation of an api on a gin engine
+package gin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/zc2638/swag"
+)
+
+// DefaultPrefix is the path prefix the documentation suite is mounted under by default
+const DefaultPrefix = "/docs"
+
+// Option configures Register
+type Option func(c *config)
+
+type config struct {
+	prefix    string
+	fallbacks bool
+	limits    bool
+}
+
+// Prefix mounts the documentation suite under the prefix instead of DefaultPrefix
+func Prefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// Fallbacks registers the fallbacks of the api: a 405 handler for the methods each path does not define,
+// and the 404 handler of the routes matching no path, see swag.API.Fallbacks
+func Fallbacks() Option {
+	return func(c *config) {
+		c.fallbacks = true
+	}
+}
+
+// Limits wraps the handler of each endpoint with swag.Limit,
+// enforcing its body size, timeout and file limits
+func Limits() Option {
+	return func(c *config) {
+		c.limits = true
+	}
+}
+
+// Register registers the endpoints of the api on the engine with their path converted to the gin syntax,
+// e.g. /pets/{id} as /pets/:id, and mounts the documentation suite of swag.Handlers under the prefix.
+// The versioned variants of an operation share its route, serving the variant returned by swag.API.Variant.
+// The handler of an endpoint is a gin.HandlerFunc, a func(*gin.Context), a http.Handler or a http.HandlerFunc,
+// and an endpoint without handler, e.g. documentation only, answers 404;
+// Register panics on any other handler, like gin does on conflicting routes
+func Register(r *gin.Engine, api *swag.API, opts ...Option) {
+	c := config{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	api.WalkVersions(func(path, method string, endpoints map[string]*swag.Endpoint) {
+		handlers := make(map[*swag.Endpoint]gin.HandlerFunc, len(endpoints))
+		for _, e := range endpoints {
+			h := Handler(e)
+			if c.limits {
+				h = limit(e, h)
+			}
+			handlers[e] = h
+		}
+		r.Handle(method, swag.ColonPath(path), func(ctx *gin.Context) {
+			h, ok := handlers[api.Variant(ctx.Request, endpoints)]
+			if !ok {
+				http.NotFound(ctx.Writer, ctx.Request)
+				return
+			}
+			h(ctx)
+		})
+	})
+
+	if c.fallbacks {
+		f := api.Fallbacks()
+		for _, p := range f.Paths {
+			h := gin.WrapH(p.Handler)
+			for _, method := range p.Methods {
+				r.Handle(method, swag.ColonPath(p.Path), h)
+			}
+		}
+		r.NoRoute(gin.WrapH(f.NotFound))
+	}
+
+	mux := http.NewServeMux()
+	api.Handlers().Mount(mux, c.prefix)
+	prefix := "/" + strings.Trim(c.prefix, "/")
+	r.GET(prefix+"/*any", gin.WrapH(mux))
+}
+
+// Handler returns the gin.HandlerFunc serving the endpoint
+func Handler(e *swag.Endpoint) gin.HandlerFunc {
+	switch h := e.Handler.(type) {
+	case nil:
+		return gin.WrapF(http.NotFound)
+	case gin.HandlerFunc:
+		return h
+	case func(*gin.Context):
+		return h
+	case http.HandlerFunc:
+		return gin.WrapF(h)
+	case func(http.ResponseWriter, *http.Request):
+		return gin.WrapF(h)
+	case http.Handler:
+		return gin.WrapH(h)
+	}
+	panic(fmt.Sprintf("swag: unsupported handler %T of %s %s", e.Handler, e.Method, e.Path))
+}
+
+type contextKey struct{}
+
+// limit wraps the handler with swag.Limit; once the endpoint has a timeout, the handler is served with a copy
+// of the context, since it may outlive the request, writing to the buffer of swag.Limit through a limitWriter
+func limit(e *swag.Endpoint, h gin.HandlerFunc) gin.HandlerFunc {
+	limited := swag.Limit(e, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := r.Context().Value(contextKey{}).(*gin.Context)
+		c.Request = r
+		if e.Timeout > 0 {
+			c.Writer = &limitWriter{ResponseWriter: c.Writer, w: w, size: -1}
+		}
+		h(c)
+	}))
+	return func(c *gin.Context) {
+		hc := c
+		if e.Timeout > 0 {
+			hc = c.Copy()
+		}
+		limited.ServeHTTP(c.Writer, c.Request.WithContext(context.WithValue(c.Request.Context(), contextKey{}, hc)))
+	}
+}
+
+// limitWriter is the gin.ResponseWriter writing to the response writer of swag.Limit
+type limitWriter struct {
+	gin.ResponseWriter
+	w      http.ResponseWriter
+	status int
+	size   int
+}
+
+func (w *limitWriter) Header() http.Header {
+	return w.w.Header()
+}
+
+func (w *limitWriter) WriteHeader(code int) {
+	if code > 0 && !w.Written() {
+		w.status = code
+	}
+}
+
+func (w *limitWriter) WriteHeaderNow() {
+	if !w.Written() {
+		w.size = 0
+		w.w.WriteHeader(w.Status())
+	}
+}
+
+func (w *limitWriter) Write(data []byte) (int, error) {
+	w.WriteHeaderNow()
+	n, err := w.w.Write(data)
+	w.size += n
+	return n, err
+}
+
+func (w *limitWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *limitWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+func (w *limitWriter) Size() int {
+	return w.size
+}
+
+func (w *limitWriter) Written() bool {
+	return w.size != -1
+}
+
+// Flush does nothing, the response being buffered until the handler returns
+func (w *limitWriter) Flush() {}
@@ -0,0 +1,182 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/endpoint"
+	"github.com/zc2638/swag/option"
+	"github.com/zc2638/swag/types"
+)
+
+func TestRegister(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	api := swag.New(
+		option.BasePath("/v1"),
+		option.Endpoints(
+			endpoint.New(http.MethodGet, "/pets/{id}",
+				endpoint.Path("id", types.String, "the pet", true),
+				endpoint.Handler(func(c *gin.Context) {
+					c.String(http.StatusOK, c.Param("id"))
+				}),
+			),
+			endpoint.New(http.MethodPost, "/pets",
+				endpoint.Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(http.StatusCreated)
+				})),
+			),
+		),
+	)
+	r := gin.New()
+	Register(r, api)
+
+	get := func(method, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+		return w
+	}
+
+	w := get(http.MethodGet, "/v1/pets/42")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "42", w.Body.String())
+
+	w = get(http.MethodPost, "/v1/pets")
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	w = get(http.MethodGet, "/docs/swagger.json")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"/pets/{id}"`)
+
+	w = get(http.MethodGet, "/docs/ui/")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestHandler(t *testing.T) {
+	e := &swag.Endpoint{Method: http.MethodGet, Path: "/pets", Handler: "pets"}
+	assert.Panics(t, func() { Handler(e) })
+
+	// the endpoints without handler are documentation only
+	r := gin.New()
+	Register(r, swag.New(option.Endpoints(endpoint.New(http.MethodGet, "/docs-only"))))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs-only", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRegister_Fallbacks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	api := swag.New(
+		option.GlobalResponses(map[int]swag.Response{http.StatusNotFound: {Description: "no such resource"}}),
+		option.Endpoints(
+			endpoint.New(http.MethodGet, "/pets/{id}",
+				endpoint.Path("id", types.String, "the pet", true),
+				endpoint.Handler(func(c *gin.Context) {}),
+			),
+		),
+	)
+	r := gin.New()
+	Register(r, api, Prefix("/swagger"), Fallbacks())
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/pets/42", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET", w.Header().Get("Allow"))
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/owners", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "no such resource\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger/swagger.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRegister_Limits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	api := swag.New(
+		option.Endpoints(
+			endpoint.New(http.MethodPost, "/pets/{id}",
+				endpoint.Limits(4, time.Second),
+				endpoint.Handler(func(c *gin.Context) {
+					c.String(http.StatusCreated, c.Param("id"))
+				}),
+			),
+		),
+	)
+	r := gin.New()
+	Register(r, api, Limits())
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/pets/42", strings.NewReader("{}")))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "42", w.Body.String())
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/pets/42", strings.NewReader("too large")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestRegister_Versions(t *testing.T) {
+	api := swag.New(
+		option.HeaderVersioning("Accept", "1", "2"),
+		option.Endpoints(
+			endpoint.New(http.MethodGet, "/pets/{id}", endpoint.Handler(func(c *gin.Context) {
+				c.String(http.StatusOK, "v1 "+c.Param("id"))
+			})),
+			endpoint.New(http.MethodGet, "/pets/{id}", endpoint.Version("2"), endpoint.Handler(func(c *gin.Context) {
+				c.String(http.StatusOK, "v2 "+c.Param("id"))
+			})),
+			endpoint.New(http.MethodDelete, "/pets/{id}", endpoint.Version("2"), endpoint.Handler(func(c *gin.Context) {
+				c.Status(http.StatusNoContent)
+			})),
+		),
+	)
+	r := gin.New()
+	Register(r, api, Fallbacks())
+
+	do := func(method, version string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, "/pets/42", nil)
+		if version != "" {
+			req.Header.Set("Accept", "application/json; version="+version)
+		}
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(http.MethodGet, "")
+	assert.Equal(t, "v1 42", w.Body.String())
+	w = do(http.MethodGet, "2")
+	assert.Equal(t, "v2 42", w.Body.String())
+	w = do(http.MethodDelete, "2")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	w = do(http.MethodDelete, "")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	w = do(http.MethodPut, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
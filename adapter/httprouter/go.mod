@@ -0,0 +1,11 @@
+module github.com/zc2638/swag/adapter/httprouter
+
+go 1.16
+
+require (
+	github.com/julienschmidt/httprouter v1.3.0
+	github.com/stretchr/testify v1.7.1
+	github.com/zc2638/swag v1.0.0
+)
+
+replace github.com/zc2638/swag => ../..
@@ -0,0 +1,144 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package httprouter registers the endpoints and the documentation of an api on a httprouter router
+package httprouter
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/zc2638/swag"
+)
+
+// DefaultPrefix is the path prefix the documentation suite is mounted under by default
+const DefaultPrefix = "/docs"
+
+// Option configures Register
+type Option func(c *config)
+
+type config struct {
+	prefix    string
+	fallbacks bool
+	limits    bool
+}
+
+// Prefix mounts the documentation suite under the prefix instead of DefaultPrefix
+func Prefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// Fallbacks registers the fallbacks of the api: a 405 handler for the methods each path does not define,
+// and the 404 handler of the routes matching no path, see swag.API.Fallbacks
+func Fallbacks() Option {
+	return func(c *config) {
+		c.fallbacks = true
+	}
+}
+
+// Limits wraps the handler of each endpoint with swag.Limit,
+// enforcing its body size, timeout and file limits
+func Limits() Option {
+	return func(c *config) {
+		c.limits = true
+	}
+}
+
+// Register registers the endpoints of the api on the router with their path converted to the httprouter syntax,
+// e.g. /pets/{id} as /pets/:id, and mounts the documentation suite of swag.Handlers under the prefix.
+// The versioned variants of an operation share its route, serving the variant returned by swag.API.Variant.
+// The handler of an endpoint is a httprouter.Handle, a http.Handler, a http.HandlerFunc
+// or a func(http.ResponseWriter, *http.Request), whose path parameters are read with httprouter.ParamsFromContext;
+// an endpoint without handler, e.g. documentation only, answers 404, and Register panics on any other handler
+func Register(r *httprouter.Router, api *swag.API, opts ...Option) {
+	c := config{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	api.WalkVersions(func(path, method string, endpoints map[string]*swag.Endpoint) {
+		handles := make(map[*swag.Endpoint]httprouter.Handle, len(endpoints))
+		for _, e := range endpoints {
+			h := Handle(e)
+			if c.limits {
+				h = limit(e, h)
+			}
+			handles[e] = h
+		}
+		r.Handle(method, swag.ColonPath(path), func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+			h, ok := handles[api.Variant(req, endpoints)]
+			if !ok {
+				http.NotFound(w, req)
+				return
+			}
+			h(w, req, ps)
+		})
+	})
+
+	if c.fallbacks {
+		f := api.Fallbacks()
+		for _, p := range f.Paths {
+			for _, method := range p.Methods {
+				r.Handler(method, swag.ColonPath(p.Path), p.Handler)
+			}
+		}
+		r.NotFound = f.NotFound
+	}
+
+	docs := http.NewServeMux()
+	api.Handlers().Mount(docs, c.prefix)
+	prefix := "/" + strings.Trim(c.prefix, "/")
+	r.Handler(http.MethodGet, prefix+"/*any", docs)
+}
+
+// Handle returns the httprouter.Handle serving the endpoint
+func Handle(e *swag.Endpoint) httprouter.Handle {
+	var h http.Handler
+	switch v := e.Handler.(type) {
+	case nil:
+		h = http.NotFoundHandler()
+	case httprouter.Handle:
+		return v
+	case func(http.ResponseWriter, *http.Request, httprouter.Params):
+		return v
+	case http.Handler:
+		h = v
+	case func(http.ResponseWriter, *http.Request):
+		h = http.HandlerFunc(v)
+	default:
+		panic(fmt.Sprintf("swag: unsupported handler %T of %s %s", e.Handler, e.Method, e.Path))
+	}
+	return func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		if len(ps) > 0 {
+			req = req.WithContext(context.WithValue(req.Context(), httprouter.ParamsKey, ps))
+		}
+		h.ServeHTTP(w, req)
+	}
+}
+
+// limit wraps the handle with swag.Limit, passing the path parameters through the context of the request
+func limit(e *swag.Endpoint, h httprouter.Handle) httprouter.Handle {
+	limited := swag.Limit(e, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		h(w, req, httprouter.ParamsFromContext(req.Context()))
+	}))
+	return func(w http.ResponseWriter, req *http.Request, ps httprouter.Params) {
+		limited.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), httprouter.ParamsKey, ps)))
+	}
+}
@@ -0,0 +1,11 @@
+module github.com/zc2638/swag/adapter/mux
+
+go 1.16
+
+require (
+	github.com/gorilla/mux v1.8.1
+	github.com/stretchr/testify v1.7.1
+	github.com/zc2638/swag v1.0.0
+)
+
+replace github.com/zc2638/swag => ../..
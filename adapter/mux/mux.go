@@ -0,0 +1,109 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mux registers the endpoints and the documentation of an api on a gorilla/mux router
+package mux
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/zc2638/swag"
+)
+
+// DefaultPrefix is the path prefix the documentation suite is mounted under by default
+const DefaultPrefix = "/docs"
+
+// Option configures Register
+type Option func(c *config)
+
+type config struct {
+	prefix    string
+	fallbacks bool
+	limits    bool
+}
+
+// Prefix mounts the documentation suite under the prefix instead of DefaultPrefix
+func Prefix(prefix string) Option {
+	return func(c *config) {
+		c.prefix = prefix
+	}
+}
+
+// Fallbacks registers the fallbacks of the api: a 405 handler for the methods each path does not define,
+// and the 404 handler of the routes matching no path, see swag.API.Fallbacks
+func Fallbacks() Option {
+	return func(c *config) {
+		c.fallbacks = true
+	}
+}
+
+// Limits wraps the handler of each endpoint with swag.Limit,
+// enforcing its body size, timeout and file limits
+func Limits() Option {
+	return func(c *config) {
+		c.limits = true
+	}
+}
+
+// Register registers the endpoints of the api on the router, whose path syntax is the swagger one,
+// and mounts the documentation suite of swag.Handlers under the prefix.
+// The versioned variants of an operation share its route, see swag.API.VariantHandler.
+// The handler of an endpoint is a http.Handler, a http.HandlerFunc or a func(http.ResponseWriter, *http.Request);
+// an endpoint without handler, e.g. documentation only, answers 404, and Register panics on any other handler
+func Register(r *mux.Router, api *swag.API, opts ...Option) {
+	c := config{prefix: DefaultPrefix}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	api.WalkVersions(func(path, method string, endpoints map[string]*swag.Endpoint) {
+		r.Handle(path, api.VariantHandler(endpoints, func(e *swag.Endpoint) http.Handler {
+			h := Handler(e)
+			if c.limits {
+				h = swag.Limit(e, h)
+			}
+			return h
+		})).Methods(method)
+	})
+
+	if c.fallbacks {
+		f := api.Fallbacks()
+		for _, p := range f.Paths {
+			r.Handle(p.Path, p.Handler).Methods(p.Methods...)
+		}
+		r.NotFoundHandler = f.NotFound
+	}
+
+	docs := http.NewServeMux()
+	api.Handlers().Mount(docs, c.prefix)
+	prefix := "/" + strings.Trim(c.prefix, "/")
+	r.PathPrefix(prefix + "/").Methods(http.MethodGet).Handler(docs)
+}
+
+// Handler returns the http.Handler serving the endpoint
+func Handler(e *swag.Endpoint) http.Handler {
+	switch h := e.Handler.(type) {
+	case nil:
+		return http.NotFoundHandler()
+	case http.Handler:
+		return h
+	case func(http.ResponseWriter, *http.Request):
+		return http.HandlerFunc(h)
+	}
+	panic(fmt.Sprintf("swag: unsupported handler %T of %s %s", e.Handler, e.Method, e.Path))
+}
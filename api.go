@@ -16,12 +16,13 @@ package swag
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"path"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/zc2638/swag/asserts"
@@ -37,22 +38,48 @@ type Object struct {
 	Format      string              `json:"format,omitempty"`
 	Required    []string            `json:"required,omitempty"`
 	Properties  map[string]Property `json:"properties,omitempty"`
+	XML         *XML                `json:"xml,omitempty"`
+	// AdditionalProperties is the schema of the values of a map, e.g. read from a loaded document
+	AdditionalProperties *Property `json:"additionalProperties,omitempty"`
+	// Extensions are the vendor extensions of the definition, read from the models implementing Extender
+	Extensions map[string]interface{} `json:"-"`
 }
 
 // Property represents the property entity from the swagger definition
 type Property struct {
-	GoType      reflect.Type `json:"-"`
-	Type        string       `json:"type,omitempty"`
-	Description string       `json:"description,omitempty"`
-	Enum        []string     `json:"enum,omitempty"`
-	Format      string       `json:"format,omitempty"`
-	Ref         string       `json:"$ref,omitempty"`
-	Example     string       `json:"example,omitempty"`
-	Items       *Items       `json:"items,omitempty"`
+	GoType      reflect.Type        `json:"-"`
+	Type        string              `json:"type,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Enum        []string            `json:"enum,omitempty"`
+	Format      string              `json:"format,omitempty"`
+	Ref         string              `json:"$ref,omitempty"`
+	Example     string              `json:"example,omitempty"`
+	Items       *Items              `json:"items,omitempty"`
+	Layout      string              `json:"x-format-layout,omitempty"`
+	Pattern     string              `json:"pattern,omitempty"`
+	Unit        string              `json:"x-unit,omitempty"`
+	Precision   *int                `json:"x-precision,omitempty"`
+	Required    []string            `json:"required,omitempty"`
+	Properties  map[string]Property `json:"properties,omitempty"`
+	Sensitive   bool                `json:"x-sensitive,omitempty"`
+	Nullable    bool                `json:"x-nullable,omitempty"`
+	Const       string              `json:"x-const,omitempty"`
+	XML         *XML                `json:"xml,omitempty"`
+	// OneOf lists the alternative schemas of the value; swagger 2.0 has no equivalent,
+	// and renders it according to RenderOptions.Unsupported
+	OneOf []Property `json:"oneOf,omitempty"`
+	// AdditionalProperties is the schema of the values of a map, e.g. read from a loaded document
+	AdditionalProperties *Property `json:"additionalProperties,omitempty"`
+	// Audience restricts the property to the rendering for one of the audiences
+	Audience []string `json:"-"`
+	// Extensions are the vendor extensions of the property, read from the x- keys of the field tag
+	Extensions map[string]interface{} `json:"-"`
 }
 
 // Contact represents the contact entity from the swagger definition; used by Info
 type Contact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
 	Email string `json:"email,omitempty"`
 }
 
@@ -70,6 +97,8 @@ type Info struct {
 	Title          string   `json:"title,omitempty"`
 	Contact        *Contact `json:"contact,omitempty"`
 	License        License  `json:"license"`
+	// Extensions are the vendor extensions of the info
+	Extensions map[string]interface{} `json:"-"`
 }
 
 // SecurityScheme represents a security scheme from the swagger definition.
@@ -82,6 +111,30 @@ type SecurityScheme struct {
 	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
 	TokenURL         string            `json:"tokenUrl,omitempty"`
 	Scopes           map[string]string `json:"scopes,omitempty"`
+	// Scheme is the http authorization scheme of an apiKey in the Authorization header, e.g. bearer;
+	// the OpenAPI 3 rendering turns the apiKey into an http security scheme
+	Scheme       string `json:"x-scheme,omitempty"`
+	BearerFormat string `json:"x-bearer-format,omitempty"`
+}
+
+// BearerSecurity returns the security scheme of the JWT bearer tokens sent in the Authorization header,
+// an apiKey in swagger 2.0 and an http bearer scheme in OpenAPI 3, and registers it under the name:
+// an endpoint requiring the name, e.g. with endpoint.Security, adds the scheme to the security definitions
+// of the api unless the api defines the name. The name bearer is registered by default
+func BearerSecurity(name string) SecurityScheme {
+	scheme := bearerScheme()
+	RegisterSecurityScheme(name, scheme)
+	return scheme
+}
+
+func bearerScheme() SecurityScheme {
+	return SecurityScheme{
+		Type:         "apiKey",
+		Name:         "Authorization",
+		In:           "header",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+	}
 }
 
 // Endpoints represents all the swagger endpoints associated with a particular path
@@ -122,6 +175,13 @@ func (e *Endpoints) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		endpoint = e.Connect
 	}
 
+	if endpoint == nil {
+		if allow := e.allow(); allow != "" {
+			w.Header().Set("Allow", allow)
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+	}
 	if endpoint == nil || endpoint.Handler == nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
@@ -171,35 +231,153 @@ func (e *Endpoints) Walk(fn func(endpoint *Endpoint)) {
 	}
 }
 
+// endpoint returns the endpoint of the method, or nil if there is none
+func (e *Endpoints) endpoint(method string) *Endpoint {
+	var result *Endpoint
+	e.Walk(func(v *Endpoint) {
+		if strings.EqualFold(v.Method, method) {
+			result = v
+		}
+	})
+	return result
+}
+
+// allow returns the comma separated list of the methods defined within the Endpoints
+func (e *Endpoints) allow() string {
+	methods := make([]string, 0)
+	e.Walk(func(endpoint *Endpoint) {
+		methods = append(methods, strings.ToUpper(endpoint.Method))
+	})
+	sort.Strings(methods)
+	return strings.Join(methods, ", ")
+}
+
+// set assigns the endpoint to the field matching the specified method
+func (e *Endpoints) set(method string, endpoint *Endpoint) {
+	switch strings.ToUpper(method) {
+	case http.MethodDelete:
+		e.Delete = endpoint
+	case http.MethodGet:
+		e.Get = endpoint
+	case http.MethodHead:
+		e.Head = endpoint
+	case http.MethodOptions:
+		e.Options = endpoint
+	case http.MethodPost:
+		e.Post = endpoint
+	case http.MethodPut:
+		e.Put = endpoint
+	case http.MethodPatch:
+		e.Patch = endpoint
+	case http.MethodTrace:
+		e.Trace = endpoint
+	case http.MethodConnect:
+		e.Connect = endpoint
+	default:
+		panic(fmt.Errorf("invalid method, %v", method))
+	}
+}
+
 // API provides the top level encapsulation for the swagger definition
 type API struct {
 	Swagger             string                    `json:"swagger,omitempty"`
 	Info                Info                      `json:"info"`
 	BasePath            string                    `json:"basePath,omitempty"`
 	Schemes             []string                  `json:"schemes,omitempty"`
+	Consumes            []string                  `json:"consumes,omitempty"`
+	Produces            []string                  `json:"produces,omitempty"`
 	Paths               map[string]*Endpoints     `json:"paths,omitempty"`
 	Definitions         map[string]Object         `json:"definitions,omitempty"`
+	Parameters          map[string]Parameter      `json:"parameters,omitempty"`
+	Responses           map[string]Response       `json:"responses,omitempty"`
 	Tags                []Tag                     `json:"tags,omitempty"`
 	Host                string                    `json:"host,omitempty"`
 	SecurityDefinitions map[string]SecurityScheme `json:"securityDefinitions,omitempty"`
 	Security            *SecurityRequirement      `json:"security,omitempty"`
 
+	// Compact omits per-operation produces/consumes matching the global defaults
+	// and empty arrays/maps when the definition is encoded; without global defaults,
+	// the media types shared by most operations are encoded as the defaults
+	Compact bool `json:"-"`
+	// Render controls how the definition is encoded
+	Render RenderOptions `json:"-"`
+	// Overlays are applied in order to the rendered definition
+	Overlays []*Overlay `json:"-"`
+	// Filters decide which endpoints are kept in the rendered definition
+	Filters []Filter `json:"-"`
+	// Versioning declares the header based versioning of the api
+	Versioning *Versioning `json:"-"`
+	// MethodNotAllowed documents a 405 response listing the allowed methods on every operation
+	MethodNotAllowed bool `json:"-"`
+	// InternalDefinitions are left out of the rendered definition,
+	// and the references to them are rendered according to their mode
+	InternalDefinitions map[string]DefinitionMode `json:"-"`
+	// Audience selects the properties rendered; properties restricted to other audiences are stripped,
+	// and empty means the public definition without any restricted property
+	Audience string `json:"-"`
+	// InferTags tags the endpoints added without any tag after the first static segment of their path,
+	// e.g. /billing/invoices is tagged billing
+	InferTags bool `json:"-"`
+	// OpenAPI is the OpenAPI 3 version of the rendered document, e.g. 3.0.3; empty means swagger 2.0
+	OpenAPI string `json:"-"`
+	// OpenAPIHooks mutate the typed model of the OpenAPI 3 document before it is encoded
+	OpenAPIHooks []OpenAPIHook `json:"-"`
+	// GlobalResponses are documented on every operation, unless the operation already defines a response
+	// for the code; with GlobalResponsesOptIn, only the operations using them document them
+	GlobalResponses      map[string]Response `json:"-"`
+	GlobalResponsesOptIn bool                `json:"-"`
+	// Extensions are the vendor extensions of the root of the definition
+	Extensions map[string]interface{} `json:"-"`
+	// EnvVars whitelists the environment variables replacing their ${NAME} placeholders in the host,
+	// the base path and the info when the definition is rendered
+	EnvVars []string `json:"-"`
+	// ServeOrigin decides the host, basePath and schemes of the definition served for a request;
+	// nil means RequestOrigin, and ConfiguredOrigin keeps the configured values
+	ServeOrigin func(req *http.Request) Origin `json:"-"`
+	// Renderer renders the docs page of the handlers; nil means RendererRedoc
+	Renderer Renderer `json:"-"`
+	// Envelope wraps the response bodies of the operations and of the responses section in a common model
+	// when the definition is rendered
+	Envelope *Envelope `json:"-"`
+
 	tags       []Tag
 	prefixPath string
 }
 
 func (a *API) Clone() *API {
 	return &API{
-		Swagger:             a.Swagger,
-		Info:                a.Info,
-		BasePath:            a.BasePath,
-		Schemes:             a.Schemes,
-		Paths:               a.Paths,
-		Definitions:         a.Definitions,
-		Tags:                a.Tags,
-		Host:                a.Host,
-		SecurityDefinitions: a.SecurityDefinitions,
-		Security:            a.Security,
+		Swagger:              a.Swagger,
+		Info:                 a.Info,
+		BasePath:             a.BasePath,
+		Schemes:              a.Schemes,
+		Consumes:             a.Consumes,
+		Produces:             a.Produces,
+		Paths:                a.Paths,
+		Definitions:          a.Definitions,
+		Parameters:           a.Parameters,
+		Responses:            a.Responses,
+		Tags:                 a.Tags,
+		Host:                 a.Host,
+		SecurityDefinitions:  a.SecurityDefinitions,
+		Security:             a.Security,
+		Compact:              a.Compact,
+		Render:               a.Render,
+		Overlays:             a.Overlays,
+		Filters:              a.Filters,
+		Versioning:           a.Versioning,
+		MethodNotAllowed:     a.MethodNotAllowed,
+		InternalDefinitions:  a.InternalDefinitions,
+		Audience:             a.Audience,
+		InferTags:            a.InferTags,
+		OpenAPI:              a.OpenAPI,
+		OpenAPIHooks:         a.OpenAPIHooks,
+		GlobalResponses:      a.GlobalResponses,
+		GlobalResponsesOptIn: a.GlobalResponsesOptIn,
+		Extensions:           a.Extensions,
+		EnvVars:              a.EnvVars,
+		ServeOrigin:          a.ServeOrigin,
+		Renderer:             a.Renderer,
+		Envelope:             a.Envelope,
 	}
 }
 
@@ -213,29 +391,7 @@ func (a *API) addPath(e *Endpoint) {
 		v = &Endpoints{}
 		a.Paths[e.Path] = v
 	}
-
-	switch strings.ToUpper(e.Method) {
-	case http.MethodDelete:
-		v.Delete = e
-	case http.MethodGet:
-		v.Get = e
-	case http.MethodHead:
-		v.Head = e
-	case http.MethodOptions:
-		v.Options = e
-	case http.MethodPost:
-		v.Post = e
-	case http.MethodPut:
-		v.Put = e
-	case http.MethodPatch:
-		v.Patch = e
-	case http.MethodTrace:
-		v.Trace = e
-	case http.MethodConnect:
-		v.Connect = e
-	default:
-		panic(fmt.Errorf("invalid method, %v", e.Method))
-	}
+	v.set(e.Method, e)
 }
 
 func (a *API) addDefinition(e *Endpoint) {
@@ -245,31 +401,69 @@ func (a *API) addDefinition(e *Endpoint) {
 
 	if e.Parameters != nil {
 		for _, p := range e.Parameters {
-			if p.Schema != nil {
-				def := define(p.Schema.Prototype)
-				for k, v := range def {
-					if _, ok := a.Definitions[k]; !ok {
-						a.Definitions[k] = v
-					}
-				}
+			if p.Schema != nil && p.Schema.Prototype != nil {
+				a.mergeDefinitions(p.Schema.Prototype)
 			}
 		}
 	}
 
 	if e.Responses != nil {
 		for _, response := range e.Responses {
-			if response.Schema != nil {
-				def := define(response.Schema.Prototype)
-				for k, v := range def {
-					if _, ok := a.Definitions[k]; !ok {
-						a.Definitions[k] = v
-					}
-				}
+			if response.Schema != nil && response.Schema.Prototype != nil {
+				a.mergeDefinitions(response.Schema.Prototype)
+			}
+			if response.Schema != nil && response.Schema.Prototype == nil {
+				a.mergeNamedDefinition(response.Schema.Ref)
 			}
 		}
 	}
 }
 
+// mergeNamedDefinition adds the definition of the prototype registered under the name of the reference,
+// so that the reference resolves under the name chosen by the caller, along with the types it references
+func (a *API) mergeNamedDefinition(ref string) {
+	if !strings.HasPrefix(ref, definitionPrefix) {
+		return
+	}
+	name := strings.TrimPrefix(ref, definitionPrefix)
+	prototype, ok := TypeByName(name)
+	if !ok {
+		return
+	}
+	if _, ok := a.Definitions[name]; ok {
+		return
+	}
+	root := defineObject(prototype, "")
+	for k, v := range define(prototype) {
+		if k == root.Name {
+			k = name
+		}
+		if _, ok := a.Definitions[k]; !ok {
+			a.Definitions[k] = v
+		}
+	}
+}
+
+// mergeDefinitions adds the definitions of the prototype and the types it references
+func (a *API) mergeDefinitions(prototype interface{}) {
+	if a.Definitions == nil {
+		a.Definitions = map[string]Object{}
+	}
+	for k, v := range define(prototype) {
+		if _, ok := a.Definitions[k]; !ok {
+			a.Definitions[k] = v
+		}
+	}
+}
+
+// AddDefinitions adds the definitions of the prototypes even if no endpoint references them,
+// e.g. models only referenced via interfaces or raw json
+func (a *API) AddDefinitions(prototypes ...interface{}) {
+	for _, prototype := range prototypes {
+		a.mergeDefinitions(prototype)
+	}
+}
+
 func (a *API) clean() {
 	a.tags = nil
 	a.prefixPath = ""
@@ -293,6 +487,23 @@ func (a *API) WithTags(tags ...Tag) *API {
 	return a
 }
 
+// inferTag tags the endpoint after the first static segment of its path
+func (a *API) inferTag(e *Endpoint) {
+	for _, segment := range strings.Split(e.Path, "/") {
+		if segment == "" || strings.HasPrefix(segment, "{") || strings.HasPrefix(segment, ":") {
+			continue
+		}
+		e.Tags = []string{segment}
+		for _, tag := range a.Tags {
+			if tag.Name == segment {
+				return
+			}
+		}
+		a.Tags = append(a.Tags, Tag{Name: segment})
+		return
+	}
+}
+
 func (a *API) WithTag(name, description string) *API {
 	return a.WithTags(Tag{Name: name, Description: description})
 }
@@ -305,18 +516,80 @@ func (a *API) WithGroup(prefixPath string) *API {
 // AddEndpoint adds the specified endpoint to the API definition;
 // to generate an endpoint use ```endpoint.New```
 func (a *API) AddEndpoint(es ...*Endpoint) {
+	tags := a.groupTags()
+	for _, e := range es {
+		a.addEndpoint(e, tags)
+	}
+	a.clean()
+}
+
+// addSecuritySchemes registers the security schemes of the endpoint not defined by the api,
+// as well as the registered schemes its security requirements refer to, see RegisterSecurityScheme
+func (a *API) addSecuritySchemes(e *Endpoint) {
+	add := func(name string, scheme SecurityScheme) {
+		if _, ok := a.SecurityDefinitions[name]; ok {
+			return
+		}
+		if a.SecurityDefinitions == nil {
+			a.SecurityDefinitions = make(map[string]SecurityScheme)
+		}
+		a.SecurityDefinitions[name] = scheme
+	}
+	for name, scheme := range e.SecuritySchemes {
+		add(name, scheme)
+	}
+	if e.Security == nil {
+		return
+	}
+	for _, requirement := range e.Security.Requirements {
+		for name := range requirement {
+			if scheme, ok := SecuritySchemeByName(name); ok {
+				add(name, scheme)
+			}
+		}
+	}
+}
+
+func (a *API) groupTags() []string {
 	tags := make([]string, 0, len(a.tags))
 	for _, tag := range a.tags {
 		tags = append(tags, tag.Name)
 	}
-	for _, e := range es {
-		e.Path = path.Join(a.prefixPath, e.Path)
-		e.Tags = append(e.Tags, tags...)
-		e.BuildOperationID()
+	return tags
+}
+
+func (a *API) addEndpoint(e *Endpoint, tags []string) {
+	e.Path = path.Join(a.prefixPath, e.Path)
+	e.Tags = append(e.Tags, tags...)
+	if len(e.Tags) == 0 && a.InferTags {
+		a.inferTag(e)
+	}
+	e.BuildOperationID()
+	a.addSecuritySchemes(e)
+	if len(e.Versions) > 0 {
+		a.addVariant(e)
+	} else {
 		a.addPath(e)
-		a.addDefinition(e)
 	}
-	a.clean()
+	a.addDefinition(e)
+	if e.FieldSelection {
+		a.addFieldSelection(e)
+	}
+}
+
+// AddEndpointContext is like AddEndpoint, but stops with the error of the context once it is done;
+// the endpoints added before the cancellation are kept
+func (a *API) AddEndpointContext(ctx context.Context, es ...*Endpoint) error {
+	defer a.clean()
+
+	tags := a.groupTags()
+	for _, e := range es {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		a.addEndpoint(e, tags)
+	}
+	return nil
 }
 
 // AddOptions adds some options
@@ -347,31 +620,46 @@ func (a *API) AddTag(name, description string) {
 // cors headers
 func (a *API) Handler() http.HandlerFunc {
 	return func(w http.ResponseWriter, req *http.Request) {
-		// customize the swagger header based on host
-		scheme := ""
-		if req.TLS != nil {
-			scheme = "https"
-		}
-		if v := req.Header.Get("X-Forwarded-Proto"); v != "" {
-			scheme = v
-		}
-		if scheme == "" {
-			scheme = req.URL.Scheme
-		}
-		if scheme == "" {
-			scheme = "http"
+		// the definition is rendered before answering, so that its errors are answered with 500
+		var buf bytes.Buffer
+		if err := a.requestDoc(req).EncodeContext(req.Context(), &buf); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
 		}
-		doc := a.Clone()
-		doc.Host = req.Host
-		doc.Schemes = []string{scheme}
-
 		w.Header().Set("Content-Type", "application/json")
+		body, done := compressWriter(w, req)
+		defer done()
 		w.WriteHeader(http.StatusOK)
-		_ = json.NewEncoder(w).Encode(doc)
+		_, _ = buf.WriteTo(body)
 	}
 }
 
-// Walk invoke the callback for each endpoint defined in the swagger doc
+// requestDoc returns the definition rendered for the request
+func (a *API) requestDoc(req *http.Request) *API {
+	origin := RequestOrigin
+	if a.ServeOrigin != nil {
+		origin = a.ServeOrigin
+	}
+	doc := a.RenderVersion(a.requestVersion(req))
+	origin(req).apply(doc)
+	return doc
+}
+
+// EffectiveSecurity returns the security requirements applying to the endpoint: its own requirements,
+// or the default security of the api if it declares none; empty means no security
+func (a *API) EffectiveSecurity(e *Endpoint) []map[string][]string {
+	security := e.Security
+	if security == nil {
+		security = a.Security
+	}
+	if security == nil || security.DisableSecurity {
+		return nil
+	}
+	return security.Requirements
+}
+
+// Walk invoke the callback for each endpoint defined in the swagger doc;
+// the versioned variants of the operations are visited by WalkVersions
 func (a *API) Walk(callback func(path string, endpoint *Endpoint)) {
 	for rawPath, endpoints := range a.Paths {
 		u := path.Join(a.BasePath, rawPath)
@@ -390,19 +678,28 @@ func UIPatterns(prefix string) []string {
 	patterns := make([]string, 0, len(files)+1)
 	patterns = append(patterns, path.Join(prefix)+"/")
 	for _, f := range files {
+		if isPrecompressed(f.Name()) {
+			continue
+		}
 		patterns = append(patterns, path.Join(prefix, f.Name()))
 	}
 	return patterns
 }
 
-// UIHandler returns a http.Handler by the specify path prefix and the full path
+// UIHandler returns a http.Handler by the specify path prefix and the full path;
+// it is the same as SwaggerUI with UIPrefix, and UIAutoDomain if autoDomain is true
 func UIHandler(prefix, uri string, autoDomain bool) http.Handler {
-	return http.StripPrefix(prefix, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "" {
-			url := strings.TrimSuffix(prefix, "/") + "/"
-			http.Redirect(w, r, url, http.StatusFound)
-			return
-		}
+	opts := []UIOption{UIPrefix(prefix)}
+	if autoDomain {
+		opts = append(opts, UIAutoDomain())
+	}
+	return SwaggerUI(uri, opts...)
+}
+
+// uiFiles serves the swagger ui files by their path without the prefix,
+// rendering the index page with the spec at uri
+func uiFiles(uri string, c uiConfig) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path == "/" || r.URL.Path == "index.html" {
 			fullName := path.Join(asserts.DistDir, "index.html")
 			fileData, err := asserts.Dist.ReadFile(fullName)
@@ -411,6 +708,7 @@ func UIHandler(prefix, uri string, autoDomain bool) http.Handler {
 				_, _ = w.Write([]byte("index.html read exception"))
 				return
 			}
+			fileData = c.apply(fileData)
 			if uri == "" {
 				_, _ = w.Write(fileData)
 				return
@@ -418,7 +716,7 @@ func UIHandler(prefix, uri string, autoDomain bool) http.Handler {
 
 			// Prevent uri assignment from causing final uri exception.
 			currentURI := uri
-			if autoDomain {
+			if c.autoDomain {
 				scheme := ""
 				if r.TLS != nil {
 					scheme = "https"
@@ -439,6 +737,6 @@ func UIHandler(prefix, uri string, autoDomain bool) http.Handler {
 			_, _ = w.Write(fileData)
 			return
 		}
-		http.FileServer(DirFS(asserts.DistDir, asserts.Dist)).ServeHTTP(w, r)
-	}))
+		serveAsset(w, r)
+	})
 }
@@ -15,6 +15,7 @@
 package swag
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -37,6 +38,19 @@ func TestEndpoints_ServeHTTPNotFound(t *testing.T) {
 	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
+func TestEndpoints_ServeHTTPMethodNotAllowed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodDelete, "http://localhost", nil)
+	w := httptest.NewRecorder()
+
+	es := Endpoints{
+		Get:  &Endpoint{Method: http.MethodGet},
+		Post: &Endpoint{Method: http.MethodPost},
+	}
+	es.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, POST", w.Header().Get("Allow"))
+}
+
 func TestEndpoints_ServeHTTP(t *testing.T) {
 	fn := func(v string) *Endpoint {
 		return &Endpoint{
@@ -78,6 +92,17 @@ func TestEndpoints_ServeHTTP(t *testing.T) {
 	}
 }
 
+func TestEndpoints_endpoint(t *testing.T) {
+	get := &Endpoint{Method: http.MethodGet}
+	post := &Endpoint{Method: "post"}
+	e := &Endpoints{Get: get, Post: post}
+
+	assert.Equal(t, get, e.endpoint("get"))
+	assert.Equal(t, post, e.endpoint(http.MethodPost))
+	assert.Nil(t, e.endpoint(http.MethodDelete))
+	assert.Equal(t, "GET, POST", e.allow())
+}
+
 func TestAPI_AddOptions(t *testing.T) {
 	type args struct {
 		options []Option
@@ -525,6 +550,21 @@ func TestAPI_addPath(t *testing.T) {
 	}
 }
 
+func TestAPI_EffectiveSecurity(t *testing.T) {
+	api := New()
+	inherited := &Endpoint{}
+	assert.Nil(t, api.EffectiveSecurity(inherited))
+
+	api.Security = &SecurityRequirement{Requirements: []map[string][]string{{"api_key": {}}}}
+	assert.Equal(t, []map[string][]string{{"api_key": {}}}, api.EffectiveSecurity(inherited))
+
+	overridden := &Endpoint{Security: &SecurityRequirement{Requirements: []map[string][]string{{"oauth": {"read"}}}}}
+	assert.Equal(t, []map[string][]string{{"oauth": {"read"}}}, api.EffectiveSecurity(overridden))
+
+	public := &Endpoint{Security: &SecurityRequirement{DisableSecurity: true}}
+	assert.Nil(t, api.EffectiveSecurity(public))
+}
+
 func TestAPI_Walk(t *testing.T) {
 	type fields struct {
 		Endpoints []*Endpoint
@@ -806,3 +846,41 @@ func TestAPI_WithTag(t *testing.T) {
 		})
 	}
 }
+
+func TestAPI_InferTags(t *testing.T) {
+	a := New()
+	a.InferTags = true
+	a.AddTag("billing", "the billing api")
+	a.AddEndpoint(
+		&Endpoint{Method: http.MethodGet, Path: "/billing/invoices"},
+		&Endpoint{Method: http.MethodGet, Path: "/{tenant}/users"},
+		&Endpoint{Method: http.MethodGet, Path: "/orders", Tags: []string{"shop"}},
+		&Endpoint{Method: http.MethodGet, Path: "/"},
+	)
+	a.WithGroup("/billing").AddEndpoint(&Endpoint{Method: http.MethodPost, Path: "/refunds"})
+
+	assert.Equal(t, []string{"billing"}, a.Paths["/billing/invoices"].Get.Tags)
+	assert.Equal(t, []string{"users"}, a.Paths["/{tenant}/users"].Get.Tags)
+	assert.Equal(t, []string{"shop"}, a.Paths["/orders"].Get.Tags)
+	assert.Empty(t, a.Paths["/"].Get.Tags)
+	assert.Equal(t, []string{"billing"}, a.Paths["/billing/refunds"].Post.Tags)
+	assert.Equal(t, []Tag{{Name: "billing", Description: "the billing api"}, {Name: "users"}}, a.Tags)
+}
+
+func TestAPI_AddEndpointContext(t *testing.T) {
+	a := New()
+	err := a.WithGroup("/v1").AddEndpointContext(context.Background(),
+		&Endpoint{Method: http.MethodGet, Path: "/pets"},
+		&Endpoint{Method: http.MethodGet, Path: "/owners"},
+	)
+	assert.NoError(t, err)
+	assert.Contains(t, a.Paths, "/v1/pets")
+	assert.Contains(t, a.Paths, "/v1/owners")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = a.WithGroup("/v2").AddEndpointContext(ctx, &Endpoint{Method: http.MethodGet, Path: "/pets"})
+	assert.Equal(t, context.Canceled, err)
+	assert.NotContains(t, a.Paths, "/v2/pets")
+	assert.Empty(t, a.prefixPath)
+}
@@ -22,5 +22,10 @@ const DistDir = "dist"
 
 var URL = "https://petstore.swagger.io/v2/swagger.json"
 
+// Dist holds the swagger ui assets, along with the brotli (.br) and gzip (.gz) copies of the largest ones,
+// to be regenerated with go generate whenever the assets are updated, see the precompress command
+//
 //go:embed dist
 var Dist embed.FS
+
+//go:generate go -C precompress run . ../dist
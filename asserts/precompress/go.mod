@@ -0,0 +1,8 @@
+module github.com/zc2638/swag/asserts/precompress
+
+go 1.16
+
+require (
+	github.com/andybalholm/brotli v1.1.0
+	github.com/stretchr/testify v1.7.1
+)
@@ -0,0 +1,85 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const distDir = "../dist"
+
+// TestDist checks that each precompressed copy of the dist directory decompresses to its source asset
+func TestDist(t *testing.T) {
+	readers := map[string]func(r io.Reader) (io.Reader, error){
+		".br": func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil },
+		".gz": func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+	}
+
+	files, err := os.ReadDir(distDir)
+	require.NoError(t, err)
+	compressed := 0
+	for _, f := range files {
+		ext := filepath.Ext(f.Name())
+		reader, ok := readers[ext]
+		if !ok {
+			continue
+		}
+		compressed++
+		t.Run(f.Name(), func(t *testing.T) {
+			source, err := os.ReadFile(filepath.Join(distDir, strings.TrimSuffix(f.Name(), ext)))
+			require.NoError(t, err)
+			blob, err := os.ReadFile(filepath.Join(distDir, f.Name()))
+			require.NoError(t, err)
+
+			r, err := reader(bytes.NewReader(blob))
+			require.NoError(t, err)
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.True(t, bytes.Equal(source, data), "%s does not decompress to its source", f.Name())
+		})
+	}
+	assert.Equal(t, 2*len(assets), compressed)
+}
+
+func TestPrecompress(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "app.js")
+	source := []byte(strings.Repeat("console.log('swag');\n", 100))
+	require.NoError(t, os.WriteFile(name, source, 0o644))
+	require.NoError(t, precompress(name))
+
+	blob, err := os.ReadFile(name + ".br")
+	require.NoError(t, err)
+	data, err := io.ReadAll(brotli.NewReader(bytes.NewReader(blob)))
+	require.NoError(t, err)
+	assert.Equal(t, source, data)
+
+	blob, err = os.ReadFile(name + ".gz")
+	require.NoError(t, err)
+	zr, err := gzip.NewReader(bytes.NewReader(blob))
+	require.NoError(t, err)
+	data, err = io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, source, data)
+}
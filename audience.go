@@ -0,0 +1,70 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+// RenderAudience returns a copy of the api rendering the properties visible to the audience,
+// e.g. api.RenderAudience("internal").Handler() next to the public api.Handler()
+func (a *API) RenderAudience(audience string) *API {
+	doc := a.Clone()
+	doc.Audience = audience
+	return doc
+}
+
+// visible reports whether the property is rendered for the audience
+func (p Property) visible(audience string) bool {
+	if len(p.Audience) == 0 {
+		return true
+	}
+	return audience != "" && containsString(p.Audience, audience)
+}
+
+// forAudience returns a copy of the api in which the properties restricted to other audiences
+// are stripped from the definitions, or the api itself if none is
+func (a *API) forAudience() *API {
+	restricted := false
+	for _, obj := range a.Definitions {
+		for _, p := range obj.Properties {
+			if !p.visible(a.Audience) {
+				restricted = true
+			}
+		}
+	}
+	if !restricted {
+		return a
+	}
+
+	doc := a.Clone()
+	doc.Definitions = make(map[string]Object, len(a.Definitions))
+	for name, obj := range a.Definitions {
+		properties := make(map[string]Property, len(obj.Properties))
+		for k, p := range obj.Properties {
+			if p.visible(a.Audience) {
+				properties[k] = p
+			}
+		}
+		if len(properties) != len(obj.Properties) {
+			required := make([]string, 0, len(obj.Required))
+			for _, k := range obj.Required {
+				if _, ok := properties[k]; ok {
+					required = append(required, k)
+				}
+			}
+			obj.Properties = properties
+			obj.Required = required
+		}
+		doc.Definitions[name] = obj
+	}
+	return doc
+}
@@ -0,0 +1,55 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Partner struct {
+	Name   string `json:"name" required:""`
+	Margin int    `json:"margin" audience:"internal" required:""`
+	Region string `json:"region" swag:"audience=internal|partner"`
+}
+
+func TestAudience(t *testing.T) {
+	api := New()
+	api.AddEndpoint(&Endpoint{
+		Path:   "/partners",
+		Method: http.MethodGet,
+		Responses: map[string]Response{
+			"200": {Description: "ok", Schema: MakeSchema(Partner{})},
+		},
+	})
+	name := DefinitionName(Partner{})
+
+	public := decodeDoc(t, api).Definitions[name]
+	assert.Equal(t, []string{"name"}, public.Required)
+	assert.Len(t, public.Properties, 1)
+
+	partner := decodeDoc(t, api.RenderAudience("partner")).Definitions[name]
+	assert.Contains(t, partner.Properties, "region")
+	assert.NotContains(t, partner.Properties, "margin")
+
+	internal := decodeDoc(t, api.RenderAudience("internal")).Definitions[name]
+	assert.Equal(t, []string{"name", "margin"}, internal.Required)
+	assert.Len(t, internal.Properties, 3)
+
+	// the api itself is left untouched
+	assert.Len(t, api.Definitions[name].Properties, 3)
+}
@@ -0,0 +1,123 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+	"sync"
+
+	"github.com/zc2638/swag/types"
+)
+
+// BigNumberFormat represents how arbitrary precision numbers are documented
+type BigNumberFormat string
+
+const (
+	// BigNumberString documents the number as a string
+	BigNumberString BigNumberFormat = "string"
+	// BigNumberNumber documents the number as a json number
+	BigNumberNumber BigNumberFormat = "number"
+)
+
+type bigNumber struct {
+	integer bool
+	format  BigNumberFormat
+}
+
+var bigNumbers = struct {
+	sync.RWMutex
+	types map[reflect.Type]bigNumber
+	names map[string]bigNumber
+}{
+	// the default formats follow how each type is encoded to json
+	types: map[reflect.Type]bigNumber{
+		reflect.TypeOf(big.Int{}):   {integer: true, format: BigNumberNumber},
+		reflect.TypeOf(big.Float{}): {format: BigNumberString},
+		reflect.TypeOf(big.Rat{}):   {format: BigNumberString},
+	},
+	// types of third party packages are matched by name to avoid depending on them
+	names: map[string]bigNumber{
+		"github.com/shopspring/decimal.Decimal":     {format: BigNumberString},
+		"github.com/shopspring/decimal.NullDecimal": {format: BigNumberString},
+	},
+}
+
+// RegisterBigNumber documents the custom arbitrary precision type in the specified format;
+// the bignum tag, e.g. bignum:"number", overrides it per field,
+// and the precision tag adds the x-precision hint
+func RegisterBigNumber(prototype interface{}, integer bool, format BigNumberFormat) {
+	t := reflect.TypeOf(prototype)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	bigNumbers.Lock()
+	defer bigNumbers.Unlock()
+	bigNumbers.types[t] = bigNumber{integer: integer, format: format}
+}
+
+func lookupBigNumber(t reflect.Type) (bigNumber, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	bigNumbers.RLock()
+	defer bigNumbers.RUnlock()
+	if n, ok := bigNumbers.types[t]; ok {
+		return n, true
+	}
+	if t.Name() == "" {
+		return bigNumber{}, false
+	}
+	n, ok := bigNumbers.names[t.PkgPath()+"."+t.Name()]
+	return n, ok
+}
+
+// applyBigNumber documents the property as an arbitrary precision number,
+// using the specified format instead of the default format of the type if not empty
+func applyBigNumber(p *Property, n bigNumber, format BigNumberFormat) {
+	if format == "" {
+		format = n.format
+	}
+
+	p.Ref = ""
+	switch {
+	case format == BigNumberString && n.integer:
+		p.Type = types.String.String()
+		p.Format = "big-integer"
+		p.Pattern = `^-?[0-9]+$`
+	case format == BigNumberString:
+		p.Type = types.String.String()
+		p.Format = "decimal"
+		p.Pattern = `^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`
+	case n.integer:
+		p.Type = types.Integer.String()
+		p.Format = "big-integer"
+		p.Pattern = ""
+	default:
+		p.Type = types.Number.String()
+		p.Format = "decimal"
+		p.Pattern = ""
+	}
+}
+
+// applyPrecision sets the x-precision hint of the property from the precision tag
+func applyPrecision(p *Property, tag string) {
+	if precision, err := strconv.Atoi(tag); err == nil && precision >= 0 {
+		p.Precision = &precision
+	}
+}
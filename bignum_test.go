@@ -0,0 +1,57 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Money struct {
+	units string
+}
+
+type Invoice struct {
+	Count  *big.Int   `json:"count"`
+	Total  big.Float  `json:"total" precision:"2"`
+	Rate   *big.Float `json:"rate" bignum:"number"`
+	Serial big.Int    `json:"serial" bignum:"string"`
+	Amount Money      `json:"amount" precision:"4"`
+	Parts  []*big.Int `json:"parts"`
+}
+
+func TestBigNumber(t *testing.T) {
+	RegisterBigNumber(&Money{}, false, BigNumberString)
+
+	objects := define(Invoice{})
+	assert.Len(t, objects, 1)
+	obj := objects["github.com_zc2638_swag.Invoice"]
+
+	precision := func(v int) *int { return &v }
+	assert.Equal(t, Property{Type: "integer", Format: "big-integer"}, withoutGoType(obj.Properties["count"]))
+	assert.Equal(t, Property{
+		Type:      "string",
+		Format:    "decimal",
+		Pattern:   `^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`,
+		Precision: precision(2),
+	}, withoutGoType(obj.Properties["total"]))
+	assert.Equal(t, Property{Type: "number", Format: "decimal"}, withoutGoType(obj.Properties["rate"]))
+	assert.Equal(t, Property{Type: "string", Format: "big-integer", Pattern: `^-?[0-9]+$`}, withoutGoType(obj.Properties["serial"]))
+	assert.Equal(t, "string", obj.Properties["amount"].Type)
+	assert.Equal(t, precision(4), obj.Properties["amount"].Precision)
+	assert.Equal(t, &Items{Type: "integer", Format: "big-integer"}, obj.Properties["parts"].Items)
+}
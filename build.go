@@ -0,0 +1,66 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+)
+
+// BuildExtension is the info extension carrying the BuildInfo of the api
+const BuildExtension = "x-build"
+
+// BuildInfo is the build metadata of the binary serving the api,
+// which lets the consumers correlate the versions of the spec with the deployments
+type BuildInfo struct {
+	Module    string `json:"module,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Commit    string `json:"commit,omitempty"`
+	Time      string `json:"time,omitempty"`
+	GoVersion string `json:"goVersion"`
+}
+
+// ReadBuildInfo returns the module path and version of the main module read from runtime/debug
+// along with the commit and the build time, which the go toolchain does not record,
+// e.g. set with -ldflags "-X main.commit=$(git rev-parse HEAD)"
+func ReadBuildInfo(commit, time string) BuildInfo {
+	b := BuildInfo{Commit: commit, Time: time, GoVersion: runtime.Version()}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		b.Module = info.Main.Path
+		b.Version = info.Main.Version
+	}
+	return b
+}
+
+// Build returns the build info set in info.x-build, see option.Build
+func (a *API) Build() (BuildInfo, bool) {
+	b, ok := a.Info.Extensions[BuildExtension].(BuildInfo)
+	return b, ok
+}
+
+// BuildHandler returns a http.Handler serving the build info of the api as json, or 404 if it is not set
+func (a *API) BuildHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		b, ok := a.Build()
+		if !ok {
+			http.NotFound(w, req)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(b)
+	})
+}
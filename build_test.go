@@ -0,0 +1,55 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInfo(t *testing.T) {
+	api := New()
+	_, ok := api.Build()
+	assert.False(t, ok)
+
+	w := httptest.NewRecorder()
+	api.BuildHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/build", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	api.Info.Extensions = map[string]interface{}{BuildExtension: ReadBuildInfo("0a1b2c3", "2022-06-01T00:00:00Z")}
+
+	w = httptest.NewRecorder()
+	api.BuildHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/build", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	var b BuildInfo
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &b))
+	assert.Equal(t, "0a1b2c3", b.Commit)
+	assert.Equal(t, runtime.Version(), b.GoVersion)
+
+	var buf bytes.Buffer
+	require.NoError(t, api.Encode(&buf))
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	build := doc["info"].(map[string]interface{})[BuildExtension].(map[string]interface{})
+	assert.Equal(t, "0a1b2c3", build["commit"])
+	assert.Equal(t, "2022-06-01T00:00:00Z", build["time"])
+}
@@ -0,0 +1,92 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import "strings"
+
+// inlineSingleUse removes the definitions referenced exactly once from the decoded document
+// and inlines them where they are referenced; shared and recursive definitions are kept as references
+func inlineSingleUse(doc interface{}) interface{} {
+	m, ok := doc.(map[string]interface{})
+	if !ok {
+		return doc
+	}
+	definitions, _ := m["definitions"].(map[string]interface{})
+	if len(definitions) == 0 {
+		return doc
+	}
+
+	counts := make(map[string]int)
+	countRefs(doc, func(name string) {
+		counts[name]++
+	})
+	graph := make(map[string][]string, len(definitions))
+	for name, def := range definitions {
+		countRefs(def, func(ref string) {
+			graph[name] = append(graph[name], ref)
+		})
+	}
+
+	single := make(map[string]interface{})
+	for name, def := range definitions {
+		if counts[name] == 1 && !reaches(graph, name, name, make(map[string]bool)) {
+			single[name] = def
+			delete(definitions, name)
+		}
+	}
+	if len(definitions) == 0 {
+		delete(m, "definitions")
+	}
+
+	h := &definitionHider{
+		internal: single,
+		visiting: make(map[string]bool),
+	}
+	return h.replace(doc)
+}
+
+// countRefs calls fn with the name of every definition referenced within the decoded json value
+func countRefs(v interface{}, fn func(name string)) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok && strings.HasPrefix(ref, definitionPrefix) {
+			fn(strings.TrimPrefix(ref, definitionPrefix))
+		}
+		for _, item := range value {
+			countRefs(item, fn)
+		}
+	case []interface{}:
+		for _, item := range value {
+			countRefs(item, fn)
+		}
+	}
+}
+
+// reaches reports whether the target definition is reachable from the references of the definition
+func reaches(graph map[string][]string, from, target string, seen map[string]bool) bool {
+	for _, next := range graph[from] {
+		if next == target {
+			return true
+		}
+		if seen[next] {
+			continue
+		}
+		seen[next] = true
+		if reaches(graph, next, target, seen) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Address struct {
+	City string `json:"city"`
+}
+
+type CreateOrderRequest struct {
+	Item     string  `json:"item"`
+	Shipping Address `json:"shipping"`
+}
+
+type Order struct {
+	ID      string  `json:"id"`
+	Billing Address `json:"billing"`
+}
+
+func TestAPI_EncodeInlineSingleUse(t *testing.T) {
+	api := New()
+	api.Render.InlineSingleUse = true
+	api.AddEndpoint(
+		&Endpoint{
+			Path:   "/orders",
+			Method: http.MethodPost,
+			Parameters: []Parameter{
+				{In: "body", Name: "body", Schema: MakeSchema(CreateOrderRequest{})},
+			},
+			Responses: map[string]Response{"200": {Description: "ok", Schema: MakeSchema(Order{})}},
+		},
+		&Endpoint{
+			Path:      "/orders/{id}",
+			Method:    http.MethodGet,
+			Responses: map[string]Response{"200": {Description: "ok", Schema: MakeSchema(Order{})}},
+		},
+		&Endpoint{
+			Path:      "/traces",
+			Method:    http.MethodGet,
+			Responses: map[string]Response{"200": {Description: "ok", Schema: MakeSchema(Meta{})}},
+		},
+	)
+
+	doc := decodeDoc(t, api)
+	assert.Contains(t, doc.Definitions, DefinitionName(Order{}))
+	assert.Contains(t, doc.Definitions, DefinitionName(Address{}))
+	assert.Contains(t, doc.Definitions, DefinitionName(Meta{}))
+	assert.NotContains(t, doc.Definitions, DefinitionName(CreateOrderRequest{}))
+
+	body := doc.Paths["/orders"].Post.Parameters[0].Schema
+	assert.Empty(t, body.Ref)
+	assert.Equal(t, "object", body.Type)
+	assert.Equal(t, "#/definitions/"+DefinitionName(Address{}), body.Properties["shipping"].Ref)
+	assert.Equal(t, "#/definitions/"+DefinitionName(Order{}), doc.Paths["/orders/{id}"].Get.Responses["200"].Schema.Ref)
+	assert.Equal(t, "#/definitions/"+DefinitionName(Meta{}), doc.Paths["/traces"].Get.Responses["200"].Schema.Ref)
+}
+
+func TestInlineSingleUseChain(t *testing.T) {
+	doc := map[string]interface{}{
+		"definitions": map[string]interface{}{
+			"A": map[string]interface{}{"type": "object", "properties": map[string]interface{}{
+				"b": map[string]interface{}{"$ref": "#/definitions/B"},
+			}},
+			"B": map[string]interface{}{"type": "string"},
+			"C": map[string]interface{}{"$ref": "#/definitions/D"},
+			"D": map[string]interface{}{"$ref": "#/definitions/C"},
+		},
+		"schema": map[string]interface{}{"$ref": "#/definitions/A"},
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"definitions": map[string]interface{}{
+			"C": map[string]interface{}{"$ref": "#/definitions/D"},
+			"D": map[string]interface{}{"$ref": "#/definitions/C"},
+		},
+		"schema": map[string]interface{}{"type": "object", "properties": map[string]interface{}{
+			"b": map[string]interface{}{"type": "string"},
+		}},
+	}, inlineSingleUse(doc))
+}
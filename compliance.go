@@ -0,0 +1,134 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// SensitiveField represents a sensitive field accepted or returned by an operation
+type SensitiveField struct {
+	// Location is where the field appears, e.g. body or response 200
+	Location string `json:"location"`
+	// Field is the path of the field inside the payload, e.g. cards[].number
+	Field string `json:"field"`
+}
+
+// SensitiveOperation represents an operation accepting or returning sensitive fields
+type SensitiveOperation struct {
+	OperationID string `json:"operationId"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	// Version is the version of a versioned variant, empty for the default operation
+	Version string           `json:"version,omitempty"`
+	Fields  []SensitiveField `json:"fields"`
+}
+
+// SensitiveReport lists every operation and versioned variant accepting or returning fields tagged sensitive,
+// sorted by path, method and version, for data protection audits
+func (a *API) SensitiveReport() []SensitiveOperation {
+	report := make([]SensitiveOperation, 0)
+	a.walkOperations(func(version string, e *Endpoint) {
+		fields := make([]SensitiveField, 0)
+		for _, p := range e.Parameters {
+			for _, field := range a.sensitiveFields(p.Schema) {
+				fields = append(fields, SensitiveField{Location: p.In, Field: field})
+			}
+		}
+		codes := make([]string, 0, len(e.Responses))
+		for code := range e.Responses {
+			codes = append(codes, code)
+		}
+		sort.Strings(codes)
+		for _, code := range codes {
+			for _, field := range a.sensitiveFields(e.Responses[code].Schema) {
+				fields = append(fields, SensitiveField{Location: "response " + code, Field: field})
+			}
+		}
+		if len(fields) == 0 {
+			return
+		}
+		report = append(report, SensitiveOperation{
+			OperationID: e.OperationID,
+			Method:      strings.ToUpper(e.Method),
+			Path:        e.Path,
+			Version:     version,
+			Fields:      fields,
+		})
+	})
+	return report
+}
+
+// sensitiveFields returns the paths of the sensitive fields of the schema
+func (a *API) sensitiveFields(schema *Schema) []string {
+	if schema == nil {
+		return nil
+	}
+	var fields []string
+	a.collectSensitive(schema.Ref, schema.Properties, schema.Items, "", map[string]bool{}, &fields)
+	return fields
+}
+
+// collectSensitive appends the paths of the sensitive fields of the schema, given by its reference,
+// its inline properties and its items, to fields
+func (a *API) collectSensitive(ref string, properties map[string]Property, items *Items, prefix string, visiting map[string]bool, fields *[]string) {
+	if ref != "" {
+		name := refName(ref)
+		if visiting[name] {
+			return
+		}
+		visiting[name] = true
+		defer delete(visiting, name)
+		properties = a.Definitions[name].Properties
+	}
+	if items != nil {
+		a.collectSensitive(items.Ref, items.Properties, items.Items, prefix+"[]", visiting, fields)
+	}
+
+	keys := make([]string, 0, len(properties))
+	for k := range properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := properties[k]
+		field := k
+		if prefix != "" {
+			field = prefix + "." + k
+		}
+		if p.Sensitive {
+			*fields = append(*fields, field)
+			continue
+		}
+		a.collectSensitive(p.Ref, p.Properties, p.Items, field, visiting, fields)
+	}
+}
+
+// WriteSensitiveReport writes the report as a tab aligned table to w
+func WriteSensitiveReport(w io.Writer, report []SensitiveOperation) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "OPERATION\tMETHOD\tPATH\tVERSION\tLOCATION\tFIELD")
+	for _, op := range report {
+		for _, field := range op.Fields {
+			_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", op.OperationID, op.Method, op.Path, op.Version, field.Location, field.Field)
+		}
+	}
+	return tw.Flush()
+}
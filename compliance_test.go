@@ -0,0 +1,120 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPI_SensitiveReport(t *testing.T) {
+	api := New()
+	api.AddEndpoint(
+		&Endpoint{
+			Path:   "/patients",
+			Method: http.MethodPost,
+			Parameters: []Parameter{
+				{In: "body", Name: "body", Schema: MakeSchema(Patient{})},
+			},
+			Responses: map[string]Response{
+				"200": {Description: "ok", Schema: MakeSchema([]Card{})},
+			},
+		},
+		&Endpoint{
+			Path:   "/brands",
+			Method: http.MethodGet,
+			Responses: map[string]Response{
+				"200": {Description: "ok", Schema: MakeSchema(Street{})},
+			},
+		},
+	)
+
+	report := api.SensitiveReport()
+	assert.Equal(t, []SensitiveOperation{
+		{
+			OperationID: "postPatients",
+			Method:      "POST",
+			Path:        "/patients",
+			Fields: []SensitiveField{
+				{Location: "body", Field: "age"},
+				{Location: "body", Field: "card.number"},
+				{Location: "body", Field: "cards[].number"},
+				{Location: "body", Field: "name"},
+				{Location: "response 200", Field: "[].number"},
+			},
+		},
+	}, report)
+
+	var buf bytes.Buffer
+	assert.NoError(t, WriteSensitiveReport(&buf, report))
+	assert.Equal(t, `OPERATION     METHOD  PATH       VERSION  LOCATION      FIELD
+postPatients  POST    /patients           body          age
+postPatients  POST    /patients           body          card.number
+postPatients  POST    /patients           body          cards[].number
+postPatients  POST    /patients           body          name
+postPatients  POST    /patients           response 200  [].number
+`, buf.String())
+}
+
+func TestAPI_SensitiveReportInlineAndVariants(t *testing.T) {
+	api := New()
+	api.Versioning = &Versioning{Header: "Accept", Versions: []string{"2"}}
+	api.AddEndpoint(
+		&Endpoint{
+			Path:   "/owners",
+			Method: http.MethodGet,
+			Responses: map[string]Response{
+				"200": {Description: "ok", Schema: &Schema{Type: "object", Properties: map[string]Property{
+					"owner": {Type: "object", Properties: map[string]Property{
+						"ssn": {Type: "string", Sensitive: true},
+					}},
+					"phones": {Type: "array", Items: &Items{Type: "array", Items: &Items{Type: "object", Properties: map[string]Property{
+						"number": {Type: "string", Sensitive: true},
+					}}}},
+				}}},
+			},
+		},
+		&Endpoint{
+			Path:     "/owners",
+			Method:   http.MethodGet,
+			Versions: []string{"2"},
+			Responses: map[string]Response{
+				"200": {Description: "ok", Schema: MakeSchema([]Card{})},
+			},
+		},
+	)
+
+	assert.Equal(t, []SensitiveOperation{
+		{
+			OperationID: "getOwners",
+			Method:      "GET",
+			Path:        "/owners",
+			Fields: []SensitiveField{
+				{Location: "response 200", Field: "owner.ssn"},
+				{Location: "response 200", Field: "phones[][].number"},
+			},
+		},
+		{
+			OperationID: "getOwners",
+			Method:      "GET",
+			Path:        "/owners",
+			Version:     "2",
+			Fields:      []SensitiveField{{Location: "response 200", Field: "[].number"}},
+		},
+	}, api.SensitiveReport())
+}
@@ -0,0 +1,110 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/zc2638/swag/asserts"
+)
+
+// precompressed are the encodings of the precompressed copies of the ui assets, by preference
+var precompressed = []struct {
+	encoding  string
+	extension string
+}{
+	{encoding: "br", extension: ".br"},
+	{encoding: "gzip", extension: ".gz"},
+}
+
+// isPrecompressed reports whether the asset is the precompressed copy of another one,
+// which is served in place of the original
+func isPrecompressed(name string) bool {
+	for _, p := range precompressed {
+		if strings.HasSuffix(name, p.extension) {
+			return true
+		}
+	}
+	return false
+}
+
+// serveAsset serves the ui asset of the request path, from its precompressed copy
+// in the best encoding accepted by the client if any
+func serveAsset(w http.ResponseWriter, r *http.Request) {
+	name := path.Join(asserts.DistDir, path.Clean("/"+r.URL.Path))
+	compressed := false
+	for _, p := range precompressed {
+		data, err := asserts.Dist.ReadFile(name + p.extension)
+		if err != nil {
+			continue
+		}
+		compressed = true
+		if !acceptsEncoding(r, p.encoding) {
+			continue
+		}
+		w.Header().Set("Content-Encoding", p.encoding)
+		w.Header().Add("Vary", "Accept-Encoding")
+		if ctype := mime.TypeByExtension(path.Ext(name)); ctype != "" {
+			w.Header().Set("Content-Type", ctype)
+		}
+		http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(data))
+		return
+	}
+	if compressed {
+		w.Header().Add("Vary", "Accept-Encoding")
+	}
+	http.FileServer(DirFS(asserts.DistDir, asserts.Dist)).ServeHTTP(w, r)
+}
+
+// acceptsEncoding reports whether the Accept-Encoding header of the request accepts the encoding
+func acceptsEncoding(r *http.Request, encoding string) bool {
+	for _, header := range r.Header.Values("Accept-Encoding") {
+		for _, v := range strings.Split(header, ",") {
+			parts := strings.Split(v, ";")
+			name := strings.TrimSpace(parts[0])
+			if name != encoding && name != "*" {
+				continue
+			}
+			rejected := false
+			for _, param := range parts[1:] {
+				param = strings.ReplaceAll(param, " ", "")
+				if param == "q=0" || strings.HasPrefix(param, "q=0.") && strings.Trim(param[4:], "0") == "" {
+					rejected = true
+				}
+			}
+			return !rejected
+		}
+	}
+	return false
+}
+
+// compressWriter returns the writer of a response body, gzip compressed if the client accepts it,
+// along with the function to call once the body is written
+func compressWriter(w http.ResponseWriter, r *http.Request) (io.Writer, func()) {
+	w.Header().Add("Vary", "Accept-Encoding")
+	if !acceptsEncoding(r, "gzip") {
+		return w, func() {}
+	}
+	w.Header().Set("Content-Encoding", "gzip")
+	zw := gzip.NewWriter(w)
+	return zw, func() { _ = zw.Close() }
+}
@@ -0,0 +1,110 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zc2638/swag/asserts"
+)
+
+func TestSwaggerUI_Precompressed(t *testing.T) {
+	h := SwaggerUI("/swagger.json")
+	get := func(target, acceptEncoding string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		if acceptEncoding != "" {
+			r.Header.Set("Accept-Encoding", acceptEncoding)
+		}
+		h.ServeHTTP(w, r)
+		return w
+	}
+	original, err := asserts.Dist.ReadFile("dist/swagger-ui.css")
+	require.NoError(t, err)
+
+	w := get("/swagger-ui.css", "gzip, deflate, br")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "br", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "text/css; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Less(t, w.Body.Len(), len(original))
+
+	w = get("/swagger-ui.css", "gzip, br;q=0")
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	zr, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, original, body)
+
+	w = get("/swagger-ui.css", "")
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "Accept-Encoding", w.Header().Get("Vary"))
+	assert.Equal(t, original, w.Body.Bytes())
+
+	// the assets without a precompressed copy are served as is
+	w = get("/favicon-32x32.png", "gzip")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+}
+
+func TestAPI_HandlerGzip(t *testing.T) {
+	api := New()
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	api.Handler().ServeHTTP(w, r)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+
+	zr, err := gzip.NewReader(w.Body)
+	require.NoError(t, err)
+	body, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, api.requestDoc(r).Encode(&buf))
+	assert.Equal(t, buf.String(), string(body))
+
+	w = httptest.NewRecorder()
+	api.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+}
+
+func TestAcceptsEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{header: "", want: false},
+		{header: "gzip", want: true},
+		{header: "deflate, gzip;q=0.8", want: true},
+		{header: "gzip;q=0", want: false},
+		{header: "gzip; q=0.000", want: false},
+		{header: "*", want: true},
+		{header: "br", want: false},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set("Accept-Encoding", tt.header)
+		assert.Equal(t, tt.want, acceptsEncoding(r, "gzip"), tt.header)
+	}
+}
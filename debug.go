@@ -0,0 +1,71 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// Logger receives the trace of the reflection of the models, e.g. a *log.Logger
+type Logger interface {
+	Printf(format string, v ...interface{})
+}
+
+type debugLoggerHolder struct {
+	logger Logger
+}
+
+var debugLogger atomic.Value
+
+func init() {
+	debugLogger.Store(debugLoggerHolder{})
+}
+
+// SetDebugLogger logs how each field of the reflected models is documented, or why it is skipped,
+// to find out why a field is missing from the definition; nil disables the logging, which is the default
+func SetDebugLogger(logger Logger) {
+	debugLogger.Store(debugLoggerHolder{logger: logger})
+}
+
+func debugf(format string, v ...interface{}) {
+	if logger := debugLogger.Load().(debugLoggerHolder).logger; logger != nil {
+		logger.Printf("swag: "+format, v...)
+	}
+}
+
+func debugEnabled() bool {
+	return debugLogger.Load().(debugLoggerHolder).logger != nil
+}
+
+// debugProperty logs the classification of the field of the struct documented as the property
+func debugProperty(t reflect.Type, field reflect.StructField, name string, p Property) {
+	if !debugEnabled() {
+		return
+	}
+	kind := "type=" + p.Type
+	switch {
+	case p.Ref != "":
+		kind = "ref=" + p.Ref
+	case p.Items != nil && p.Items.Ref != "":
+		kind += " items.ref=" + p.Items.Ref
+	case p.Items != nil:
+		kind += " items.type=" + p.Items.Type
+	}
+	if p.Format != "" {
+		kind += " format=" + p.Format
+	}
+	debugf("%s.%s (%s) documented as %q: %s", t, field.Name, field.Type, name, kind)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"log"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Traced struct {
+	Base
+	ID       string    `json:"id"`
+	Tags     []string  `json:"tags"`
+	Owner    *Person   `json:"owner"`
+	Created  time.Time `json:"created"`
+	Internal string    `json:"-"`
+	hidden   string
+}
+
+type Base struct {
+	Version int `json:"version"`
+}
+
+func TestSetDebugLogger(t *testing.T) {
+	var buf bytes.Buffer
+	SetDebugLogger(log.New(&buf, "", 0))
+	defer SetDebugLogger(nil)
+
+	define(Traced{})
+	out := buf.String()
+	assert.Contains(t, out, "swag: swag.Traced.Base embedded: properties of swag.Base inlined\n")
+	assert.Contains(t, out, "swag: swag.Base.Version (int) documented as \"version\": type=integer format=int32\n")
+	assert.Contains(t, out, "swag: swag.Traced.Tags ([]string) documented as \"tags\": type=array items.type=string\n")
+	assert.Contains(t, out, "swag: swag.Traced.Owner (*swag.Person) documented as \"owner\": ref=#/definitions/github.com_zc2638_swag.Person\n")
+	assert.Contains(t, out, "swag: swag.Traced.Created (time.Time) documented as \"created\": type=string format=date-time\n")
+	assert.Contains(t, out, "swag: swag.Traced.Internal skipped: ignored by its tag\n")
+	assert.Contains(t, out, "swag: swag.Traced.hidden skipped: unexported\n")
+
+	SetDebugLogger(nil)
+	buf.Reset()
+	define(Traced{})
+	assert.Empty(t, buf.String())
+}
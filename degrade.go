@@ -0,0 +1,238 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// UnsupportedPolicy decides how the swagger 2.0 rendering handles the OpenAPI 3 only features:
+// cookie parameters, callbacks and oneOf schemas
+type UnsupportedPolicy int
+
+const (
+	// UnsupportedDrop removes the features from the rendering; Degradations lists them
+	UnsupportedDrop UnsupportedPolicy = iota
+	// UnsupportedExtension approximates the features with vendor extensions:
+	// x-cookie-parameters and x-callbacks on the operation, and x-oneOf on the schema
+	UnsupportedExtension
+	// UnsupportedError fails the rendering with ErrUnsupportedFeature
+	UnsupportedError
+)
+
+// Degradation is an OpenAPI 3 only feature the swagger 2.0 rendering cannot represent
+type Degradation struct {
+	// Pointer is the json pointer of the feature in the swagger 2.0 definition
+	Pointer string
+	// Feature is one of cookie parameter, callbacks and oneOf
+	Feature string
+}
+
+func (d Degradation) String() string {
+	return d.Feature + " at " + d.Pointer
+}
+
+// Degradations lists the features of the api the swagger 2.0 rendering cannot represent
+func (a *API) Degradations() ([]Degradation, error) {
+	data, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return degrade(v, UnsupportedDrop), nil
+}
+
+// unsupported reports whether the api uses a feature the swagger 2.0 rendering cannot represent
+func (a *API) unsupported() bool {
+	for _, d := range a.Definitions {
+		if hasOneOf(d.Properties) {
+			return true
+		}
+	}
+	for _, p := range a.Parameters {
+		if unsupportedParameter(p) {
+			return true
+		}
+	}
+	for _, r := range a.Responses {
+		if r.Schema != nil && hasOneOf(r.Schema.Properties) {
+			return true
+		}
+	}
+
+	found := false
+	a.Walk(func(_ string, e *Endpoint) {
+		if len(e.Callbacks) > 0 {
+			found = true
+		}
+		for _, p := range e.Parameters {
+			if unsupportedParameter(p) {
+				found = true
+			}
+		}
+		for _, r := range e.Responses {
+			if r.Schema != nil && hasOneOf(r.Schema.Properties) {
+				found = true
+			}
+		}
+	})
+	return found
+}
+
+func unsupportedParameter(p Parameter) bool {
+	return p.In == "cookie" || (p.Schema != nil && hasOneOf(p.Schema.Properties))
+}
+
+func hasOneOf(properties map[string]Property) bool {
+	for _, p := range properties {
+		if len(p.OneOf) > 0 || hasOneOf(p.Properties) || (p.Items != nil && hasOneOf(p.Items.Properties)) {
+			return true
+		}
+	}
+	return false
+}
+
+// degrade applies the policy to the OpenAPI 3 only features of the swagger 2.0 definition, and returns them
+func degrade(doc interface{}, policy UnsupportedPolicy) []Degradation {
+	root, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var result []Degradation
+	report := func(pointer []string, feature string) {
+		result = append(result, Degradation{Pointer: jsonPointer(pointer), Feature: feature})
+	}
+
+	var schema func(v interface{}, pointer []string)
+	schema = func(v interface{}, pointer []string) {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return
+		}
+		if oneOf, ok := m["oneOf"]; ok {
+			report(append(pointer, "oneOf"), "oneOf")
+			delete(m, "oneOf")
+			if policy == UnsupportedExtension {
+				m["x-oneOf"] = oneOf
+			}
+		}
+		if properties, ok := m["properties"].(map[string]interface{}); ok {
+			for _, name := range sortedKeys(properties) {
+				schema(properties[name], append(pointer, "properties", name))
+			}
+		}
+		schema(m["items"], append(pointer, "items"))
+	}
+	schemas := func(v interface{}, pointer []string) {
+		m, _ := v.(map[string]interface{})
+		for _, name := range sortedKeys(m) {
+			item, _ := m[name].(map[string]interface{})
+			schema(item["schema"], append(pointer, name, "schema"))
+		}
+	}
+
+	if definitions, ok := root["definitions"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(definitions) {
+			schema(definitions[name], []string{"definitions", name})
+		}
+	}
+	// the named cookie parameters are dropped, and the operations referencing them handle them as their own
+	dropped := make(map[string]interface{})
+	if parameters, ok := root["parameters"].(map[string]interface{}); ok {
+		for _, name := range sortedKeys(parameters) {
+			if param, ok := parameters[name].(map[string]interface{}); ok && param["in"] == "cookie" {
+				report([]string{"parameters", name}, "cookie parameter")
+				dropped[name] = param
+				delete(parameters, name)
+			}
+		}
+		schemas(parameters, []string{"parameters"})
+	}
+	schemas(root["responses"], []string{"responses"})
+
+	paths, _ := root["paths"].(map[string]interface{})
+	for _, p := range sortedKeys(paths) {
+		operations, _ := paths[p].(map[string]interface{})
+		for _, method := range sortedKeys(operations) {
+			op, ok := operations[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			pointer := []string{"paths", p, method}
+			if callbacks, ok := op["callbacks"]; ok {
+				report(append(pointer, "callbacks"), "callbacks")
+				delete(op, "callbacks")
+				if policy == UnsupportedExtension {
+					op["x-callbacks"] = callbacks
+				}
+			}
+
+			params, _ := op["parameters"].([]interface{})
+			kept := make([]interface{}, 0, len(params))
+			var cookies []interface{}
+			for i, item := range params {
+				param, _ := item.(map[string]interface{})
+				if ref, ok := param["$ref"].(string); ok && strings.HasPrefix(ref, "#/parameters/") {
+					name := strings.NewReplacer("~1", "/", "~0", "~").Replace(strings.TrimPrefix(ref, "#/parameters/"))
+					if v, ok := dropped[name]; ok {
+						param, _ = v.(map[string]interface{})
+					}
+				}
+				if param["in"] == "cookie" {
+					report(append(pointer, "parameters", strconv.Itoa(i)), "cookie parameter")
+					cookies = append(cookies, param)
+					continue
+				}
+				schema(param["schema"], append(pointer, "parameters", strconv.Itoa(i), "schema"))
+				kept = append(kept, item)
+			}
+			if len(cookies) > 0 {
+				op["parameters"] = kept
+				if policy == UnsupportedExtension {
+					op["x-cookie-parameters"] = cookies
+				}
+			}
+			schemas(op["responses"], append(pointer, "responses"))
+		}
+	}
+
+	return result
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// jsonPointer escapes the reference tokens of the RFC 6901 json pointer
+func jsonPointer(tokens []string) string {
+	var b strings.Builder
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+	return b.String()
+}
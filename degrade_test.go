@@ -0,0 +1,153 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newDegradationAPI() *API {
+	notify := &Endpoint{Method: http.MethodPost, Responses: map[string]Response{"200": {Description: "ok"}}}
+	callback := Callback{}
+	callback.Add("{$request.body#/url}", notify)
+
+	api := New()
+	api.Definitions = map[string]Object{
+		"Pet": {Type: "object", Properties: map[string]Property{
+			"id": {Type: "integer"},
+			"kind": {OneOf: []Property{
+				{Ref: "#/definitions/Cat"},
+				{Ref: "#/definitions/Dog"},
+			}},
+		}},
+	}
+	api.AddEndpoint(&Endpoint{
+		Method: http.MethodPost,
+		Path:   "/subscriptions",
+		Parameters: []Parameter{
+			{Name: "session", In: "cookie", Type: "string"},
+			{Name: "page", In: "query", Type: "integer"},
+		},
+		Callbacks: map[string]Callback{"onEvent": callback},
+		Responses: map[string]Response{"201": {Description: "subscribed"}},
+	})
+	return api
+}
+
+func TestAPI_Degradations(t *testing.T) {
+	api := newDegradationAPI()
+	degradations, err := api.Degradations()
+	assert.NoError(t, err)
+	assert.Equal(t, []Degradation{
+		{Pointer: "/definitions/Pet/properties/kind/oneOf", Feature: "oneOf"},
+		{Pointer: "/paths/~1subscriptions/post/callbacks", Feature: "callbacks"},
+		{Pointer: "/paths/~1subscriptions/post/parameters/0", Feature: "cookie parameter"},
+	}, degradations)
+	assert.Equal(t, "callbacks at /paths/~1subscriptions/post/callbacks", degradations[1].String())
+
+	degradations, err = New().Degradations()
+	assert.NoError(t, err)
+	assert.Empty(t, degradations)
+}
+
+func TestAPI_EncodeUnsupported(t *testing.T) {
+	api := newDegradationAPI()
+
+	doc := decodeDoc(t, api)
+	post := doc.Paths["/subscriptions"].Post
+	assert.Len(t, post.Parameters, 1)
+	assert.Equal(t, "page", post.Parameters[0].Name)
+	assert.Nil(t, post.Callbacks)
+	assert.Nil(t, doc.Definitions["Pet"].Properties["kind"].OneOf)
+
+	api.Render.Unsupported = UnsupportedExtension
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	var v map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &v))
+	op := v["paths"].(map[string]interface{})["/subscriptions"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Len(t, op["parameters"], 1)
+	assert.Equal(t, "session", op["x-cookie-parameters"].([]interface{})[0].(map[string]interface{})["name"])
+	assert.Contains(t, op["x-callbacks"], "onEvent")
+	assert.NotContains(t, op, "callbacks")
+	kind := v["definitions"].(map[string]interface{})["Pet"].(map[string]interface{})["properties"].(map[string]interface{})["kind"]
+	assert.Len(t, kind.(map[string]interface{})["x-oneOf"], 2)
+
+	api.Render.Unsupported = UnsupportedError
+	err := api.Encode(&bytes.Buffer{})
+	assert.True(t, errors.Is(err, ErrUnsupportedFeature))
+	assert.Contains(t, err.Error(), "/definitions/Pet/properties/kind/oneOf")
+
+	for _, h := range []http.Handler{api.Handler(), api.Handlers().YAML} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/swagger.json", nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Contains(t, w.Body.String(), "unsupported feature")
+	}
+
+	// the OpenAPI 3 rendering keeps the features
+	api.OpenAPI = OpenAPI3
+	buf.Reset()
+	assert.NoError(t, api.Encode(&buf))
+	v = nil
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &v))
+	op = v["paths"].(map[string]interface{})["/subscriptions"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Equal(t, "cookie", op["parameters"].([]interface{})[0].(map[string]interface{})["in"])
+	notify := op["callbacks"].(map[string]interface{})["onEvent"].(map[string]interface{})["{$request.body#/url}"].(map[string]interface{})["post"].(map[string]interface{})
+	assert.Contains(t, notify["responses"].(map[string]interface{})["200"], "description")
+	assert.Contains(t, buf.String(), `"$ref":"#/components/schemas/Cat"`)
+}
+
+func TestAPI_EncodeNamedCookie(t *testing.T) {
+	api := New()
+	api.Parameters = map[string]Parameter{
+		"session": {Name: "session", In: "cookie", Type: "string"},
+		"page":    {Name: "page", In: "query", Type: "integer"},
+	}
+	api.AddEndpoint(&Endpoint{
+		Method: http.MethodGet,
+		Path:   "/pets",
+		Parameters: []Parameter{
+			{Ref: "#/parameters/session"},
+			{Ref: "#/parameters/page"},
+		},
+		Responses: map[string]Response{"200": {Description: "ok"}},
+	})
+
+	degradations, err := api.Degradations()
+	assert.NoError(t, err)
+	assert.Equal(t, []Degradation{
+		{Pointer: "/parameters/session", Feature: "cookie parameter"},
+		{Pointer: "/paths/~1pets/get/parameters/0", Feature: "cookie parameter"},
+	}, degradations)
+
+	api.Render.Unsupported = UnsupportedExtension
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	assert.NotContains(t, buf.String(), "#/parameters/session")
+	var v map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &v))
+	assert.NotContains(t, v["parameters"], "session")
+	op := v["paths"].(map[string]interface{})["/pets"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"$ref": "#/parameters/page"}}, op["parameters"])
+	assert.Equal(t, "session", op["x-cookie-parameters"].([]interface{})[0].(map[string]interface{})["name"])
+}
@@ -0,0 +1,90 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Diagnostic describes a field whose documentation may not match its json encoding
+type Diagnostic struct {
+	// Definition is the name of the definition holding the field
+	Definition string
+	// Field is the go name of the field
+	Field string
+	// Message describes the issue
+	Message string
+}
+
+// Diagnostics reports the fields of the definitions which are documented differently than they are encoded:
+// unexported fields carrying a json tag, which encoding/json never encodes,
+// and json.Marshaler fields whose json could not be inferred and should be registered with RegisterJSONSchema
+func (a *API) Diagnostics() []Diagnostic {
+	names := make([]string, 0, len(a.Definitions))
+	for name := range a.Definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var result []Diagnostic
+	for _, name := range names {
+		t := a.Definitions[name].GoType
+		if t == nil || t.Kind() != reflect.Struct {
+			continue
+		}
+		for _, d := range diagnoseFields(t) {
+			d.Definition = name
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+func diagnoseFields(t reflect.Type) []Diagnostic {
+	var result []Diagnostic
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if isUnexported(field) {
+			if name, ok := field.Tag.Lookup("json"); ok && name != "-" {
+				result = append(result, Diagnostic{
+					Field:   field.Name,
+					Message: "unexported field has a json tag but is never encoded",
+				})
+			}
+			continue
+		}
+		if !isJSONField(field) {
+			continue
+		}
+
+		ft := field.Type
+		for ft.Kind() == reflect.Ptr || ft.Kind() == reflect.Slice || ft.Kind() == reflect.Array {
+			ft = ft.Elem()
+		}
+		if field.Anonymous && ft.Kind() == reflect.Struct {
+			result = append(result, diagnoseFields(ft)...)
+			continue
+		}
+		if !isOpaqueMarshaler(ft) || isScalar(ft) {
+			continue
+		}
+		result = append(result, Diagnostic{
+			Field:   field.Name,
+			Message: ft.String() + " encodes itself to json and its schema could not be inferred; register it with RegisterJSONSchema",
+		})
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag/testdata/legacy"
+)
+
+func TestAPI_Diagnostics(t *testing.T) {
+	api := New()
+	assert.Empty(t, api.Diagnostics())
+
+	api.AddEndpoint(&Endpoint{
+		Path:      "/accounts",
+		Method:    http.MethodGet,
+		Responses: map[string]Response{"200": {Description: "ok", Schema: MakeSchema(Wallet{})}},
+	})
+	assert.Equal(t, []Diagnostic{
+		{
+			Definition: DefinitionName(Wallet{}),
+			Field:      "Handle",
+			Message:    "swag.Handle encodes itself to json and its schema could not be inferred; register it with RegisterJSONSchema",
+		},
+	}, api.Diagnostics())
+
+	api.AddDefinitions(legacy.Customer{})
+	assert.Contains(t, api.Diagnostics(), Diagnostic{
+		Definition: DefinitionName(legacy.Customer{}),
+		Field:      "email",
+		Message:    "unexported field has a json tag but is never encoded",
+	})
+}
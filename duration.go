@@ -0,0 +1,85 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/zc2638/swag/types"
+)
+
+// DurationFormat represents how time.Duration values are documented
+type DurationFormat string
+
+const (
+	// DurationNanoseconds documents durations as an integer number of nanoseconds
+	DurationNanoseconds DurationFormat = "nanoseconds"
+	// DurationSeconds documents durations as an integer number of seconds
+	DurationSeconds DurationFormat = "seconds"
+	// DurationString documents durations as strings like 1h30m
+	DurationString DurationFormat = "string"
+)
+
+// DurationPattern matches the durations formatted by time.Duration.String
+const DurationPattern = `^(0|-?([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
+
+var (
+	durationType   = reflect.TypeOf(time.Duration(0))
+	durationFormat atomic.Value
+)
+
+func init() {
+	durationFormat.Store(DurationNanoseconds)
+}
+
+// SetDurationFormat sets how time.Duration values are documented globally;
+// the duration tag, e.g. duration:"seconds", overrides it per field
+func SetDurationFormat(format DurationFormat) {
+	durationFormat.Store(format)
+}
+
+// applyDurationFormat documents the property as a duration in the specified format,
+// falling back to the global format if empty
+func applyDurationFormat(p *Property, format DurationFormat) {
+	if format == "" {
+		format = durationFormat.Load().(DurationFormat)
+	}
+	switch format {
+	case DurationString:
+		p.Type = types.String.String()
+		p.Format = "duration"
+		p.Pattern = DurationPattern
+		p.Unit = ""
+	case DurationSeconds:
+		p.Type = types.Integer.String()
+		p.Format = "int64"
+		p.Pattern = ""
+		p.Unit = "s"
+	default:
+		p.Type = types.Integer.String()
+		p.Format = "int64"
+		p.Pattern = ""
+		p.Unit = "ns"
+	}
+}
+
+func isDuration(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t == durationType
+}
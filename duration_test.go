@@ -0,0 +1,51 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Job struct {
+	Timeout  time.Duration  `json:"timeout"`
+	Interval *time.Duration `json:"interval" duration:"seconds"`
+	Backoff  time.Duration  `json:"backoff" duration:"string"`
+}
+
+func TestDurationFormat(t *testing.T) {
+	obj := define(Job{})["github.com_zc2638_swag.Job"]
+	assert.Equal(t, Property{Type: "integer", Format: "int64", Unit: "ns"}, withoutGoType(obj.Properties["timeout"]))
+	assert.Equal(t, Property{Type: "integer", Format: "int64", Unit: "s"}, withoutGoType(obj.Properties["interval"]))
+	assert.Equal(t, Property{Type: "string", Format: "duration", Pattern: DurationPattern}, withoutGoType(obj.Properties["backoff"]))
+
+	SetDurationFormat(DurationString)
+	defer SetDurationFormat(DurationNanoseconds)
+	obj = define(Job{})["github.com_zc2638_swag.Job"]
+	assert.Equal(t, "string", obj.Properties["timeout"].Type)
+	assert.Equal(t, "integer", obj.Properties["interval"].Type)
+}
+
+func TestDurationPattern(t *testing.T) {
+	re := regexp.MustCompile(DurationPattern)
+	for _, d := range []time.Duration{0, time.Nanosecond, 1500 * time.Microsecond, 90 * time.Minute, -time.Second} {
+		assert.True(t, re.MatchString(d.String()), d.String())
+	}
+	assert.False(t, re.MatchString("1x"))
+	assert.False(t, re.MatchString(""))
+}
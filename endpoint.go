@@ -17,23 +17,33 @@ package swag
 import (
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/zc2638/swag/types"
 )
 
 // Items represents items from the swagger doc
 type Items struct {
-	Type   string `json:"type,omitempty"`
-	Format string `json:"format,omitempty"`
-	Ref    string `json:"$ref,omitempty"`
+	Type       string              `json:"type,omitempty"`
+	Format     string              `json:"format,omitempty"`
+	Enum       []string            `json:"enum,omitempty"`
+	Ref        string              `json:"$ref,omitempty"`
+	Required   []string            `json:"required,omitempty"`
+	Properties map[string]Property `json:"properties,omitempty"`
+	// Items are the elements of nested arrays
+	Items *Items `json:"items,omitempty"`
+	XML   *XML   `json:"xml,omitempty"`
 }
 
 // Schema represents a schema from the swagger doc
 type Schema struct {
-	Type      string      `json:"type,omitempty"`
-	Items     *Items      `json:"items,omitempty"`
-	Ref       string      `json:"$ref,omitempty"`
-	Prototype interface{} `json:"-"`
+	Type       string              `json:"type,omitempty"`
+	Format     string              `json:"format,omitempty"`
+	Items      *Items              `json:"items,omitempty"`
+	Ref        string              `json:"$ref,omitempty"`
+	Required   []string            `json:"required,omitempty"`
+	Properties map[string]Property `json:"properties,omitempty"`
+	Prototype  interface{}         `json:"-"`
 }
 
 // Header represents a response header
@@ -41,17 +51,41 @@ type Header struct {
 	Type        types.ParameterType `json:"type"`
 	Format      string              `json:"format"`
 	Description string              `json:"description"`
+	Enum        []string            `json:"enum,omitempty"`
+	Default     string              `json:"default,omitempty"`
+	// Items describes the values of an array header, separated according to CollectionFormat
+	Items            *Items `json:"items,omitempty"`
+	CollectionFormat string `json:"collectionFormat,omitempty"`
+	// Required marks the header as always sent; swagger 2.0 has no equivalent, but OpenAPI 3 does
+	Required bool `json:"x-required,omitempty"`
 }
 
 // Response represents a response from the swagger doc
 type Response struct {
+	// Ref references a response of the responses section of the api, e.g. #/responses/NotFound;
+	// the other fields are ignored when set
+	Ref         string            `json:"$ref,omitempty"`
 	Description string            `json:"description"`
 	Schema      *Schema           `json:"schema,omitempty"`
 	Headers     map[string]Header `json:"headers,omitempty"`
+	// Examples maps the media types to an example of the response body
+	Examples map[string]interface{} `json:"examples,omitempty"`
+}
+
+// MarshalJSON encodes the reference alone if the response is a reference
+func (r Response) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": r.Ref})
+	}
+	type alias Response
+	return json.Marshal(alias(r))
 }
 
 // Parameter represents a parameter from the swagger doc
 type Parameter struct {
+	// Ref references a parameter of the parameters section of the api, e.g. #/parameters/page;
+	// the other fields are ignored when set
+	Ref         string              `json:"$ref,omitempty"`
 	In          string              `json:"in,omitempty"`
 	Name        string              `json:"name,omitempty"`
 	Description string              `json:"description,omitempty"`
@@ -60,6 +94,36 @@ type Parameter struct {
 	Type        types.ParameterType `json:"type,omitempty"`
 	Format      string              `json:"format,omitempty"`
 	Default     string              `json:"default,omitempty"`
+	Enum        []string            `json:"enum,omitempty"`
+	Items       *Items              `json:"items,omitempty"`
+	// CollectionFormat is the format of array values, one of csv, ssv, tsv, pipes and multi; defaults to csv
+	CollectionFormat string   `json:"collectionFormat,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMaximum bool     `json:"exclusiveMaximum,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty"`
+	ExclusiveMinimum bool     `json:"exclusiveMinimum,omitempty"`
+	MaxLength        *int     `json:"maxLength,omitempty"`
+	MinLength        *int     `json:"minLength,omitempty"`
+	Pattern          string   `json:"pattern,omitempty"`
+	MaxItems         *int     `json:"maxItems,omitempty"`
+	MinItems         *int     `json:"minItems,omitempty"`
+	UniqueItems      bool     `json:"uniqueItems,omitempty"`
+	MultipleOf       *float64 `json:"multipleOf,omitempty"`
+	// Example is an example payload of a body parameter
+	Example interface{} `json:"x-example,omitempty"`
+	// MaxSize limits the size in bytes of a file parameter, enforced by Limit
+	MaxSize int64 `json:"x-max-size,omitempty"`
+	// ContentTypes lists the media types accepted for a file parameter, e.g. image/png or image/*, enforced by Limit
+	ContentTypes []string `json:"x-content-types,omitempty"`
+}
+
+// MarshalJSON encodes the reference alone if the parameter is a reference
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	if p.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": p.Ref})
+	}
+	type alias Parameter
+	return json.Marshal(alias(p))
 }
 
 // Endpoint represents an endpoint from the swagger doc
@@ -79,12 +143,57 @@ type Endpoint struct {
 	// swagger spec requires security to be an array of objects
 	Security   *SecurityRequirement `json:"security,omitempty"`
 	Deprecated bool                 `json:"deprecated,omitempty"`
+	// ExternalDocs links the operation to additional documentation, e.g. a runbook
+	ExternalDocs *TagDocs `json:"externalDocs,omitempty"`
+	// Callbacks are the requests the api makes in response to the operation, by callback name;
+	// swagger 2.0 has no equivalent, and renders them according to RenderOptions.Unsupported
+	Callbacks map[string]Callback `json:"callbacks,omitempty"`
+	// Capabilities advertises the features supported by the operation, e.g. sorting, with their fields
+	Capabilities map[string][]string `json:"x-capabilities,omitempty"`
+	// FieldSelection documents the fields query parameter selecting the properties of the success response,
+	// whose values are derived from the response model when the endpoint is added to the api
+	FieldSelection bool `json:"-"`
+	// SecuritySchemes are registered in the security definitions of the api when not defined there
+	SecuritySchemes map[string]SecurityScheme `json:"-"`
+
+	// Extensions are the vendor extensions of the operation, e.g. x-amazon-apigateway-integration
+	Extensions map[string]interface{} `json:"-"`
+	// Owner is the team or person owning the operation
+	Owner string `json:"x-owner,omitempty"`
+	// MaxBodyBytes limits the size of the request body, enforced by Limit
+	MaxBodyBytes int64 `json:"x-max-body-size,omitempty"`
+	// Timeout limits the time to serve the request, enforced by Limit
+	Timeout time.Duration `json:"-"`
+	// Versions lists the api versions documenting this variant of the operation
+	Versions []string `json:"-"`
+	// UseGlobalResponses opts the operation in the global responses of the api,
+	// restricted to the codes of GlobalResponses unless empty
+	UseGlobalResponses bool     `json:"-"`
+	GlobalResponses    []string `json:"-"`
+	// NoEnvelope opts the operation out of the envelope of the api
+	NoEnvelope bool `json:"-"`
 }
 
 func (e *Endpoint) BuildOperationID() {
 	e.OperationID = strings.ToLower(e.Method) + camel(e.Path)
 }
 
+// Callback maps the runtime expressions of a callback, e.g. {$request.body#/callbackUrl},
+// to the operations the api calls on the resulting url
+type Callback map[string]*Endpoints
+
+// Add adds the operations called on the url of the expression
+func (c Callback) Add(expression string, es ...*Endpoint) {
+	v, ok := c[expression]
+	if !ok {
+		v = &Endpoints{}
+		c[expression] = v
+	}
+	for _, e := range es {
+		v.set(e.Method, e)
+	}
+}
+
 type SecurityRequirement struct {
 	Requirements    []map[string][]string
 	DisableSecurity bool
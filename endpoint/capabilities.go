@@ -0,0 +1,101 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"strings"
+
+	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/types"
+)
+
+// Capability advertises a feature supported by the endpoint in x-capabilities, with the fields it applies to
+func Capability(name string, fields ...string) Option {
+	return func(e *swag.Endpoint) {
+		if e.Capabilities == nil {
+			e.Capabilities = make(map[string][]string)
+		}
+		if fields == nil {
+			fields = make([]string, 0)
+		}
+		e.Capabilities[name] = fields
+	}
+}
+
+// SupportsSorting advertises the sorting capability of the endpoint, and documents the sort query parameter:
+// a comma separated list of the fields, each prefixed with - for the descending order
+func SupportsSorting(fields ...string) Option {
+	values := make([]string, 0, len(fields)*2)
+	for _, field := range fields {
+		values = append(values, field, "-"+field)
+	}
+	p := swag.Parameter{
+		Name:             "sort",
+		In:               "query",
+		Type:             types.Array,
+		Items:            &swag.Items{Type: types.String.String(), Enum: values},
+		CollectionFormat: "csv",
+		Description:      "the fields sorting the results, prefixed with - for the descending order",
+	}
+	return func(e *swag.Endpoint) {
+		Capability("sorting", fields...)(e)
+		parameter(p)(e)
+	}
+}
+
+// SupportsFiltering advertises the filtering capability of the endpoint, and documents
+// a filter[field] query parameter for each field
+func SupportsFiltering(fields ...string) Option {
+	return func(e *swag.Endpoint) {
+		Capability("filtering", fields...)(e)
+		for _, field := range fields {
+			parameter(swag.Parameter{
+				Name:        "filter[" + field + "]",
+				In:          "query",
+				Type:        types.String,
+				Description: "filters the results on " + field,
+			})(e)
+		}
+	}
+}
+
+// SupportsFieldSelection documents the fields query parameter selecting the properties of the results,
+// whose values are the properties of the model of the success response when the endpoint is added to the api
+func SupportsFieldSelection() Option {
+	return func(e *swag.Endpoint) {
+		e.FieldSelection = true
+	}
+}
+
+// SortingFrom is SupportsSorting with the fields of the model tagged sortable, see swag.SortableFields
+func SortingFrom(prototype interface{}) Option {
+	return SupportsSorting(swag.SortableFields(prototype)...)
+}
+
+// FilteringFrom advertises the filtering capability of the endpoint, and documents a typed filter[field]
+// query parameter for each field of the model tagged filterable, see swag.FilterParameters
+func FilteringFrom(prototype interface{}) Option {
+	params := swag.FilterParameters(prototype)
+	fields := make([]string, 0, len(params))
+	for _, p := range params {
+		fields = append(fields, strings.TrimSuffix(strings.TrimPrefix(p.Name, "filter["), "]"))
+	}
+	return func(e *swag.Endpoint) {
+		Capability("filtering", fields...)(e)
+		for _, p := range params {
+			parameter(p)(e)
+		}
+	}
+}
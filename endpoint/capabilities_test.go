@@ -0,0 +1,87 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/types"
+)
+
+func TestCapability(t *testing.T) {
+	e := New("get", "/pets", Capability("bulk"))
+	assert.Equal(t, map[string][]string{"bulk": {}}, e.Capabilities)
+
+	data, err := json.Marshal(e)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"x-capabilities":{"bulk":[]}`)
+}
+
+func TestSupportsSorting(t *testing.T) {
+	e := New("get", "/pets", SupportsSorting("name", "createdAt"))
+	assert.Equal(t, []string{"name", "createdAt"}, e.Capabilities["sorting"])
+	assert.Equal(t, []swag.Parameter{{
+		Name:             "sort",
+		In:               "query",
+		Type:             types.Array,
+		Items:            &swag.Items{Type: "string", Enum: []string{"name", "-name", "createdAt", "-createdAt"}},
+		CollectionFormat: "csv",
+		Description:      "the fields sorting the results, prefixed with - for the descending order",
+	}}, e.Parameters)
+}
+
+func TestSupportsFiltering(t *testing.T) {
+	e := New("get", "/pets", SupportsFiltering("status", "tag"))
+	assert.Equal(t, []string{"status", "tag"}, e.Capabilities["filtering"])
+	assert.Len(t, e.Parameters, 2)
+	assert.Equal(t, "filter[status]", e.Parameters[0].Name)
+	assert.Equal(t, "query", e.Parameters[0].In)
+	assert.Equal(t, "filter[tag]", e.Parameters[1].Name)
+
+	api := swag.New()
+	api.AddEndpoint(e)
+	assert.NoError(t, api.Validate())
+}
+
+func TestSupportsFieldSelection(t *testing.T) {
+	type Pet struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	e := New("get", "/pets", SupportsFieldSelection(), Response(http.StatusOK, "ok", SchemaResponseOption([]Pet{})))
+	assert.True(t, e.FieldSelection)
+
+	api := swag.New()
+	api.AddEndpoint(e)
+	assert.Equal(t, []string{"age", "name"}, e.Parameters[0].Items.Enum)
+}
+
+func TestSortingFilteringFrom(t *testing.T) {
+	type Pet struct {
+		Name string `json:"name" sortable:"true" filterable:"true"`
+		Age  int    `json:"age" sortable:"true" filterable:"true"`
+	}
+	e := New("get", "/pets", SortingFrom(Pet{}), FilteringFrom(Pet{}))
+	assert.Equal(t, []string{"name", "age"}, e.Capabilities["sorting"])
+	assert.Equal(t, []string{"name", "age"}, e.Capabilities["filtering"])
+	assert.Len(t, e.Parameters, 3)
+	assert.Equal(t, "sort", e.Parameters[0].Name)
+	assert.Equal(t, "filter[age]", e.Parameters[2].Name)
+	assert.Equal(t, types.Integer, e.Parameters[2].Type)
+}
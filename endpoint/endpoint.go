@@ -15,17 +15,25 @@
 package endpoint
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/zc2638/swag"
 	"github.com/zc2638/swag/types"
 )
 
-// New constructs a new swagger endpoint using the fields and functional options provided
+// New constructs a new swagger endpoint using the fields and functional options provided.
+// An empty method reads the method from a pattern of the Go 1.22 http.ServeMux, e.g. New("", "GET /pets/{id}")
 func New(method, path string, options ...Option) *swag.Endpoint {
+	if method == "" {
+		method, path = swag.ParsePattern(path)
+	}
 	e := &swag.Endpoint{
 		Method:   strings.ToUpper(method),
 		Path:     path,
@@ -90,7 +98,10 @@ func Consumes(v ...string) Option {
 	}
 }
 
-func parameter(p swag.Parameter) Option {
+func parameter(p swag.Parameter, opts ...ParameterOption) Option {
+	for _, opt := range opts {
+		opt(&p)
+	}
 	return func(e *swag.Endpoint) {
 		if e.Parameters == nil {
 			e.Parameters = make([]swag.Parameter, 0)
@@ -99,10 +110,18 @@ func parameter(p swag.Parameter) Option {
 	}
 }
 
+// ParamRef adds a reference to the named parameter of the parameters section of the api,
+// see option.ParameterDefinition
+func ParamRef(name string) Option {
+	return func(e *swag.Endpoint) {
+		e.Parameters = append(e.Parameters, swag.Parameter{Ref: "#/parameters/" + name})
+	}
+}
+
 // Path defines a path parameter for the endpoint;
 // name, typ, description and required correspond to the matching swagger fields
-func Path(name string, typ types.ParameterType, description string, required bool) Option {
-	return PathDefault(name, typ, description, "", required)
+func Path(name string, typ types.ParameterType, description string, required bool, opts ...ParameterOption) Option {
+	return PathDefault(name, typ, description, "", required, opts...)
 }
 
 // PathString is the same as PathS.
@@ -115,13 +134,13 @@ func PathString(name, description string) Option {
 // name and description correspond to the matching swagger fields,
 // type defaults to string,
 // required defaults to true.
-func PathS(name, description string) Option {
-	return PathDefault(name, types.String, description, "", true)
+func PathS(name, description string, opts ...ParameterOption) Option {
+	return PathDefault(name, types.String, description, "", true, opts...)
 }
 
 // PathDefault defines a path parameter for the endpoint;
 // name, typ, description, defVal and required correspond to the matching swagger fields
-func PathDefault(name string, typ types.ParameterType, description, defVal string, required bool) Option {
+func PathDefault(name string, typ types.ParameterType, description, defVal string, required bool, opts ...ParameterOption) Option {
 	p := swag.Parameter{
 		Name:        name,
 		In:          "path",
@@ -130,13 +149,13 @@ func PathDefault(name string, typ types.ParameterType, description, defVal strin
 		Required:    required,
 		Default:     defVal,
 	}
-	return parameter(p)
+	return parameter(p, opts...)
 }
 
 // Query defines a query parameter for the endpoint;
 // name, typ, description and required correspond to the matching swagger fields
-func Query(name string, typ types.ParameterType, description string, required bool) Option {
-	return QueryDefault(name, typ, description, "", required)
+func Query(name string, typ types.ParameterType, description string, required bool, opts ...ParameterOption) Option {
+	return QueryDefault(name, typ, description, "", required, opts...)
 }
 
 // QueryString is the same as QueryS.
@@ -149,13 +168,13 @@ func QueryString(name, description string) Option {
 // name and description correspond to the matching swagger fields,
 // type defaults to string,
 // required defaults to false.
-func QueryS(name, description string) Option {
-	return QueryDefault(name, types.String, description, "", false)
+func QueryS(name, description string, opts ...ParameterOption) Option {
+	return QueryDefault(name, types.String, description, "", false, opts...)
 }
 
 // QueryDefault defines a query parameter for the endpoint;
 // name, typ, description, defVal and required correspond to the matching swagger fields
-func QueryDefault(name string, typ types.ParameterType, description, defVal string, required bool) Option {
+func QueryDefault(name string, typ types.ParameterType, description, defVal string, required bool, opts ...ParameterOption) Option {
 	p := swag.Parameter{
 		Name:        name,
 		In:          "query",
@@ -164,12 +183,68 @@ func QueryDefault(name string, typ types.ParameterType, description, defVal stri
 		Required:    required,
 		Default:     defVal,
 	}
-	return parameter(p)
+	return parameter(p, opts...)
+}
+
+// QueryObject defines a query parameter for each field of the prototype, named after the query tag of the field,
+// e.g. the filters of a list endpoint; the type and format are reflected from the field, and the description,
+// default, enum, format and required tags are read as for the definitions.
+// prototype should be a struct or a pointer to struct, and nested structs are skipped
+func QueryObject(prototype interface{}) Option {
+	params := swag.StructParameters(prototype, "query", "query")
+	return func(e *swag.Endpoint) {
+		for _, p := range params {
+			parameter(p)(e)
+		}
+	}
+}
+
+// QueryArray defines an array query parameter for the endpoint, e.g. ?ids=1,2,3 with the csv collectionFormat
+// or ?id=1&id=2 with multi; name, description and required correspond to the matching swagger fields,
+// and itemType is the type of the array items
+func QueryArray(name string, itemType types.ParameterType, collectionFormat, description string, required bool, opts ...ParameterOption) Option {
+	p := swag.Parameter{
+		Name:             name,
+		In:               "query",
+		Type:             types.Array,
+		Items:            &swag.Items{Type: itemType.String()},
+		CollectionFormat: collectionFormat,
+		Description:      description,
+		Required:         required,
+	}
+	return parameter(p, opts...)
+}
+
+// HeaderParam defines a header parameter for the endpoint, e.g. X-Request-ID or If-Match;
+// name, typ, format, description and required correspond to the matching swagger fields
+func HeaderParam(name string, typ types.ParameterType, format, description string, required bool, opts ...ParameterOption) Option {
+	p := swag.Parameter{
+		Name:        name,
+		In:          "header",
+		Type:        typ,
+		Format:      format,
+		Description: description,
+		Required:    required,
+	}
+	return parameter(p, opts...)
+}
+
+// Cookie defines a cookie parameter for the endpoint;
+// swagger 2.0 has no cookie parameters, and renders it according to RenderOptions.Unsupported
+func Cookie(name string, typ types.ParameterType, description string, required bool, opts ...ParameterOption) Option {
+	p := swag.Parameter{
+		Name:        name,
+		In:          "cookie",
+		Type:        typ,
+		Description: description,
+		Required:    required,
+	}
+	return parameter(p, opts...)
 }
 
 // FormData defines a form-data parameter for the endpoint;
 // name, typ, description and required correspond to the matching swagger fields
-func FormData(name string, typ types.ParameterType, description string, required bool) Option {
+func FormData(name string, typ types.ParameterType, description string, required bool, opts ...ParameterOption) Option {
 	p := swag.Parameter{
 		In:          "formData",
 		Type:        typ,
@@ -178,7 +253,7 @@ func FormData(name string, typ types.ParameterType, description string, required
 		Required:    required,
 	}
 	return func(e *swag.Endpoint) {
-		parameter(p)(e)
+		parameter(p, opts...)(e)
 
 		list := make([]string, 0, len(e.Consumes)+1)
 		for _, v := range e.Consumes {
@@ -192,6 +267,37 @@ func FormData(name string, typ types.ParameterType, description string, required
 	}
 }
 
+// File defines a file upload parameter for the endpoint, i.e. a form-data parameter of type file;
+// name, description and required correspond to the matching swagger fields,
+// and consumes is switched to multipart/form-data since files cannot be url encoded
+func File(name, description string, required bool, opts ...ParameterOption) Option {
+	return func(e *swag.Endpoint) {
+		FormData(name, types.File, description, required, opts...)(e)
+
+		list := make([]string, 0, len(e.Consumes))
+		for _, v := range e.Consumes {
+			if v == "application/x-www-form-urlencoded" {
+				continue
+			}
+			list = append(list, v)
+		}
+		e.Consumes = list
+	}
+}
+
+// FormBody defines a form-data parameter for each field of the prototype, named after the form tag of the field;
+// like QueryObject, the fields documented as objects are skipped and the format tag sets the format of the parameter.
+// prototype should be a struct or a pointer to struct, and consumes is set to application/x-www-form-urlencoded
+func FormBody(prototype interface{}) Option {
+	params := swag.StructParameters(prototype, "formData", "form")
+	return func(e *swag.Endpoint) {
+		for _, p := range params {
+			parameter(p)(e)
+		}
+		e.Consumes = []string{"application/x-www-form-urlencoded"}
+	}
+}
+
 // BodyR defines a body parameter for the swagger endpoint as would commonly be used for the POST, PUT, and PATCH methods
 // prototype should be a struct or a pointer to struct that swag can use to reflect upon the return type
 func BodyR(prototype interface{}) Option {
@@ -200,14 +306,56 @@ func BodyR(prototype interface{}) Option {
 
 // Body defines a body parameter for the swagger endpoint as would commonly be used for the POST, PUT, and PATCH methods
 // prototype should be a struct or a pointer to struct that swag can use to reflect upon the return type
-func Body(prototype interface{}, description string, required bool) Option {
-	return bodyType(reflect.TypeOf(prototype), description, required)
+func Body(prototype interface{}, description string, required bool, opts ...ParameterOption) Option {
+	return bodyType(reflect.TypeOf(prototype), description, required, opts...)
+}
+
+// BodyExample defines a body parameter like Body, illustrated with the example payload;
+// the example is serialized to json with its sensitive fields redacted, and it panics if it cannot be serialized
+func BodyExample(prototype, example interface{}, description string, required bool, opts ...ParameterOption) Option {
+	value, err := exampleValue("application/json", example)
+	if err != nil {
+		panic(fmt.Errorf("body example: %v", err))
+	}
+	opts = append([]ParameterOption{func(p *swag.Parameter) {
+		p.Example = value
+	}}, opts...)
+	return bodyType(reflect.TypeOf(prototype), description, required, opts...)
+}
+
+// BodyPrimitive defines a body parameter whose payload is a bare primitive value, e.g. a plain-text webhook;
+// typ should be one of the primitive types such as types.String or types.Integer
+func BodyPrimitive(typ types.ParameterType, description string, required bool, opts ...ParameterOption) Option {
+	p := swag.Parameter{
+		In:          "body",
+		Name:        "body",
+		Description: description,
+		Schema:      &swag.Schema{Type: typ.String()},
+		Required:    required,
+	}
+	return parameter(p, opts...)
+}
+
+// BodyBinary defines a body parameter whose payload is raw binary data, e.g. a file upload,
+// documented as a string of format binary; consumes is set to application/octet-stream
+func BodyBinary(description string, required bool, opts ...ParameterOption) Option {
+	p := swag.Parameter{
+		In:          "body",
+		Name:        "body",
+		Description: description,
+		Schema:      &swag.Schema{Type: types.String.String(), Format: "binary"},
+		Required:    required,
+	}
+	return func(e *swag.Endpoint) {
+		parameter(p, opts...)(e)
+		e.Consumes = []string{"application/octet-stream"}
+	}
 }
 
 // bodyType defines a body parameter for the swagger endpoint as would commonly be used for the POST, PUT, and PATCH methods
 // prototype should be a struct or a pointer to struct that swag can use to reflect upon the return type
 // t represents the Type of the body
-func bodyType(t reflect.Type, description string, required bool) Option {
+func bodyType(t reflect.Type, description string, required bool, opts ...ParameterOption) Option {
 	p := swag.Parameter{
 		In:          "body",
 		Name:        "body",
@@ -215,7 +363,7 @@ func bodyType(t reflect.Type, description string, required bool) Option {
 		Schema:      swag.MakeSchema(t),
 		Required:    required,
 	}
-	return parameter(p)
+	return parameter(p, opts...)
 }
 
 // Tags allows one or more tags to be associated with the endpoint
@@ -228,7 +376,9 @@ func Tags(tags ...string) Option {
 	}
 }
 
-// Security allows a security scheme to be associated with the endpoint.
+// Security allows a security scheme to be associated with the endpoint,
+// overriding the default security of the api; like option.Security, a scheme without scopes
+// is rendered with an empty list of scopes, e.g. {"basic": []}, as swagger requires.
 func Security(scheme string, scopes ...string) Option {
 	return func(e *swag.Endpoint) {
 		if e.Security == nil {
@@ -238,11 +388,48 @@ func Security(scheme string, scopes ...string) Option {
 		if e.Security.Requirements == nil {
 			e.Security.Requirements = []map[string][]string{}
 		}
-
+		if scopes == nil {
+			scopes = make([]string, 0)
+		}
 		e.Security.Requirements = append(e.Security.Requirements, map[string][]string{scheme: scopes})
 	}
 }
 
+// SecurityScheme associates the security scheme with the endpoint, registering it in the
+// security definitions of the api unless a scheme of the same name is defined there.
+func SecurityScheme(name string, scheme swag.SecurityScheme, scopes ...string) Option {
+	return func(e *swag.Endpoint) {
+		if e.SecuritySchemes == nil {
+			e.SecuritySchemes = make(map[string]swag.SecurityScheme)
+		}
+		e.SecuritySchemes[name] = scheme
+		Security(name, scopes...)(e)
+	}
+}
+
+// Callback documents the operations the api calls on the url of the runtime expression
+// once the endpoint is invoked, e.g. {$request.body#/callbackUrl}
+func Callback(name, expression string, es ...*swag.Endpoint) Option {
+	return func(e *swag.Endpoint) {
+		if e.Callbacks == nil {
+			e.Callbacks = make(map[string]swag.Callback)
+		}
+		callback, ok := e.Callbacks[name]
+		if !ok {
+			callback = swag.Callback{}
+			e.Callbacks[name] = callback
+		}
+		callback.Add(expression, es...)
+	}
+}
+
+// ExternalDocs links the endpoint to additional documentation, e.g. a runbook or a design doc
+func ExternalDocs(url, description string) Option {
+	return func(e *swag.Endpoint) {
+		e.ExternalDocs = &swag.TagDocs{URL: url, Description: description}
+	}
+}
+
 // NoSecurity explicitly sets the endpoint to have no security requirements.
 func NoSecurity() Option {
 	return func(e *swag.Endpoint) {
@@ -260,40 +447,142 @@ func SchemaResponseOption(schema interface{}) ResponseOption {
 	}
 }
 
+// SchemaRef references an already registered definition by name as the response schema,
+// without requiring the Go type to be in scope;
+// if the name was registered with swag.RegisterType, the definition is generated from the registered prototype
+// under that name when the endpoint is added
+func SchemaRef(name string) ResponseOption {
+	return func(response *swag.Response) {
+		response.Schema = swag.MakeSchemaRef(name)
+	}
+}
+
+// Example adds an example of the response body for the media type, e.g. application/json;
+// the value is serialized to json with its sensitive fields redacted, unless it is a string or []byte
+// of a media type other than json, which is used as is; it panics if the value cannot be serialized
+func Example(mediaType string, value interface{}) ResponseOption {
+	example, err := exampleValue(mediaType, value)
+	if err != nil {
+		panic(fmt.Errorf("response example for %s: %v", mediaType, err))
+	}
+	return func(response *swag.Response) {
+		if response.Examples == nil {
+			response.Examples = map[string]interface{}{}
+		}
+		response.Examples[mediaType] = example
+	}
+}
+
+func exampleValue(mediaType string, value interface{}) (interface{}, error) {
+	if !strings.Contains(mediaType, "json") {
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case []byte:
+			return string(v), nil
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = swag.RedactSample(value, data); err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var example interface{}
+	err = decoder.Decode(&example)
+	return example, err
+}
+
 // Schema is the same as SchemaResponseOption.
 // Deprecated.
 var Schema = SchemaResponseOption
 
 // HeaderResponseOption adds header definitions to swagger responses
-func HeaderResponseOption(name string, typ types.ParameterType, format, description string) ResponseOption {
+func HeaderResponseOption(name string, typ types.ParameterType, format, description string, opts ...HeaderOption) ResponseOption {
 	return func(response *swag.Response) {
 		if response.Headers == nil {
 			response.Headers = map[string]swag.Header{}
 		}
-		response.Headers[name] = swag.Header{
+		header := swag.Header{
 			Type:        typ,
 			Format:      format,
 			Description: description,
 		}
+		for _, opt := range opts {
+			opt(&header)
+		}
+		response.Headers[name] = header
 	}
 }
 
 // HeaderSResponseOption adds the string type header definitions to swagger responses
-func HeaderSResponseOption(name, description string) ResponseOption {
-	return func(response *swag.Response) {
-		if response.Headers == nil {
-			response.Headers = map[string]swag.Header{}
-		}
-		response.Headers[name] = swag.Header{
-			Type:        types.String,
-			Description: description,
-		}
+func HeaderSResponseOption(name, description string, opts ...HeaderOption) ResponseOption {
+	return HeaderResponseOption(name, types.String, "", description, opts...)
+}
+
+// HeaderOption allows for additional configurations on response headers
+type HeaderOption func(header *swag.Header)
+
+// HeaderEnum restricts the values of the header
+func HeaderEnum(values ...string) HeaderOption {
+	return func(header *swag.Header) {
+		header.Enum = values
+	}
+}
+
+// HeaderDefault sets the value of the header assumed when it is not sent
+func HeaderDefault(value string) HeaderOption {
+	return func(header *swag.Header) {
+		header.Default = value
+	}
+}
+
+// HeaderItems describes an array header, whose values of itemType are separated according to collectionFormat,
+// one of csv, ssv, tsv and pipes
+func HeaderItems(itemType types.ParameterType, collectionFormat string) HeaderOption {
+	return func(header *swag.Header) {
+		header.Type = types.Array
+		header.Items = &swag.Items{Type: itemType.String()}
+		header.CollectionFormat = collectionFormat
+	}
+}
+
+// HeaderRequired marks the header as always sent
+func HeaderRequired() HeaderOption {
+	return func(header *swag.Header) {
+		header.Required = true
 	}
 }
 
 // Response sets the endpoint response for the specified code;
 // may be used multiple times with different status codes
 func Response(code int, description string, opts ...ResponseOption) Option {
+	return response(strconv.Itoa(code), description, opts...)
+}
+
+// DefaultResponse sets the endpoint response for the codes not declared by Response,
+// e.g. a generic error envelope
+func DefaultResponse(description string, opts ...ResponseOption) Option {
+	return response("default", description, opts...)
+}
+
+// ResponseRef sets the endpoint response for the specified code to a reference
+// to the named response of the responses section of the api, see option.ResponseDefinition
+func ResponseRef(code int, name string) Option {
+	return func(e *swag.Endpoint) {
+		if e.Responses == nil {
+			e.Responses = make(map[string]swag.Response)
+		}
+		e.Responses[strconv.Itoa(code)] = swag.Response{Ref: "#/responses/" + name}
+	}
+}
+
+func response(key, description string, opts ...ResponseOption) Option {
 	return func(e *swag.Endpoint) {
 		if e.Responses == nil {
 			e.Responses = make(map[string]swag.Response)
@@ -304,7 +593,7 @@ func Response(code int, description string, opts ...ResponseOption) Option {
 		for _, opt := range opts {
 			opt(&r)
 		}
-		e.Responses[strconv.Itoa(code)] = r
+		e.Responses[key] = r
 	}
 }
 
@@ -312,6 +601,69 @@ func ResponseSuccess(opts ...ResponseOption) Option {
 	return Response(http.StatusOK, "success", opts...)
 }
 
+// UseGlobalResponses documents the global responses of the api on the endpoint,
+// restricted to the codes if any; required when the api opts in the global responses
+func UseGlobalResponses(codes ...int) Option {
+	return func(e *swag.Endpoint) {
+		e.UseGlobalResponses = true
+		e.GlobalResponses = nil
+		for _, code := range codes {
+			e.GlobalResponses = append(e.GlobalResponses, strconv.Itoa(code))
+		}
+	}
+}
+
+// NoEnvelope leaves the response bodies of the endpoint out of the envelope of the api, e.g. for a file download
+func NoEnvelope() Option {
+	return func(e *swag.Endpoint) {
+		e.NoEnvelope = true
+	}
+}
+
+// Limits documents and sets the maximum request body size and the timeout of the endpoint,
+// along with the 413 and 408 responses; zero values mean no limit.
+// The limits are enforced by swag.Limit
+func Limits(maxBodyBytes int64, timeout time.Duration) Option {
+	return func(e *swag.Endpoint) {
+		e.MaxBodyBytes = maxBodyBytes
+		e.Timeout = timeout
+		if maxBodyBytes > 0 {
+			Response(http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("request body exceeds %d bytes", maxBodyBytes))(e)
+		}
+		if timeout > 0 {
+			Response(http.StatusRequestTimeout,
+				fmt.Sprintf("request not served within %v", timeout))(e)
+		}
+	}
+}
+
+// Version marks the endpoint as the variant of the operation documented for the specified api versions;
+// see option.HeaderVersioning
+func Version(versions ...string) Option {
+	return func(e *swag.Endpoint) {
+		e.Versions = append(e.Versions, versions...)
+	}
+}
+
+// Extension sets a vendor extension of the endpoint, e.g. x-amazon-apigateway-integration;
+// the key is prefixed with x- if needed
+func Extension(key string, value interface{}) Option {
+	return func(e *swag.Endpoint) {
+		if e.Extensions == nil {
+			e.Extensions = make(map[string]interface{})
+		}
+		e.Extensions[swag.ExtensionKey(key)] = value
+	}
+}
+
+// Owner sets the team or person owning the endpoint
+func Owner(owner string) Option {
+	return func(e *swag.Endpoint) {
+		e.Owner = owner
+	}
+}
+
 func Deprecated() Option {
 	return func(e *swag.Endpoint) {
 		e.Deprecated = true
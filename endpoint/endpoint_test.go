@@ -15,10 +15,13 @@
 package endpoint
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/zc2638/swag/types"
 
@@ -157,6 +160,92 @@ func TestQuery(t *testing.T) {
 	assert.Equal(t, expected, e.Parameters[0])
 }
 
+type ListPetsQuery struct {
+	Status    string    `query:"status" desc:"the status of the pets" enum:"available,sold"`
+	Limit     int       `query:"limit" default:"20"`
+	Tags      []string  `query:"tags"`
+	Owner     string    `query:"owner" required:""`
+	UpdatedAt time.Time `query:"updated_since" format:"date"`
+	Model     Model
+}
+
+func TestQueryObject(t *testing.T) {
+	e := New("get", "/pets", QueryObject(ListPetsQuery{}))
+
+	assert.Equal(t, []string{"application/json"}, e.Consumes)
+	assert.Equal(t, []swag.Parameter{
+		{In: "query", Name: "status", Type: types.String, Description: "the status of the pets", Enum: []string{"available", "sold"}},
+		{In: "query", Name: "limit", Type: types.Integer, Format: "int32", Default: "20"},
+		{In: "query", Name: "tags", Type: types.Array, Items: &swag.Items{Type: "string"}},
+		{In: "query", Name: "owner", Type: types.String, Required: true},
+		{In: "query", Name: "updated_since", Type: types.String, Format: "date"},
+	}, e.Parameters)
+}
+
+func TestQueryArray(t *testing.T) {
+	expected := swag.Parameter{
+		In:               "query",
+		Name:             "ids",
+		Description:      "the ids",
+		Type:             types.Array,
+		Items:            &swag.Items{Type: "integer"},
+		CollectionFormat: "csv",
+	}
+
+	e := New("get", "/",
+		QueryArray(expected.Name, types.Integer, "csv", expected.Description, false),
+	)
+
+	assert.Equal(t, []swag.Parameter{expected}, e.Parameters)
+}
+
+func TestHeaderParam(t *testing.T) {
+	expected := swag.Parameter{
+		In:          "header",
+		Name:        "X-Request-ID",
+		Description: "the request id",
+		Required:    true,
+		Type:        "string",
+		Format:      "uuid",
+	}
+
+	e := New("get", "/",
+		Summary("get thing"),
+		HeaderParam(expected.Name, expected.Type, expected.Format, expected.Description, expected.Required),
+	)
+
+	assert.Equal(t, 1, len(e.Parameters))
+	assert.Equal(t, expected, e.Parameters[0])
+}
+
+func TestCookie(t *testing.T) {
+	expected := swag.Parameter{
+		In:          "cookie",
+		Name:        "session",
+		Description: "the session id",
+		Required:    true,
+		Type:        types.String,
+	}
+
+	e := New("get", "/",
+		Cookie(expected.Name, expected.Type, expected.Description, expected.Required),
+	)
+
+	assert.Equal(t, 1, len(e.Parameters))
+	assert.Equal(t, expected, e.Parameters[0])
+}
+
+func TestCallback(t *testing.T) {
+	notify := New("post", "/", Response(http.StatusOK, "ok"))
+	e := New("post", "/subscriptions",
+		Callback("onEvent", "{$request.body#/url}", notify),
+		Callback("onEvent", "{$request.body#/fallbackUrl}", notify),
+	)
+
+	assert.Len(t, e.Callbacks["onEvent"], 2)
+	assert.Equal(t, notify, e.Callbacks["onEvent"]["{$request.body#/url}"].Post)
+}
+
 func TestQueryString(t *testing.T) {
 	expected := swag.Parameter{
 		In:          "query",
@@ -202,6 +291,57 @@ func TestFormData(t *testing.T) {
 	assert.Equal(t, expected2, e.Parameters[1])
 }
 
+func TestFile(t *testing.T) {
+	e := New("post", "/avatars",
+		Consumes("application/x-www-form-urlencoded"),
+		FormData("note", types.String, "the note", false),
+		File("avatar", "the avatar image", true),
+	)
+
+	assert.Equal(t, []string{"multipart/form-data"}, e.Consumes)
+	assert.Equal(t, []swag.Parameter{
+		{In: "formData", Name: "note", Type: types.String, Description: "the note"},
+		{In: "formData", Name: "avatar", Type: types.File, Description: "the avatar image", Required: true},
+	}, e.Parameters)
+}
+
+type TokenRequest struct {
+	GrantType string   `form:"grant_type" required:"" enum:"password,refresh_token"`
+	Username  string   `form:"username" desc:"the user name"`
+	ExpiresIn int64    `form:"expires_in,omitempty" default:"3600"`
+	Scopes    []string `form:"scope"`
+	Ignored   string   `form:"-"`
+	Nested    Model
+	internal  string
+}
+
+func TestFormBody(t *testing.T) {
+	e := New("post", "/oauth/token", FormBody(&TokenRequest{}))
+
+	assert.Equal(t, []string{"application/x-www-form-urlencoded"}, e.Consumes)
+	assert.Equal(t, []swag.Parameter{
+		{In: "formData", Name: "grant_type", Type: types.String, Required: true, Enum: []string{"password", "refresh_token"}},
+		{In: "formData", Name: "username", Type: types.String, Description: "the user name"},
+		{In: "formData", Name: "expires_in", Type: types.Integer, Format: "int64", Default: "3600"},
+		{In: "formData", Name: "scope", Type: types.Array, Items: &swag.Items{Type: "string"}},
+	}, e.Parameters)
+}
+
+type formMetadata struct{ values map[string]string }
+
+type FormFields struct {
+	Expires  string       `form:"expires" format:"date-time"`
+	Metadata formMetadata `form:"metadata"`
+}
+
+func TestFormBodyObjectsAndFormats(t *testing.T) {
+	swag.RegisterJSONSchema(formMetadata{}, swag.Property{Type: "object"})
+	e := New("post", "/tokens", FormBody(FormFields{}))
+	assert.Equal(t, []swag.Parameter{
+		{In: "formData", Name: "expires", Type: types.String, Format: "date-time"},
+	}, e.Parameters)
+}
+
 type Model struct {
 	String string `json:"s"`
 }
@@ -249,6 +389,60 @@ func TestBody(t *testing.T) {
 	assert.Equal(t, expected, e.Parameters[0])
 }
 
+func TestBodyExample(t *testing.T) {
+	e := New("post", "/login",
+		BodyExample(Credential{}, Credential{User: "zc", Password: "secret"}, "the credential", true),
+	)
+
+	assert.Equal(t, 1, len(e.Parameters))
+	p := e.Parameters[0]
+	assert.Equal(t, "body", p.In)
+	assert.Equal(t, "the credential", p.Description)
+	assert.True(t, p.Required)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag_endpoint.Credential", p.Schema.Ref)
+	assert.Equal(t, map[string]interface{}{"user": "zc", "password": swag.Redacted, "age": json.Number("0")}, p.Example)
+}
+
+func TestBodyPrimitive(t *testing.T) {
+	expected := swag.Parameter{
+		In:          "body",
+		Name:        "body",
+		Description: "raw payload",
+		Required:    true,
+		Schema:      &swag.Schema{Type: "string"},
+	}
+
+	e := New(
+		"post", "/",
+		Summary("webhook"),
+		BodyPrimitive(types.String, expected.Description, expected.Required),
+	)
+
+	assert.Equal(t, 1, len(e.Parameters))
+	assert.Equal(t, expected, e.Parameters[0])
+
+	api := swag.New()
+	api.AddEndpoint(e)
+	assert.Equal(t, 0, len(api.Definitions))
+}
+
+func TestBodyBinary(t *testing.T) {
+	e := New(
+		"put", "/avatar",
+		Summary("upload avatar"),
+		BodyBinary("image data", true),
+		Response(http.StatusOK, "ok", SchemaResponseOption([]byte{})),
+	)
+
+	assert.Equal(t, &swag.Schema{Type: "string", Format: "binary"}, e.Parameters[0].Schema)
+	assert.Equal(t, []string{"application/octet-stream"}, e.Consumes)
+	assert.Equal(t, &swag.Schema{Type: "string", Format: "byte"}, e.Responses["200"].Schema)
+
+	api := swag.New()
+	api.AddEndpoint(e)
+	assert.Equal(t, 0, len(api.Definitions))
+}
+
 func TestResponse(t *testing.T) {
 	expected := swag.Response{
 		Description: "successful",
@@ -269,6 +463,88 @@ func TestResponse(t *testing.T) {
 	assert.Equal(t, *expected.Schema, *e.Responses["200"].Schema)
 }
 
+func TestDefaultResponse(t *testing.T) {
+	e := New(
+		"get", "/",
+		Response(http.StatusOK, "successful"),
+		DefaultResponse("unexpected error", SchemaResponseOption(Model{})),
+	)
+
+	assert.Equal(t, 2, len(e.Responses))
+	assert.Equal(t, "unexpected error", e.Responses["default"].Description)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag_endpoint.Model", e.Responses["default"].Schema.Ref)
+}
+
+func TestUseGlobalResponses(t *testing.T) {
+	e := New("get", "/", UseGlobalResponses())
+	assert.True(t, e.UseGlobalResponses)
+	assert.Empty(t, e.GlobalResponses)
+
+	e = New("get", "/", UseGlobalResponses(http.StatusUnauthorized, http.StatusForbidden))
+	assert.Equal(t, []string{"401", "403"}, e.GlobalResponses)
+}
+
+func TestNoEnvelope(t *testing.T) {
+	e := New("get", "/", NoEnvelope())
+	assert.True(t, e.NoEnvelope)
+}
+
+func TestParamRef(t *testing.T) {
+	e := New("get", "/", ParamRef("page"), ParamRef("limit"))
+	assert.Equal(t, []swag.Parameter{{Ref: "#/parameters/page"}, {Ref: "#/parameters/limit"}}, e.Parameters)
+}
+
+func TestResponseRef(t *testing.T) {
+	e := New("get", "/", ResponseRef(http.StatusNotFound, "NotFound"))
+	assert.Equal(t, swag.Response{Ref: "#/responses/NotFound"}, e.Responses["404"])
+}
+
+func TestExtension(t *testing.T) {
+	e := New("get", "/",
+		Extension("x-amazon-apigateway-integration", map[string]string{"type": "http_proxy"}),
+		Extension("codegen-name", "list"),
+	)
+	assert.Equal(t, map[string]interface{}{
+		"x-amazon-apigateway-integration": map[string]string{"type": "http_proxy"},
+		"x-codegen-name":                  "list",
+	}, e.Extensions)
+}
+
+func TestOwner(t *testing.T) {
+	e := New("get", "/", Owner("billing"))
+	assert.Equal(t, "billing", e.Owner)
+}
+
+func TestSchemaRef(t *testing.T) {
+	e := New(
+		"get", "/",
+		Summary("get thing"),
+		Response(http.StatusBadRequest, "bad request", SchemaRef("ErrorResponse")),
+	)
+
+	assert.Equal(t, 1, len(e.Responses))
+	assert.Equal(t, &swag.Schema{Ref: "#/definitions/ErrorResponse"}, e.Responses["400"].Schema)
+
+	api := swag.New()
+	api.AddEndpoint(e)
+	assert.Equal(t, 0, len(api.Definitions))
+}
+
+func TestSchemaRefRegistered(t *testing.T) {
+	swag.RegisterType("EndpointModel", Model{})
+	e := New(
+		"get", "/",
+		Response(http.StatusOK, "success", SchemaRef("EndpointModel")),
+	)
+	assert.Equal(t, "#/definitions/EndpointModel", e.Responses["200"].Schema.Ref)
+
+	api := swag.New()
+	api.AddEndpoint(e)
+	assert.Contains(t, api.Definitions, "EndpointModel")
+	assert.NotContains(t, api.Definitions, "github.com_zc2638_swag_endpoint.Model")
+	assert.Contains(t, api.Definitions["EndpointModel"].Properties, "s")
+}
+
 func TestResponseHeader(t *testing.T) {
 	expected := swag.Response{
 		Description: "successful",
@@ -294,6 +570,88 @@ func TestResponseHeader(t *testing.T) {
 	assert.Equal(t, expected, e.Responses["200"])
 }
 
+func TestResponseHeaderOptions(t *testing.T) {
+	e := New(
+		"get", "/",
+		Response(http.StatusOK, "successful",
+			HeaderSResponseOption("X-RateLimit-Policy", "the rate limit policy",
+				HeaderEnum("burst", "steady"),
+				HeaderDefault("steady"),
+				HeaderRequired(),
+			),
+			HeaderResponseOption("X-RateLimit-Windows", "", "", "the windows in seconds",
+				HeaderItems(types.Integer, "csv"),
+			),
+		),
+	)
+
+	headers := e.Responses["200"].Headers
+	assert.Equal(t, swag.Header{
+		Type:        types.String,
+		Description: "the rate limit policy",
+		Enum:        []string{"burst", "steady"},
+		Default:     "steady",
+		Required:    true,
+	}, headers["X-RateLimit-Policy"])
+	assert.Equal(t, swag.Header{
+		Type:             types.Array,
+		Description:      "the windows in seconds",
+		Items:            &swag.Items{Type: "integer"},
+		CollectionFormat: "csv",
+	}, headers["X-RateLimit-Windows"])
+}
+
+type Credential struct {
+	User     string `json:"user"`
+	Password string `json:"password" sensitive:"true"`
+	Age      int    `json:"age"`
+}
+
+func TestExample(t *testing.T) {
+	e := New(
+		"get", "/",
+		Response(http.StatusOK, "successful",
+			SchemaResponseOption(Credential{}),
+			Example("application/json", Credential{User: "zc", Password: "secret", Age: 18}),
+			Example("text/plain", "user zc"),
+		),
+		Response(http.StatusNotFound, "not found",
+			Example("application/problem+json", map[string]interface{}{"title": "Not Found"}),
+		),
+	)
+
+	assert.Equal(t, map[string]interface{}{
+		"application/json": map[string]interface{}{"user": "zc", "password": swag.Redacted, "age": json.Number("18")},
+		"text/plain":       "user zc",
+	}, e.Responses["200"].Examples)
+	assert.Equal(t, map[string]interface{}{
+		"application/problem+json": map[string]interface{}{"title": "Not Found"},
+	}, e.Responses["404"].Examples)
+
+	assert.Panics(t, func() {
+		Example("application/json", func() {})
+	})
+}
+
+func TestLimits(t *testing.T) {
+	e := New(
+		"post", "/",
+		Limits(1024, time.Second),
+	)
+	assert.Equal(t, int64(1024), e.MaxBodyBytes)
+	assert.Equal(t, time.Second, e.Timeout)
+	assert.Equal(t, "request body exceeds 1024 bytes", e.Responses["413"].Description)
+	assert.Equal(t, "request not served within 1s", e.Responses["408"].Description)
+
+	e = New("post", "/", Limits(0, time.Second))
+	assert.NotContains(t, e.Responses, "413")
+}
+
+func TestVersion(t *testing.T) {
+	e := New("get", "/", Version("1", "2"))
+	assert.Equal(t, []string{"1", "2"}, e.Versions)
+}
+
 func TestSecurityScheme(t *testing.T) {
 	api := swag.New(
 		option.SecurityScheme("basic", option.BasicSecurity()),
@@ -319,6 +677,71 @@ func TestSecurity(t *testing.T) {
 	assert.Len(t, e.Security.Requirements[1]["oauth2"], 2)
 }
 
+func TestSecurityWithoutScopes(t *testing.T) {
+	e := New("get", "/", Security("basic"))
+	data, err := json.Marshal(e.Security)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"basic":[]}]`, string(data))
+}
+
+func TestSecurityOverride(t *testing.T) {
+	api := swag.New(
+		option.GlobalSecurity("api_key"),
+	)
+	api.AddEndpoint(
+		New("get", "/pets"),
+		New("get", "/users", Security("basic")),
+		New("get", "/health", NoSecurity()),
+	)
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	assert.Contains(t, buf.String(), `"security":[{"api_key":[]}]`)
+	assert.Contains(t, buf.String(), `"security":[{"basic":[]}]`)
+	assert.Contains(t, buf.String(), `"security":[]`)
+	assert.NotContains(t, buf.String(), `null`)
+}
+
+func TestSecuritySchemeRegistration(t *testing.T) {
+	api := swag.New(
+		option.SecurityScheme("basic", option.BasicSecurity()),
+	)
+	api.AddEndpoint(
+		New("get", "/pets", SecurityScheme("jwt", swag.BearerSecurity("jwt"))),
+		New("get", "/users", SecurityScheme("basic", swag.BearerSecurity("jwt"))),
+	)
+	assert.Equal(t, swag.BearerSecurity("jwt"), api.SecurityDefinitions["jwt"])
+	// a scheme defined by the api is kept
+	assert.Equal(t, "basic", api.SecurityDefinitions["basic"].Type)
+	assert.NoError(t, api.Validate())
+}
+
+func TestExternalDocs(t *testing.T) {
+	e := New("get", "/", ExternalDocs("https://runbooks.example.com/pets", "runbook"))
+	assert.Equal(t, &swag.TagDocs{URL: "https://runbooks.example.com/pets", Description: "runbook"}, e.ExternalDocs)
+
+	data, err := json.Marshal(e)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"externalDocs":{"description":"runbook","url":"https://runbooks.example.com/pets"}`)
+}
+
+func TestSecurityBearerRegistration(t *testing.T) {
+	swag.BearerSecurity("endpoint_token")
+	api := swag.New()
+	api.AddEndpoint(
+		New("get", "/pets", Security("bearer")),
+		New("get", "/users", Security("endpoint_token")),
+		New("get", "/owners", Security("unknown")),
+	)
+	assert.Equal(t, "bearer", api.SecurityDefinitions["bearer"].Scheme)
+	assert.Equal(t, "Authorization", api.SecurityDefinitions["endpoint_token"].Name)
+	assert.NotContains(t, api.SecurityDefinitions, "unknown")
+
+	api = swag.New(option.SecurityScheme("bearer", option.BasicSecurity()))
+	api.AddEndpoint(New("get", "/pets", Security("bearer")))
+	assert.Equal(t, "basic", api.SecurityDefinitions["bearer"].Type)
+}
+
 func TestNoSecurity(t *testing.T) {
 	e := New(
 		"get", "/",
@@ -327,3 +750,13 @@ func TestNoSecurity(t *testing.T) {
 	)
 	assert.True(t, e.Security.DisableSecurity)
 }
+
+func TestNewPattern(t *testing.T) {
+	e := New("", "DELETE /pets/{id}")
+	assert.Equal(t, "DELETE", e.Method)
+	assert.Equal(t, "/pets/{id}", e.Path)
+
+	e = New("get", "/pets/{id...}")
+	assert.Equal(t, "GET", e.Method)
+	assert.Equal(t, "/pets/{id...}", e.Path)
+}
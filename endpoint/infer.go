@@ -0,0 +1,94 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/zc2638/swag"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// InferSchemas derives the body and the success response schemas from the handler signature
+// when the handler is of the form func(ctx, Req) (Resp, error);
+// the request or the response may be omitted from the signature.
+// It must be placed after Handler, and never overrides a body or a 200 response that is already defined,
+// so an explicit Body must be placed before it, while Response options placed after it replace the inferred response
+func InferSchemas() Option {
+	return func(e *swag.Endpoint) {
+		req, resp, ok := handlerTypes(e.Handler)
+		if !ok {
+			return
+		}
+
+		if req != nil && hasBody(e.Method) && !hasBodyParameter(e) {
+			bodyType(req, "", true)(e)
+		}
+		if resp != nil {
+			if _, exists := e.Responses[strconv.Itoa(http.StatusOK)]; !exists {
+				ResponseSuccess(SchemaResponseOption(resp))(e)
+			}
+		}
+	}
+}
+
+// handlerTypes returns the request and the response types of handlers like func(ctx, Req) (Resp, error)
+func handlerTypes(handler interface{}) (req, resp reflect.Type, ok bool) {
+	if handler == nil {
+		return nil, nil, false
+	}
+	t := reflect.TypeOf(handler)
+	if t.Kind() != reflect.Func || t.IsVariadic() {
+		return nil, nil, false
+	}
+	if t.NumIn() < 1 || t.NumIn() > 2 || t.In(0) != contextType {
+		return nil, nil, false
+	}
+	if t.NumOut() < 1 || t.NumOut() > 2 || t.Out(t.NumOut()-1) != errorType {
+		return nil, nil, false
+	}
+
+	if t.NumIn() == 2 {
+		req = t.In(1)
+	}
+	if t.NumOut() == 2 {
+		resp = t.Out(0)
+	}
+	return req, resp, true
+}
+
+func hasBody(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	}
+	return false
+}
+
+func hasBodyParameter(e *swag.Endpoint) bool {
+	for _, p := range e.Parameters {
+		if p.In == "body" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,100 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type CreateRequest struct {
+	Name string `json:"name"`
+}
+
+func createModel(context.Context, *CreateRequest) (*Model, error) {
+	return &Model{}, nil
+}
+
+func deleteModel(context.Context, CreateRequest) error {
+	return nil
+}
+
+func TestInferSchemas(t *testing.T) {
+	e := New(
+		http.MethodPost, "/",
+		Handler(createModel),
+		InferSchemas(),
+	)
+	assert.Len(t, e.Parameters, 1)
+	assert.Equal(t, "body", e.Parameters[0].In)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag_endpoint.CreateRequest", e.Parameters[0].Schema.Ref)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag_endpoint.Model", e.Responses["200"].Schema.Ref)
+
+	e = New(
+		http.MethodPost, "/",
+		Handler(deleteModel),
+		InferSchemas(),
+	)
+	assert.Len(t, e.Parameters, 1)
+	assert.Len(t, e.Responses, 0)
+
+	// GET requests have no body
+	e = New(
+		http.MethodGet, "/",
+		Handler(createModel),
+		InferSchemas(),
+	)
+	assert.Len(t, e.Parameters, 0)
+	assert.Len(t, e.Responses, 1)
+
+	// standard http handlers are ignored
+	e = New(
+		http.MethodPost, "/",
+		Handler(Echo),
+		InferSchemas(),
+	)
+	assert.Len(t, e.Parameters, 0)
+	assert.Len(t, e.Responses, 0)
+}
+
+func TestInferSchemasOverride(t *testing.T) {
+	e := New(
+		http.MethodPost, "/",
+		Response(http.StatusOK, "explicit", SchemaResponseOption(CreateRequest{})),
+		Body(Model{}, "explicit", true),
+		Handler(createModel),
+		InferSchemas(),
+	)
+	assert.Len(t, e.Parameters, 1)
+	assert.Equal(t, "explicit", e.Parameters[0].Description)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag_endpoint.Model", e.Parameters[0].Schema.Ref)
+	assert.Equal(t, "explicit", e.Responses["200"].Description)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag_endpoint.CreateRequest", e.Responses["200"].Schema.Ref)
+}
+
+func TestInferSchemasResponseAfter(t *testing.T) {
+	e := New(
+		http.MethodPost, "/",
+		Handler(createModel),
+		InferSchemas(),
+		Response(http.StatusOK, "explicit"),
+	)
+	assert.Len(t, e.Parameters, 1)
+	assert.Equal(t, "explicit", e.Responses["200"].Description)
+	assert.Nil(t, e.Responses["200"].Schema)
+}
@@ -0,0 +1,183 @@
+// Copyright © 2020 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/zc2638/swag"
+)
+
+// ParameterOption allows for additional configurations on parameters like a longer description
+type ParameterOption func(p *swag.Parameter)
+
+// ParamDescription replaces the description of the parameter with the multi-line markdown text;
+// the leading and trailing blank lines and the indentation common to all lines are removed,
+// so the text can be written as an indented raw string literal
+func ParamDescription(text string) ParameterOption {
+	description := dedent(text)
+	return func(p *swag.Parameter) {
+		p.Description = description
+	}
+}
+
+// ParamDescriptionFile replaces the description of the parameter with the markdown file read from fsys,
+// e.g. an embed.FS holding the documentation; it returns the error of reading the file
+func ParamDescriptionFile(fsys fs.FS, name string) (ParameterOption, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("parameter description: %w", err)
+	}
+	return ParamDescription(string(data)), nil
+}
+
+// Minimum sets the inclusive lower bound of a numeric parameter
+func Minimum(v float64) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.Minimum = &v
+	}
+}
+
+// ExclusiveMinimum sets the exclusive lower bound of a numeric parameter
+func ExclusiveMinimum(v float64) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.Minimum = &v
+		p.ExclusiveMinimum = true
+	}
+}
+
+// Maximum sets the inclusive upper bound of a numeric parameter
+func Maximum(v float64) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.Maximum = &v
+	}
+}
+
+// ExclusiveMaximum sets the exclusive upper bound of a numeric parameter
+func ExclusiveMaximum(v float64) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.Maximum = &v
+		p.ExclusiveMaximum = true
+	}
+}
+
+// MultipleOf requires a numeric parameter to be a multiple of v
+func MultipleOf(v float64) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.MultipleOf = &v
+	}
+}
+
+// MinLength sets the minimum length of a string parameter
+func MinLength(n int) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.MinLength = &n
+	}
+}
+
+// MaxLength sets the maximum length of a string parameter
+func MaxLength(n int) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.MaxLength = &n
+	}
+}
+
+// Pattern sets the regular expression a string parameter must match
+func Pattern(expr string) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.Pattern = expr
+	}
+}
+
+// Enum restricts the parameter to the values
+func Enum(values ...string) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.Enum = values
+	}
+}
+
+// MinItems sets the minimum number of items of an array parameter
+func MinItems(n int) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.MinItems = &n
+	}
+}
+
+// MaxItems sets the maximum number of items of an array parameter
+func MaxItems(n int) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.MaxItems = &n
+	}
+}
+
+// UniqueItems requires the items of an array parameter to be unique
+func UniqueItems() ParameterOption {
+	return func(p *swag.Parameter) {
+		p.UniqueItems = true
+	}
+}
+
+// MaxFileSize limits the size in bytes of the file parameter, enforced by swag.Limit
+func MaxFileSize(n int64) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.MaxSize = n
+	}
+}
+
+// AcceptContentTypes lists the media types accepted for the file parameter, e.g. image/png or image/*,
+// enforced by swag.Limit
+func AcceptContentTypes(types ...string) ParameterOption {
+	return func(p *swag.Parameter) {
+		p.ContentTypes = types
+	}
+}
+
+// dedent removes the leading and trailing blank lines of the text, and the indentation common to all its lines
+func dedent(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	// the common indentation is the leading whitespace shared by the lines which are not blank
+	indent := ""
+	first := true
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		prefix := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if first {
+			indent, first = prefix, false
+			continue
+		}
+		for !strings.HasPrefix(prefix, indent) {
+			indent = indent[:len(indent)-1]
+		}
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			lines[i] = ""
+			continue
+		}
+		lines[i] = strings.TrimPrefix(line, indent)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,97 @@
+// Copyright © 2020 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package endpoint
+
+import (
+	"errors"
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/types"
+)
+
+func TestParamDescription(t *testing.T) {
+	e := New("get", "/pets",
+		Query("status", types.String, "the status", false, ParamDescription(`
+			Filters the pets by status:
+
+			- available
+			- sold
+		`)),
+	)
+
+	assert.Equal(t, "Filters the pets by status:\n\n- available\n- sold", e.Parameters[0].Description)
+}
+
+func TestParamDescriptionFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"docs/id.md": {Data: []byte("The **id** of the pet.\n\nSee `GET /pets`.\n")},
+	}
+	description, err := ParamDescriptionFile(fsys, "docs/id.md")
+	assert.NoError(t, err)
+	e := New("get", "/pets/{id}",
+		Path("id", types.Integer, "", true, description),
+		Body(Model{}, "the model", true, ParamDescription("the **model**")),
+	)
+
+	assert.Equal(t, "The **id** of the pet.\n\nSee `GET /pets`.", e.Parameters[0].Description)
+	assert.Equal(t, "the **model**", e.Parameters[1].Description)
+	_, err = ParamDescriptionFile(fsys, "docs/missing.md")
+	assert.True(t, errors.Is(err, fs.ErrNotExist))
+}
+
+func TestParameterConstraints(t *testing.T) {
+	e := New("get", "/pets",
+		Query("limit", types.Integer, "the page size", false, Minimum(1), Maximum(100), MultipleOf(10)),
+		Query("offset", types.Integer, "the offset", false, ExclusiveMinimum(-1), ExclusiveMaximum(1000)),
+		Query("name", types.String, "the name", false, MinLength(2), MaxLength(32), Pattern("^[a-z]+$")),
+		Query("sort", types.String, "the sort order", false, Enum("asc", "desc")),
+		QueryArray("ids", types.Integer, "csv", "the ids", false, MinItems(1), MaxItems(50), UniqueItems()),
+	)
+
+	one, hundred, ten, minusOne, thousand := 1.0, 100.0, 10.0, -1.0, 1000.0
+	first, two, thirtyTwo, fifty := 1, 2, 32, 50
+	assert.Equal(t, []swag.Parameter{
+		{In: "query", Name: "limit", Type: types.Integer, Description: "the page size",
+			Minimum: &one, Maximum: &hundred, MultipleOf: &ten},
+		{In: "query", Name: "offset", Type: types.Integer, Description: "the offset",
+			Minimum: &minusOne, ExclusiveMinimum: true, Maximum: &thousand, ExclusiveMaximum: true},
+		{In: "query", Name: "name", Type: types.String, Description: "the name",
+			MinLength: &two, MaxLength: &thirtyTwo, Pattern: "^[a-z]+$"},
+		{In: "query", Name: "sort", Type: types.String, Description: "the sort order", Enum: []string{"asc", "desc"}},
+		{In: "query", Name: "ids", Type: types.Array, Items: &swag.Items{Type: "integer"}, CollectionFormat: "csv",
+			Description: "the ids", MinItems: &first, MaxItems: &fifty, UniqueItems: true},
+	}, e.Parameters)
+}
+
+func TestDedent(t *testing.T) {
+	assert.Equal(t, "a\n  b\n\nc", dedent("\n    a\n      b\n\n    c\n  "))
+	assert.Equal(t, "single line", dedent("single line"))
+	assert.Equal(t, "", dedent(" \n \n"))
+	assert.Equal(t, "- a\n  - b\n\n      code", dedent("- a\n  - b\n\n      code"))
+	assert.Equal(t, "a\n\tb\n c", dedent("\ta\n\t\tb\n\t c"))
+}
+
+func TestUploadConstraints(t *testing.T) {
+	e := New("post", "/avatars",
+		File("avatar", "the avatar", true, MaxFileSize(1<<20), AcceptContentTypes("image/png", "image/jpeg")),
+	)
+	assert.Equal(t, int64(1<<20), e.Parameters[0].MaxSize)
+	assert.Equal(t, []string{"image/png", "image/jpeg"}, e.Parameters[0].ContentTypes)
+}
@@ -0,0 +1,59 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"os"
+	"regexp"
+)
+
+var envPlaceholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces the ${NAME} placeholders of the whitelisted environment variables which are set;
+// the other placeholders are kept as is
+func (a *API) expandEnv(s string) string {
+	return envPlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+		name := placeholder[2 : len(placeholder)-1]
+		if !containsString(a.EnvVars, name) {
+			return placeholder
+		}
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+		return placeholder
+	})
+}
+
+// withEnv returns a copy of the api in which the placeholders of the host, the base path,
+// and hence the server urls, and of the info are replaced by the environment variables
+func (a *API) withEnv() *API {
+	doc := a.Clone()
+	doc.Host = a.expandEnv(a.Host)
+	doc.BasePath = a.expandEnv(a.BasePath)
+	doc.Info.Title = a.expandEnv(a.Info.Title)
+	doc.Info.Description = a.expandEnv(a.Info.Description)
+	doc.Info.Version = a.expandEnv(a.Info.Version)
+	doc.Info.TermsOfService = a.expandEnv(a.Info.TermsOfService)
+	doc.Info.License.Name = a.expandEnv(a.Info.License.Name)
+	doc.Info.License.URL = a.expandEnv(a.Info.License.URL)
+	if a.Info.Contact != nil {
+		doc.Info.Contact = &Contact{
+			Name:  a.expandEnv(a.Info.Contact.Name),
+			URL:   a.expandEnv(a.Info.Contact.URL),
+			Email: a.expandEnv(a.Info.Contact.Email),
+		}
+	}
+	return doc
+}
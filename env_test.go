@@ -0,0 +1,51 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPI_EncodeEnv(t *testing.T) {
+	assert.NoError(t, os.Setenv("SWAG_TEST_HOST", "api.staging.example.com"))
+	assert.NoError(t, os.Setenv("SWAG_TEST_SECRET", "secret"))
+	defer os.Unsetenv("SWAG_TEST_HOST")
+	defer os.Unsetenv("SWAG_TEST_SECRET")
+
+	api := New()
+	api.Host = "${SWAG_TEST_HOST}"
+	api.BasePath = "/${SWAG_TEST_UNSET}"
+	api.Info.Description = "served by ${SWAG_TEST_HOST}, ${SWAG_TEST_SECRET}"
+	api.Info.Contact = &Contact{Name: "ops", URL: "https://${SWAG_TEST_HOST}/support", Email: "ops@${SWAG_TEST_HOST}"}
+	api.EnvVars = []string{"SWAG_TEST_HOST", "SWAG_TEST_UNSET"}
+
+	doc := decodeDoc(t, api)
+	assert.Equal(t, "api.staging.example.com", doc.Host)
+	assert.Equal(t, "/${SWAG_TEST_UNSET}", doc.BasePath)
+	assert.Equal(t, "served by api.staging.example.com, ${SWAG_TEST_SECRET}", doc.Info.Description)
+	assert.Equal(t, "ops@api.staging.example.com", doc.Info.Contact.Email)
+	assert.Equal(t, "https://api.staging.example.com/support", doc.Info.Contact.URL)
+	// the placeholders are resolved at every rendering
+	assert.Equal(t, "${SWAG_TEST_HOST}", api.Host)
+
+	api.OpenAPI = OpenAPI3
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	assert.Contains(t, buf.String(), `"url":"http://api.staging.example.com/${SWAG_TEST_UNSET}"`)
+}
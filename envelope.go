@@ -0,0 +1,127 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"reflect"
+)
+
+// Envelope wraps the bodies of the responses of every operation and of the responses section in a common model, e.g.
+//
+//	type Reply struct {
+//		Code    int         `json:"code"`
+//		Message string      `json:"message"`
+//		Data    interface{} `json:"data"`
+//	}
+//
+// is set with option.Envelope(Reply{}, "data"); an operation opts out with endpoint.NoEnvelope,
+// except for the responses it references from the responses section, which are wrapped for every operation
+type Envelope struct {
+	// Prototype is the envelope model
+	Prototype interface{}
+	// Field is the json name of the property of the envelope carrying the response body; defaults to data
+	Field string
+}
+
+func (v *Envelope) field() string {
+	if v.Field == "" {
+		return "data"
+	}
+	return v.Field
+}
+
+// wrap returns the schema of the envelope whose field is the schema of the response body
+func (v *Envelope) wrap(obj Object, schema *Schema) *Schema {
+	properties := make(map[string]Property, len(obj.Properties)+1)
+	for k, p := range obj.Properties {
+		properties[k] = p
+	}
+	properties[v.field()] = Property{
+		Type:       schema.Type,
+		Format:     schema.Format,
+		Ref:        schema.Ref,
+		Items:      schema.Items,
+		Required:   schema.Required,
+		Properties: schema.Properties,
+	}
+	return &Schema{
+		Type:       "object",
+		Required:   obj.Required,
+		Properties: properties,
+	}
+}
+
+// enveloped returns a copy of the api in which the response bodies of the operations and of the responses section
+// are wrapped in the envelope, except those of the operations opting out and those already of the envelope type;
+// the responses section is shared, so its responses are wrapped even when referenced by an operation opting out
+func (a *API) enveloped() *API {
+	doc := a.Clone()
+	obj := defineObject(a.Envelope.Prototype, "")
+
+	// the definitions referenced by the envelope, without the envelope itself
+	doc.Definitions = make(map[string]Object, len(a.Definitions))
+	for k, v := range a.Definitions {
+		doc.Definitions[k] = v
+	}
+	for k, v := range define(a.Envelope.Prototype) {
+		if _, ok := doc.Definitions[k]; !ok && k != obj.Name {
+			doc.Definitions[k] = v
+		}
+	}
+
+	envelopeType := elemType(reflect.TypeOf(a.Envelope.Prototype))
+	wrap := func(r Response) Response {
+		if r.Schema != nil && (r.Schema.Prototype == nil || elemType(reflect.TypeOf(r.Schema.Prototype)) != envelopeType) {
+			r.Schema = a.Envelope.wrap(obj, r.Schema)
+		}
+		return r
+	}
+
+	if a.Responses != nil {
+		doc.Responses = make(map[string]Response, len(a.Responses))
+		for name, r := range a.Responses {
+			doc.Responses[name] = wrap(r)
+		}
+	}
+	if a.Paths == nil {
+		return doc
+	}
+
+	doc.Paths = make(map[string]*Endpoints, len(a.Paths))
+	for p, endpoints := range a.Paths {
+		v := &Endpoints{}
+		endpoints.Walk(func(endpoint *Endpoint) {
+			e := *endpoint
+			if !e.NoEnvelope {
+				responses := make(map[string]Response, len(e.Responses))
+				for code, r := range e.Responses {
+					responses[code] = wrap(r)
+				}
+				e.Responses = responses
+			}
+			v.set(e.Method, &e)
+		})
+		doc.Paths[p] = v
+	}
+	return doc
+}
+
+// elemType returns the type pointed to by t, e.g. Reply for *Reply, or nil if t is nil
+func elemType(t reflect.Type) reflect.Type {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,104 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Reply struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data"`
+	Trace   *ReplyTrace `json:"trace,omitempty"`
+}
+
+type ReplyTrace struct {
+	ID string `json:"id"`
+}
+
+func TestEnvelope(t *testing.T) {
+	api := New()
+	api.Envelope = &Envelope{Prototype: Reply{}}
+	api.AddEndpoint(
+		&Endpoint{Method: http.MethodGet, Path: "/pets", Responses: map[string]Response{
+			"200": {Description: "the pets", Schema: MakeSchema([]Pet{})},
+			"400": {Description: "bad request", Schema: MakeSchema(Reply{})},
+			"204": {Description: "no pets"},
+		}},
+		&Endpoint{Method: http.MethodGet, Path: "/pets/export", NoEnvelope: true, Responses: map[string]Response{
+			"200": {Description: "the export", Schema: MakeSchema([]Pet{})},
+		}},
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, api.Encode(&buf))
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	responses := func(p string) map[string]interface{} {
+		return doc["paths"].(map[string]interface{})[p].(map[string]interface{})["get"].(map[string]interface{})["responses"].(map[string]interface{})
+	}
+
+	schema := responses("/pets")["200"].(map[string]interface{})["schema"].(map[string]interface{})
+	assert.Equal(t, "object", schema["type"])
+	properties := schema["properties"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "integer", "format": "int32"}, properties["code"])
+	assert.Equal(t, map[string]interface{}{"type": "array", "items": map[string]interface{}{"$ref": "#/definitions/github.com_zc2638_swag.Pet"}}, properties["data"])
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/github.com_zc2638_swag.ReplyTrace"}, properties["trace"])
+
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/github.com_zc2638_swag.Reply"}, responses("/pets")["400"].(map[string]interface{})["schema"])
+	assert.NotContains(t, responses("/pets")["204"], "schema")
+	assert.Equal(t, "array", responses("/pets/export")["200"].(map[string]interface{})["schema"].(map[string]interface{})["type"])
+
+	definitions := doc["definitions"].(map[string]interface{})
+	assert.Contains(t, definitions, "github.com_zc2638_swag.ReplyTrace")
+	assert.Equal(t, "array", api.Paths["/pets"].Get.Responses["200"].Schema.Type, "the api is left unchanged")
+}
+
+func TestEnvelopePointerAndResponseRef(t *testing.T) {
+	api := New()
+	api.Envelope = &Envelope{Prototype: &Reply{}}
+	api.Responses = map[string]Response{
+		"NotFound": {Description: "not found", Schema: MakeSchema(Reply{})},
+		"Pets":     {Description: "the pets", Schema: MakeSchema([]Pet{})},
+	}
+	api.AddEndpoint(&Endpoint{Method: http.MethodGet, Path: "/pets", Responses: map[string]Response{
+		"200": {Ref: "#/responses/Pets"},
+		"400": {Description: "bad request", Schema: MakeSchema(&Reply{})},
+		"404": {Ref: "#/responses/NotFound"},
+	}})
+
+	var buf bytes.Buffer
+	require.NoError(t, api.Encode(&buf))
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	responses := doc["paths"].(map[string]interface{})["/pets"].(map[string]interface{})["get"].(map[string]interface{})["responses"].(map[string]interface{})
+	// a pointer to the envelope is the envelope
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/github.com_zc2638_swag.Reply"}, responses["400"].(map[string]interface{})["schema"])
+	assert.Equal(t, map[string]interface{}{"$ref": "#/responses/Pets"}, responses["200"])
+
+	section := doc["responses"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"$ref": "#/definitions/github.com_zc2638_swag.Reply"}, section["NotFound"].(map[string]interface{})["schema"])
+	schema := section["Pets"].(map[string]interface{})["schema"].(map[string]interface{})
+	assert.Equal(t, "object", schema["type"])
+	assert.Equal(t, "array", schema["properties"].(map[string]interface{})["data"].(map[string]interface{})["type"])
+}
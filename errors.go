@@ -0,0 +1,70 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	// ErrDuplicatePath is returned when an operation is already registered with the same method and an equivalent path
+	ErrDuplicatePath = errors.New("duplicate path")
+	// ErrInvalidParameter is returned when a parameter cannot be represented by the swagger definition
+	ErrInvalidParameter = errors.New("invalid parameter")
+	// ErrInvalidMethod is returned when the method of an operation is not a http method supported by the definition
+	ErrInvalidMethod = errors.New("invalid method")
+	// ErrUnsupportedType is returned when a type cannot be represented by the swagger definition
+	ErrUnsupportedType = errors.New("unsupported type")
+	// ErrInvalidSecurity is returned when a security scheme is incomplete,
+	// or when a security requirement references an undefined scheme
+	ErrInvalidSecurity = errors.New("invalid security")
+	// ErrUnsupportedFeature is returned when the swagger 2.0 rendering meets an OpenAPI 3 only feature
+	// with the UnsupportedError policy
+	ErrUnsupportedFeature = errors.New("unsupported feature")
+)
+
+// ValidationError describes the failure of an endpoint validation;
+// it wraps one of the sentinel errors, so callers can branch on it with errors.Is
+type ValidationError struct {
+	Method string
+	Path   string
+	// Field is the parameter, response or type at fault, if any
+	Field string
+	Err   error
+	// Reason details the failure
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, 4)
+	if e.Method != "" || e.Path != "" {
+		parts = append(parts, strings.TrimSpace(strings.ToUpper(e.Method)+" "+e.Path))
+	}
+	if e.Field != "" {
+		parts = append(parts, e.Field)
+	}
+	parts = append(parts, e.Err.Error())
+	msg := strings.Join(parts, ": ")
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// Unwrap returns the sentinel error
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,164 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Extender is implemented by the models documenting vendor extensions on their definition
+type Extender interface {
+	SwaggerExtensions() map[string]interface{}
+}
+
+var extenderType = reflect.TypeOf((*Extender)(nil)).Elem()
+
+// ExtensionKey returns the key prefixed with x- as required by the specification extensions
+func ExtensionKey(key string) string {
+	if strings.HasPrefix(key, "x-") {
+		return key
+	}
+	return "x-" + key
+}
+
+// marshalExtensions appends the extensions to the json object of v with their keys prefixed with x-,
+// keeping the field order of v
+func marshalExtensions(v interface{}, ext map[string]interface{}) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(ext) == 0 {
+		return data, err
+	}
+
+	keys := make([]string, 0, len(ext))
+	for k := range ext {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.Write(data[:len(data)-1])
+	for i, k := range keys {
+		value, err := json.Marshal(ext[k])
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(strconv.Quote(ExtensionKey(k)))
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// tagExtensions returns the x- keys of the struct tag, e.g. x-order:"1";
+// the values are decoded as json if valid, and kept as strings otherwise
+func tagExtensions(tag reflect.StructTag) map[string]interface{} {
+	var ext map[string]interface{}
+	// the tag is scanned the same way as reflect.StructTag.Lookup does
+	for tag != "" {
+		tag = reflect.StructTag(strings.TrimLeft(string(tag), " "))
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := string(tag[:i])
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		quoted := string(tag[:i+1])
+		tag = tag[i+1:]
+		if !strings.HasPrefix(key, "x-") {
+			continue
+		}
+		value, err := strconv.Unquote(quoted)
+		if err != nil {
+			break
+		}
+
+		var v interface{} = value
+		decoder := json.NewDecoder(strings.NewReader(value))
+		decoder.UseNumber()
+		var decoded interface{}
+		if err := decoder.Decode(&decoded); err == nil && !decoder.More() {
+			v = decoded
+		}
+		if ext == nil {
+			ext = make(map[string]interface{})
+		}
+		ext[key] = v
+	}
+	return ext
+}
+
+// typeExtensions returns the extensions of the definition of the type if it implements Extender
+func typeExtensions(t reflect.Type) map[string]interface{} {
+	switch {
+	case t.Implements(extenderType):
+		return reflect.Zero(t).Interface().(Extender).SwaggerExtensions()
+	case reflect.PtrTo(t).Implements(extenderType):
+		return reflect.New(t).Interface().(Extender).SwaggerExtensions()
+	}
+	return nil
+}
+
+// MarshalJSON encodes the API together with its extensions
+func (a API) MarshalJSON() ([]byte, error) {
+	type alias API
+	return marshalExtensions(alias(a), a.Extensions)
+}
+
+// MarshalJSON encodes the Info together with its extensions
+func (i Info) MarshalJSON() ([]byte, error) {
+	type alias Info
+	return marshalExtensions(alias(i), i.Extensions)
+}
+
+// MarshalJSON encodes the Endpoint together with its extensions
+func (e Endpoint) MarshalJSON() ([]byte, error) {
+	type alias Endpoint
+	return marshalExtensions(alias(e), e.Extensions)
+}
+
+// MarshalJSON encodes the Object together with its extensions
+func (o Object) MarshalJSON() ([]byte, error) {
+	type alias Object
+	return marshalExtensions(alias(o), o.Extensions)
+}
+
+// MarshalJSON encodes the Property together with its extensions
+func (p Property) MarshalJSON() ([]byte, error) {
+	type alias Property
+	return marshalExtensions(alias(p), p.Extensions)
+}
@@ -0,0 +1,86 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Gadget struct {
+	ID    string `json:"id" x-order:"1" x-go-name:"GadgetID"`
+	Specs string `json:"specs" x-flags:"[\"a\",\"b\"]"`
+}
+
+func (Gadget) SwaggerExtensions() map[string]interface{} {
+	return map[string]interface{}{"x-table": "gadgets"}
+}
+
+func TestTagExtensions(t *testing.T) {
+	field, _ := reflect.TypeOf(Gadget{}).FieldByName("ID")
+	assert.Equal(t, map[string]interface{}{"x-order": json.Number("1"), "x-go-name": "GadgetID"}, tagExtensions(field.Tag))
+	assert.Nil(t, tagExtensions(`json:"id"`))
+	assert.Nil(t, tagExtensions(`broken`))
+}
+
+func TestMarshalExtensions(t *testing.T) {
+	data, err := marshalExtensions(struct {
+		A int `json:"a"`
+	}{1}, map[string]interface{}{"b": true, "x-c": "c"})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1,"x-b":true,"x-c":"c"}`, string(data))
+
+	data, err = marshalExtensions(struct{}{}, map[string]interface{}{"x-a": 1})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"x-a":1}`, string(data))
+}
+
+func TestAPI_EncodeExtensions(t *testing.T) {
+	api := New()
+	api.Extensions = map[string]interface{}{"x-tagGroups": []string{"pets"}}
+	api.Info.Extensions = map[string]interface{}{"x-logo": "logo.png"}
+	api.AddEndpoint(&Endpoint{
+		Path:       "/gadgets",
+		Method:     http.MethodGet,
+		Extensions: map[string]interface{}{"x-amazon-apigateway-integration": map[string]string{"type": "mock"}},
+		Responses:  map[string]Response{"200": {Description: "ok", Schema: MakeSchema(Gadget{})}},
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	var doc map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, []interface{}{"pets"}, doc["x-tagGroups"])
+	assert.Equal(t, "logo.png", doc["info"].(map[string]interface{})["x-logo"])
+	get := doc["paths"].(map[string]interface{})["/gadgets"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "mock"}, get["x-amazon-apigateway-integration"])
+
+	definition := doc["definitions"].(map[string]interface{})[DefinitionName(Gadget{})].(map[string]interface{})
+	assert.Equal(t, "gadgets", definition["x-table"])
+	properties := definition["properties"].(map[string]interface{})
+	assert.Equal(t, float64(1), properties["id"].(map[string]interface{})["x-order"])
+	assert.Equal(t, []interface{}{"a", "b"}, properties["specs"].(map[string]interface{})["x-flags"])
+
+	api.OpenAPI = OpenAPI3
+	oas, err := api.OpenAPIDocument()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"pets"}, oas.Extensions["x-tagGroups"])
+	assert.Equal(t, "gadgets", oas.Components.Schemas[DefinitionName(Gadget{})].Extensions["x-table"])
+}
@@ -0,0 +1,98 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"net/http"
+	"path"
+	"sort"
+	"strconv"
+)
+
+// fallbackMethods are the methods a path answers 405 to when it does not define them
+var fallbackMethods = []string{
+	http.MethodDelete,
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodPatch,
+	http.MethodPost,
+	http.MethodPut,
+}
+
+// Fallbacks are the handlers of the requests matching no operation of the api,
+// registered by the router adapters so that they answer as documented
+type Fallbacks struct {
+	// Paths lists a handler per path answering the methods the path does not define
+	Paths []PathFallback
+	// NotFound answers 404 to the requests matching no path
+	NotFound http.Handler
+}
+
+// PathFallback is the handler answering 405 to the methods not defined on a path
+type PathFallback struct {
+	// Path is the path with the base path, e.g. /v1/pets/{id}
+	Path string
+	// Methods are the methods not defined on the path
+	Methods []string
+	// Handler answers 405 with the Allow header listing the methods defined on the path
+	Handler http.Handler
+}
+
+// Fallbacks returns the fallback handlers of the api, sorted by path;
+// their messages are the descriptions of the 404 and 405 global responses if the api documents them
+func (a *API) Fallbacks() *Fallbacks {
+	f := &Fallbacks{
+		NotFound: fallbackHandler(http.StatusNotFound, a.GlobalResponses, ""),
+	}
+	routes := a.routes()
+	paths := make([]string, 0, len(routes))
+	for p := range routes {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		endpoints := routes[p]
+		var methods []string
+		for _, method := range fallbackMethods {
+			if endpoints.endpoint(method) == nil {
+				methods = append(methods, method)
+			}
+		}
+		if len(methods) == 0 {
+			continue
+		}
+		f.Paths = append(f.Paths, PathFallback{
+			Path:    path.Join(a.BasePath, p),
+			Methods: methods,
+			Handler: fallbackHandler(http.StatusMethodNotAllowed, a.GlobalResponses, endpoints.allow()),
+		})
+	}
+	return f
+}
+
+func fallbackHandler(code int, responses map[string]Response, allow string) http.Handler {
+	message := http.StatusText(code)
+	if r, ok := responses[strconv.Itoa(code)]; ok && r.Description != "" {
+		message = r.Description
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if allow != "" {
+			w.Header().Set("Allow", allow)
+		}
+		http.Error(w, message, code)
+	})
+}
@@ -0,0 +1,52 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_Fallbacks(t *testing.T) {
+	api := New()
+	api.BasePath = "/v1"
+	api.GlobalResponses = map[string]Response{"404": {Description: "no such resource"}}
+	api.AddEndpoint(
+		&Endpoint{Method: http.MethodGet, Path: "/pets"},
+		&Endpoint{Method: http.MethodPost, Path: "/pets"},
+		&Endpoint{Method: http.MethodGet, Path: "/pets/{id}"},
+	)
+
+	f := api.Fallbacks()
+	require.Len(t, f.Paths, 2)
+	assert.Equal(t, "/v1/pets", f.Paths[0].Path)
+	assert.Equal(t, []string{"DELETE", "HEAD", "OPTIONS", "PATCH", "PUT"}, f.Paths[0].Methods)
+	assert.Equal(t, "/v1/pets/{id}", f.Paths[1].Path)
+
+	w := httptest.NewRecorder()
+	f.Paths[0].Handler.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/v1/pets", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, POST", w.Header().Get("Allow"))
+	assert.Equal(t, "Method Not Allowed\n", w.Body.String())
+
+	w = httptest.NewRecorder()
+	f.NotFound.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/owners", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "no such resource\n", w.Body.String())
+}
@@ -0,0 +1,87 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/zc2638/swag/types"
+)
+
+// addFieldSelection documents the fields query parameter of the endpoint, listing the properties
+// of the model of its success response, and advertises the fieldSelection capability
+func (a *API) addFieldSelection(e *Endpoint) {
+	fields := a.responseFields(e)
+	if e.Capabilities == nil {
+		e.Capabilities = make(map[string][]string)
+	}
+	e.Capabilities["fieldSelection"] = fields
+
+	p := Parameter{
+		Name:             "fields",
+		In:               "query",
+		Type:             types.Array,
+		Items:            &Items{Type: types.String.String(), Enum: fields},
+		CollectionFormat: "csv",
+		Description:      "the fields of the results, all by default",
+	}
+	for i, v := range e.Parameters {
+		if v.In == p.In && v.Name == p.Name {
+			e.Parameters[i] = p
+			return
+		}
+	}
+	e.Parameters = append(e.Parameters, p)
+}
+
+// responseFields returns the sorted property names of the model of the lowest 2xx response of the endpoint,
+// or of its items if the response is an array
+func (a *API) responseFields(e *Endpoint) []string {
+	code := ""
+	for k := range e.Responses {
+		if n, err := strconv.Atoi(k); err == nil && n >= 200 && n < 300 && (code == "" || k < code) {
+			code = k
+		}
+	}
+	fields := make([]string, 0)
+	if code == "" {
+		return fields
+	}
+	response := e.Responses[code]
+	if response.Ref != "" {
+		response = a.Responses[strings.TrimPrefix(response.Ref, responsesPrefix)]
+	}
+	schema := response.Schema
+	if schema == nil {
+		return fields
+	}
+
+	properties := schema.Properties
+	ref := schema.Ref
+	if schema.Items != nil {
+		properties = schema.Items.Properties
+		ref = schema.Items.Ref
+	}
+	if ref != "" {
+		properties = a.Definitions[refName(ref)].Properties
+	}
+	for name := range properties {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
@@ -0,0 +1,69 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zc2638/swag/types"
+)
+
+type Statement struct {
+	ID     int    `json:"id"`
+	Amount int    `json:"amount"`
+	Status string `json:"status"`
+}
+
+func TestAPI_AddEndpointFieldSelection(t *testing.T) {
+	api := New()
+	list := &Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/invoices",
+		FieldSelection: true,
+		Responses: map[string]Response{
+			"200":     {Description: "ok", Schema: MakeSchema([]Statement{})},
+			"default": {Description: "error"},
+		},
+	}
+	get := &Endpoint{
+		Method:         http.MethodGet,
+		Path:           "/invoices/{id}",
+		FieldSelection: true,
+		Parameters:     []Parameter{{Name: "fields", In: "query", Type: types.String}},
+		Responses:      map[string]Response{"206": {Description: "partial", Schema: MakeSchema(Statement{})}},
+	}
+	api.AddEndpoint(list, get)
+
+	fields := []string{"amount", "id", "status"}
+	assert.Equal(t, fields, list.Capabilities["fieldSelection"])
+	assert.Equal(t, Parameter{
+		Name:             "fields",
+		In:               "query",
+		Type:             types.Array,
+		Items:            &Items{Type: "string", Enum: fields},
+		CollectionFormat: "csv",
+		Description:      "the fields of the results, all by default",
+	}, list.Parameters[0])
+
+	// the parameter declared by hand is replaced
+	assert.Len(t, get.Parameters, 1)
+	assert.Equal(t, fields, get.Parameters[0].Items.Enum)
+
+	empty := &Endpoint{Method: http.MethodDelete, Path: "/invoices", FieldSelection: true}
+	api.AddEndpoint(empty)
+	assert.Equal(t, []string{}, empty.Capabilities["fieldSelection"])
+}
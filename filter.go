@@ -0,0 +1,269 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Filter decides whether the endpoint is kept in the rendered definition
+type Filter func(path string, e *Endpoint) bool
+
+// filtered returns a copy of the api without the endpoints rejected by the filters;
+// tags and definitions only used by rejected endpoints are removed as well
+func (a *API) filtered() *API {
+	doc := a.Clone()
+	if a.Paths == nil {
+		return doc
+	}
+
+	doc.Paths = make(map[string]*Endpoints, len(a.Paths))
+	used := make(map[string]bool)
+	rejected := false
+	for p, endpoints := range a.Paths {
+		v := &Endpoints{}
+		kept := false
+		endpoints.Walk(func(e *Endpoint) {
+			keep := true
+			for _, filter := range a.Filters {
+				if !filter(p, e) {
+					keep = false
+					break
+				}
+			}
+			for _, tag := range e.Tags {
+				used[tag] = used[tag] || keep
+			}
+			if keep {
+				v.set(e.Method, e)
+				kept = true
+			} else {
+				rejected = true
+			}
+		})
+		if kept {
+			doc.Paths[p] = v
+		}
+	}
+
+	doc.Tags = make([]Tag, 0, len(a.Tags))
+	for _, tag := range a.Tags {
+		if keep, ok := used[tag.Name]; ok && !keep {
+			continue
+		}
+		doc.Tags = append(doc.Tags, tag)
+	}
+
+	if rejected {
+		// the definitions which are not referenced at all, e.g. registered for the clients, are kept
+		before, after := a.referencedDefinitions(), doc.referencedDefinitions()
+		doc.Definitions = make(map[string]Object, len(a.Definitions))
+		for name, obj := range a.Definitions {
+			if before[name] && !after[name] {
+				continue
+			}
+			doc.Definitions[name] = obj
+		}
+	}
+	return doc
+}
+
+// referencedDefinitions returns the names of the definitions referenced transitively
+// by the operations and their versioned variants, the parameters, the responses and the global responses of the api
+func (a *API) referencedDefinitions() map[string]bool {
+	var variants map[string]map[string]*Endpoints
+	if a.Versioning != nil {
+		variants = a.Versioning.variants
+	}
+	data, err := json.Marshal(struct {
+		Paths           map[string]*Endpoints            `json:"paths"`
+		Variants        map[string]map[string]*Endpoints `json:"variants"`
+		Parameters      map[string]Parameter             `json:"parameters"`
+		Responses       map[string]Response              `json:"responses"`
+		GlobalResponses map[string]Response              `json:"globalResponses"`
+		Definitions     map[string]Object                `json:"definitions"`
+	}{a.Paths, variants, a.Parameters, a.Responses, a.GlobalResponses, a.Definitions})
+	var v map[string]interface{}
+	if err != nil || json.Unmarshal(data, &v) != nil {
+		return nil
+	}
+	definitions, _ := v["definitions"].(map[string]interface{})
+	delete(v, "definitions")
+
+	used := make(map[string]bool)
+	var visit func(v interface{})
+	visit = func(v interface{}) {
+		countRefs(v, func(name string) {
+			if !used[name] {
+				used[name] = true
+				visit(definitions[name])
+			}
+		})
+	}
+	visit(v)
+	return used
+}
+
+// Visibility records the endpoints and tags hidden from the rendered definition at runtime;
+// it is safe for concurrent use
+type Visibility struct {
+	mu        sync.RWMutex
+	endpoints map[string]struct{}
+	tags      map[string]struct{}
+}
+
+// NewVisibility constructs a new visibility with every endpoint visible
+func NewVisibility() *Visibility {
+	return &Visibility{
+		endpoints: make(map[string]struct{}),
+		tags:      make(map[string]struct{}),
+	}
+}
+
+func visibilityKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// HideEndpoint hides the endpoint with the specified method and path
+func (v *Visibility) HideEndpoint(method, path string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.endpoints[visibilityKey(method, path)] = struct{}{}
+}
+
+// ShowEndpoint reveals the endpoint with the specified method and path
+func (v *Visibility) ShowEndpoint(method, path string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.endpoints, visibilityKey(method, path))
+}
+
+// HideTag hides all endpoints with the specified tag
+func (v *Visibility) HideTag(tag string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.tags[tag] = struct{}{}
+}
+
+// ShowTag reveals the endpoints with the specified tag
+func (v *Visibility) ShowTag(tag string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.tags, tag)
+}
+
+// Filter returns a Filter that rejects the hidden endpoints
+func (v *Visibility) Filter() Filter {
+	return func(path string, e *Endpoint) bool {
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+
+		if _, ok := v.endpoints[visibilityKey(e.Method, path)]; ok {
+			return false
+		}
+		for _, tag := range e.Tags {
+			if _, ok := v.tags[tag]; ok {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// VisibilityState represents the hidden endpoints and tags
+type VisibilityState struct {
+	Endpoints []string `json:"endpoints"`
+	Tags      []string `json:"tags"`
+}
+
+// VisibilityChange represents a request to hide or show an endpoint or a tag
+type VisibilityChange struct {
+	Hidden bool   `json:"hidden"`
+	Method string `json:"method,omitempty"`
+	Path   string `json:"path,omitempty"`
+	Tag    string `json:"tag,omitempty"`
+}
+
+// State returns the hidden endpoints and tags
+func (v *Visibility) State() VisibilityState {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	state := VisibilityState{
+		Endpoints: make([]string, 0, len(v.endpoints)),
+		Tags:      make([]string, 0, len(v.tags)),
+	}
+	for k := range v.endpoints {
+		state.Endpoints = append(state.Endpoints, k)
+	}
+	for k := range v.tags {
+		state.Tags = append(state.Tags, k)
+	}
+	sort.Strings(state.Endpoints)
+	sort.Strings(state.Tags)
+	return state
+}
+
+// Handler returns an admin http.HandlerFunc;
+// GET returns the hidden endpoints and tags,
+// POST applies a json encoded VisibilityChange and returns the new state.
+// The requests are answered with 403 unless authorize accepts them, since anyone reaching the handler
+// could reveal the hidden endpoints; it must not be mounted next to the public documentation.
+// Handler panics if authorize is nil
+func (v *Visibility) Handler(authorize func(req *http.Request) bool) http.HandlerFunc {
+	if authorize == nil {
+		panic("swag: the visibility handler requires an authorizer")
+	}
+	return func(w http.ResponseWriter, req *http.Request) {
+		if !authorize(req) {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		switch req.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var change VisibilityChange
+			if err := json.NewDecoder(req.Body).Decode(&change); err != nil {
+				http.Error(w, "invalid visibility change", http.StatusBadRequest)
+				return
+			}
+			switch {
+			case change.Tag != "" && change.Hidden:
+				v.HideTag(change.Tag)
+			case change.Tag != "":
+				v.ShowTag(change.Tag)
+			case change.Method == "" || change.Path == "":
+				http.Error(w, "either tag or method and path must be specified", http.StatusBadRequest)
+				return
+			case change.Hidden:
+				v.HideEndpoint(change.Method, change.Path)
+			default:
+				v.ShowEndpoint(change.Method, change.Path)
+			}
+		default:
+			w.Header().Set("Allow", "GET, POST")
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(v.State())
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeDoc(t *testing.T, api *API) *API {
+	var buf bytes.Buffer
+	assert.Nil(t, api.Encode(&buf))
+	doc := &API{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), doc))
+	return doc
+}
+
+func TestVisibility(t *testing.T) {
+	v := NewVisibility()
+	api := New()
+	api.Filters = []Filter{v.Filter()}
+	api.WithTag("billing", "").AddEndpoint(
+		&Endpoint{Path: "/invoices", Method: http.MethodGet},
+	)
+	api.WithTag("users", "").AddEndpoint(
+		&Endpoint{Path: "/users", Method: http.MethodGet},
+		&Endpoint{Path: "/users", Method: http.MethodPost},
+	)
+
+	doc := decodeDoc(t, api)
+	assert.Len(t, doc.Paths, 2)
+	assert.Len(t, doc.Tags, 2)
+
+	v.HideEndpoint("get", "/users")
+	v.HideTag("billing")
+	doc = decodeDoc(t, api)
+	assert.Len(t, doc.Paths, 1)
+	assert.Nil(t, doc.Paths["/users"].Get)
+	assert.NotNil(t, doc.Paths["/users"].Post)
+	assert.Equal(t, []Tag{{Name: "users"}}, doc.Tags)
+	assert.Equal(t, VisibilityState{Endpoints: []string{"GET /users"}, Tags: []string{"billing"}}, v.State())
+
+	v.ShowEndpoint(http.MethodGet, "/users")
+	v.ShowTag("billing")
+	doc = decodeDoc(t, api)
+	assert.Len(t, doc.Paths, 2)
+	assert.NotNil(t, doc.Paths["/users"].Get)
+
+	// the original definition must stay untouched
+	assert.Len(t, api.Paths, 2)
+}
+
+func TestVisibility_Handler(t *testing.T) {
+	v := NewVisibility()
+	handler := v.Handler(func(req *http.Request) bool {
+		return req.Header.Get("Authorization") == "Bearer admin"
+	})
+
+	tests := []struct {
+		name     string
+		method   string
+		body     string
+		wantCode int
+		want     VisibilityState
+	}{
+		{
+			name:     "hide endpoint",
+			method:   http.MethodPost,
+			body:     `{"hidden": true, "method": "get", "path": "/users"}`,
+			wantCode: http.StatusOK,
+			want:     VisibilityState{Endpoints: []string{"GET /users"}, Tags: []string{}},
+		},
+		{
+			name:     "hide tag",
+			method:   http.MethodPost,
+			body:     `{"hidden": true, "tag": "billing"}`,
+			wantCode: http.StatusOK,
+			want:     VisibilityState{Endpoints: []string{"GET /users"}, Tags: []string{"billing"}},
+		},
+		{
+			name:     "show endpoint",
+			method:   http.MethodPost,
+			body:     `{"hidden": false, "method": "GET", "path": "/users"}`,
+			wantCode: http.StatusOK,
+			want:     VisibilityState{Endpoints: []string{}, Tags: []string{"billing"}},
+		},
+		{
+			name:     "state",
+			method:   http.MethodGet,
+			wantCode: http.StatusOK,
+			want:     VisibilityState{Endpoints: []string{}, Tags: []string{"billing"}},
+		},
+		{
+			name:     "invalid",
+			method:   http.MethodPost,
+			body:     `{"hidden": true}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "method not allowed",
+			method:   http.MethodDelete,
+			wantCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "forbidden",
+			method:   http.MethodGet,
+			wantCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, "/admin/visibility", strings.NewReader(tt.body))
+			if tt.wantCode != http.StatusForbidden {
+				req.Header.Set("Authorization", "Bearer admin")
+			}
+			handler.ServeHTTP(w, req)
+			assert.Equal(t, tt.wantCode, w.Code)
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			var state VisibilityState
+			assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &state))
+			assert.Equal(t, tt.want, state)
+		})
+	}
+
+	assert.Panics(t, func() { v.Handler(nil) })
+}
+
+type Bill struct {
+	Lines []BillLine `json:"lines"`
+}
+
+type BillLine struct {
+	Amount int `json:"amount"`
+}
+
+type Parcel struct {
+	ID string `json:"id"`
+}
+
+func TestAPI_FilteredDefinitions(t *testing.T) {
+	v := NewVisibility()
+	api := New()
+	api.Filters = []Filter{v.Filter()}
+	api.Definitions = map[string]Object{"Standalone": {Type: "object"}}
+	api.AddEndpoint(
+		&Endpoint{Path: "/invoices", Method: http.MethodGet, Responses: map[string]Response{
+			"200": {Description: "ok", Schema: MakeSchema(Bill{})},
+		}},
+		&Endpoint{Path: "/shipments", Method: http.MethodGet, Responses: map[string]Response{
+			"200": {Description: "ok", Schema: MakeSchema([]Parcel{})},
+		}},
+	)
+
+	doc := decodeDoc(t, api)
+	assert.Len(t, doc.Definitions, 4)
+
+	v.HideEndpoint(http.MethodGet, "/invoices")
+	doc = decodeDoc(t, api)
+	assert.Len(t, doc.Definitions, 2)
+	assert.Contains(t, doc.Definitions, "Standalone")
+	assert.Contains(t, doc.Definitions, DefinitionName(Parcel{}))
+
+	// the original definition must stay untouched
+	assert.Len(t, api.Definitions, 4)
+}
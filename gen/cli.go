@@ -0,0 +1,214 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"bytes"
+	"go/format"
+	"io"
+	"path"
+	"text/template"
+
+	"github.com/zc2638/swag"
+)
+
+// CLIOption provides configuration options to the cli generator
+type CLIOption func(c *cliConfig)
+
+type cliConfig struct {
+	Name   string
+	Title  string
+	Server string
+}
+
+// CLIName sets the name of the root command; defaults to "api"
+func CLIName(name string) CLIOption {
+	return func(c *cliConfig) {
+		c.Name = name
+	}
+}
+
+// CLIServer sets the default server address used by the generated cli;
+// defaults to the first scheme and the host of the api
+func CLIServer(server string) CLIOption {
+	return func(c *cliConfig) {
+		c.Server = server
+	}
+}
+
+type cliOperation struct {
+	Name    string
+	Summary string
+	Method  string
+	Path    string
+	Params  []swag.Parameter
+}
+
+// CLI writes the go source of a cobra based command line client for the api to w;
+// every operation becomes a subcommand named after its operationId,
+// and every parameter becomes a flag of that subcommand
+func CLI(w io.Writer, api *swag.API, options ...CLIOption) error {
+	cfg := &cliConfig{
+		Name:   "api",
+		Title:  api.Info.Title,
+		Server: defaultServer(api),
+	}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	operations := make([]cliOperation, 0)
+	walk(api, func(p string, e *swag.Endpoint) {
+		operations = append(operations, cliOperation{
+			Name:    e.OperationID,
+			Summary: e.Summary,
+			Method:  e.Method,
+			Path:    path.Join("/", api.BasePath, p),
+			Params:  e.Parameters,
+		})
+	})
+
+	var buf bytes.Buffer
+	err := cliTemplate.Execute(&buf, map[string]interface{}{
+		"Config":     cfg,
+		"Operations": operations,
+	})
+	if err != nil {
+		return err
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(source)
+	return err
+}
+
+var cliTemplate = template.Must(template.New("cli").Parse(`// Code generated by swag. DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var server string
+
+func main() {
+	root := &cobra.Command{
+		Use:          {{printf "%q" .Config.Name}},
+		Short:        {{printf "%q" .Config.Title}},
+		SilenceUsage: true,
+	}
+	root.PersistentFlags().StringVar(&server, "server", {{printf "%q" .Config.Server}}, "the server address of the api")
+{{range .Operations}}
+	root.AddCommand(newCommand({{printf "%q" .Name}}, {{printf "%q" .Summary}}, {{printf "%q" .Method}}, {{printf "%q" .Path}}, []flag{
+{{- range .Params}}
+		{in: {{printf "%q" .In}}, name: {{printf "%q" .Name}}, usage: {{printf "%q" .Description}}, required: {{.Required}}},
+{{- end}}
+	}))
+{{- end}}
+
+	if err := root.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
+
+type flag struct {
+	in       string
+	name     string
+	usage    string
+	required bool
+	value    string
+}
+
+func newCommand(name, short, method, path string, flags []flag) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   name,
+		Short: short,
+	}
+	for i := range flags {
+		f := &flags[i]
+		cmd.Flags().StringVar(&f.value, f.name, "", f.usage)
+		if f.required {
+			_ = cmd.MarkFlagRequired(f.name)
+		}
+	}
+
+	cmd.RunE = func(cmd *cobra.Command, _ []string) error {
+		uri := path
+		query := url.Values{}
+		form := url.Values{}
+		header := http.Header{}
+		var body io.Reader
+		for _, f := range flags {
+			if !cmd.Flags().Changed(f.name) {
+				continue
+			}
+			switch f.in {
+			case "path":
+				uri = strings.ReplaceAll(uri, "{"+f.name+"}", url.PathEscape(f.value))
+			case "query":
+				query.Add(f.name, f.value)
+			case "header":
+				header.Set(f.name, f.value)
+			case "formData":
+				form.Add(f.name, f.value)
+			case "body":
+				body = strings.NewReader(f.value)
+				header.Set("Content-Type", "application/json")
+			}
+		}
+		if len(form) > 0 {
+			body = strings.NewReader(form.Encode())
+			header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+
+		target := strings.TrimSuffix(server, "/") + uri
+		if len(query) > 0 {
+			target += "?" + query.Encode()
+		}
+		req, err := http.NewRequestWithContext(cmd.Context(), method, target, body)
+		if err != nil {
+			return err
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if _, err := io.Copy(cmd.OutOrStdout(), resp.Body); err != nil {
+			return err
+		}
+		if resp.StatusCode >= http.StatusBadRequest {
+			return fmt.Errorf("unexpected status: %s", resp.Status)
+		}
+		return nil
+	}
+	return cmd
+}
+`))
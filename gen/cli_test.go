@@ -0,0 +1,69 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/endpoint"
+	"github.com/zc2638/swag/types"
+)
+
+type Pet struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+func newAPI() *swag.API {
+	api := swag.New()
+	api.AddEndpoint(
+		endpoint.New(http.MethodGet, "/pets/{id}",
+			endpoint.Summary("Find pet by ID"),
+			endpoint.Path("id", types.Integer, "ID of pet", true),
+			endpoint.Query("verbose", types.Boolean, "verbose output", false),
+			endpoint.Response(http.StatusOK, "success", endpoint.Schema(Pet{})),
+		),
+		endpoint.New(http.MethodPost, "/pets",
+			endpoint.Summary("Add a new pet"),
+			endpoint.Body(Pet{}, "the pet", true),
+		),
+	)
+	return api
+}
+
+func TestCLI(t *testing.T) {
+	var buf bytes.Buffer
+	err := CLI(&buf, newAPI(), CLIName("petctl"), CLIServer("https://example.com"))
+	assert.Nil(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "main.go", buf.Bytes(), 0)
+	assert.Nil(t, err)
+
+	source := buf.String()
+	assert.Contains(t, source, `Use:          "petctl"`)
+	assert.Contains(t, source, `"https://example.com"`)
+	assert.Contains(t, source, `newCommand("getPetsId", "Find pet by ID", "GET", "/pets/{id}"`)
+	assert.Contains(t, source, `{in: "path", name: "id", usage: "ID of pet", required: true}`)
+	assert.Contains(t, source, `{in: "query", name: "verbose", usage: "verbose output", required: false}`)
+	assert.Contains(t, source, `newCommand("postPets", "Add a new pet", "POST", "/pets"`)
+	assert.Contains(t, source, `{in: "body", name: "body", usage: "the pet", required: true}`)
+}
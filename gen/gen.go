@@ -0,0 +1,71 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/zc2638/swag"
+)
+
+// walk invokes the callback for each endpoint sorted by path and method,
+// with the references to the named parameters of the api replaced by the parameters
+func walk(api *swag.API, callback func(rawPath string, e *swag.Endpoint)) {
+	paths := make([]string, 0, len(api.Paths))
+	for p := range api.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	for _, p := range paths {
+		endpoints := make([]*swag.Endpoint, 0)
+		api.Paths[p].Walk(func(e *swag.Endpoint) {
+			endpoints = append(endpoints, e)
+		})
+		sort.SliceStable(endpoints, func(i, j int) bool {
+			return endpoints[i].Method < endpoints[j].Method
+		})
+		for _, e := range endpoints {
+			callback(p, withParameters(api, e))
+		}
+	}
+}
+
+func withParameters(api *swag.API, e *swag.Endpoint) *swag.Endpoint {
+	v := *e
+	v.Parameters = make([]swag.Parameter, 0, len(e.Parameters))
+	for _, p := range e.Parameters {
+		if p.Ref != "" {
+			if named, ok := api.Parameters[strings.TrimPrefix(p.Ref, "#/parameters/")]; ok {
+				p = named
+			}
+		}
+		v.Parameters = append(v.Parameters, p)
+	}
+	return &v
+}
+
+func defaultServer(api *swag.API) string {
+	scheme := "http"
+	if len(api.Schemes) > 0 {
+		scheme = api.Schemes[0]
+	}
+	host := api.Host
+	if host == "" {
+		host = "localhost"
+	}
+	return scheme + "://" + strings.TrimSuffix(host, "/")
+}
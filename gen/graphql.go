@@ -0,0 +1,204 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zc2638/swag"
+)
+
+// LossyConversion reports a definition or a property which could not be converted faithfully
+type LossyConversion struct {
+	Definition string
+	// Field is the json name of the property, or empty for the definition itself
+	Field  string
+	Reason string
+}
+
+func (l LossyConversion) String() string {
+	if l.Field == "" {
+		return l.Definition + ": " + l.Reason
+	}
+	return l.Definition + "." + l.Field + ": " + l.Reason
+}
+
+var graphqlName = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// GraphQL writes the definitions of the api to w as GraphQL SDL types on a best-effort basis,
+// and reports the conversions losing information, e.g. 64-bit integers narrowed to Int
+func GraphQL(w io.Writer, api *swag.API) ([]LossyConversion, error) {
+	g := &graphqlGenerator{names: typeNames(api.Definitions)}
+	defs := make([]string, 0, len(api.Definitions))
+	for name := range api.Definitions {
+		defs = append(defs, name)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return g.names[defs[i]] < g.names[defs[j]]
+	})
+
+	var body strings.Builder
+	for _, name := range defs {
+		g.definition(&body, g.names[name], api.Definitions[name])
+	}
+
+	var b strings.Builder
+	b.WriteString("# Code generated by swag. DO NOT EDIT.\n")
+	if g.json {
+		b.WriteString("\n\"any json value\"\nscalar JSON\n")
+	}
+	b.WriteString(body.String())
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return nil, err
+	}
+	return g.losses, nil
+}
+
+type graphqlGenerator struct {
+	names  map[string]string
+	losses []LossyConversion
+	json   bool
+	// enums are written after the type being written
+	enums strings.Builder
+}
+
+func (g *graphqlGenerator) lossy(definition, field, reason string, args ...interface{}) {
+	g.losses = append(g.losses, LossyConversion{
+		Definition: definition,
+		Field:      field,
+		Reason:     fmt.Sprintf(reason, args...),
+	})
+}
+
+func (g *graphqlGenerator) description(b *strings.Builder, indent, text string) {
+	if text = comment(text); text != "" {
+		b.WriteString(indent + quote(text, "") + "\n")
+	}
+}
+
+func (g *graphqlGenerator) definition(b *strings.Builder, name string, obj swag.Object) {
+	b.WriteString("\n")
+	g.description(b, "", obj.Description)
+	if obj.Type != "object" {
+		g.lossy(name, "", "%s definition declared as a custom scalar", obj.Type)
+		b.WriteString("scalar " + name + "\n")
+		return
+	}
+	if len(obj.Properties) == 0 {
+		g.lossy(name, "", "object without properties declared as a custom scalar")
+		b.WriteString("scalar " + name + "\n")
+		return
+	}
+
+	required := make(map[string]bool, len(obj.Required))
+	for _, v := range obj.Required {
+		required[v] = true
+	}
+	props := make([]string, 0, len(obj.Properties))
+	for p := range obj.Properties {
+		props = append(props, p)
+	}
+	sort.Strings(props)
+
+	b.WriteString("type " + name + " {\n")
+	for _, p := range props {
+		prop := obj.Properties[p]
+		field := p
+		if !graphqlName.MatchString(field) {
+			field = camelName(p)
+			g.lossy(name, p, "renamed to %s", field)
+		}
+		typ := g.propertyType(name, p, prop)
+		if required[p] {
+			typ += "!"
+		}
+		g.description(b, "  ", prop.Description)
+		b.WriteString("  " + field + ": " + typ + "\n")
+	}
+	b.WriteString("}\n")
+	b.WriteString(g.enums.String())
+	g.enums.Reset()
+}
+
+func (g *graphqlGenerator) propertyType(definition, field string, prop swag.Property) string {
+	if len(prop.Enum) > 0 {
+		if prop.Type == "string" {
+			return g.enum(definition+exportName(field), definition, field, prop.Enum)
+		}
+		g.lossy(definition, field, "enum values of the %s dropped", prop.Type)
+	}
+	switch {
+	case prop.Ref != "":
+		return g.refName(prop.Ref)
+	case prop.Type == "array" && prop.Items != nil && prop.Items.Ref != "":
+		return "[" + g.refName(prop.Items.Ref) + "]"
+	case prop.Type == "array" && prop.Items != nil:
+		return "[" + g.scalar(definition, field, prop.Items.Type, prop.Items.Format) + "]"
+	case prop.Type == "array":
+		return "[" + g.scalar(definition, field, "", "") + "]"
+	}
+	return g.scalar(definition, field, prop.Type, prop.Format)
+}
+
+func (g *graphqlGenerator) refName(ref string) string {
+	name := strings.TrimPrefix(ref, "#/definitions/")
+	if v, ok := g.names[name]; ok {
+		return v
+	}
+	return exportName(name)
+}
+
+func (g *graphqlGenerator) scalar(definition, field, typ, format string) string {
+	switch typ {
+	case "integer":
+		if format != "int32" {
+			g.lossy(definition, field, "64-bit integer narrowed to Int")
+		}
+		return "Int"
+	case "number":
+		return "Float"
+	case "boolean":
+		return "Boolean"
+	case "string":
+		if format != "" {
+			g.lossy(definition, field, "%s format dropped", format)
+		}
+		return "String"
+	}
+	g.json = true
+	if typ == "object" {
+		g.lossy(definition, field, "free-form object mapped to JSON")
+	} else {
+		g.lossy(definition, field, "untyped value mapped to JSON")
+	}
+	return "JSON"
+}
+
+func (g *graphqlGenerator) enum(name, definition, field string, values []string) string {
+	g.enums.WriteString("\nenum " + name + " {\n")
+	for _, v := range values {
+		value := constantName(v)
+		if value != v {
+			g.lossy(definition, field, "enum value %q renamed to %s", v, value)
+		}
+		g.enums.WriteString("  " + value + "\n")
+	}
+	g.enums.WriteString("}\n")
+	return name
+}
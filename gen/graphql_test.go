@@ -0,0 +1,48 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraphQL(t *testing.T) {
+	var buf bytes.Buffer
+	losses, err := GraphQL(&buf, newOrderAPI())
+	assert.Nil(t, err)
+
+	source := buf.String()
+	assert.Contains(t, source, "scalar JSON\n")
+	assert.Contains(t, source, "type Order {\n")
+	assert.Contains(t, source, "  id: Int!\n")
+	assert.Contains(t, source, "  \"order status\"\n  status: OrderStatus\n")
+	assert.Contains(t, source, "  pets: [Pet]\n")
+	assert.Contains(t, source, "  metadata: JSON\n")
+	assert.Contains(t, source, "enum OrderStatus {\n  PLACED\n  IN_TRANSIT\n  DELIVERED\n}\n")
+	assert.Contains(t, source, "type Pet {\n")
+
+	reasons := make([]string, 0, len(losses))
+	for _, l := range losses {
+		reasons = append(reasons, l.String())
+	}
+	assert.Contains(t, reasons, `Order.status: enum value "in-transit" renamed to IN_TRANSIT`)
+	assert.Contains(t, reasons, "Order.priority: enum values of the integer dropped")
+	assert.Contains(t, reasons, "Order.metadata: untyped value mapped to JSON")
+	assert.Contains(t, reasons, "Pet.id: 64-bit integer narrowed to Int")
+	assert.NotContains(t, reasons, "Order.id: 64-bit integer narrowed to Int")
+}
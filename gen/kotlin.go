@@ -0,0 +1,129 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"io"
+	"text/template"
+
+	"github.com/zc2638/swag"
+)
+
+// KotlinOption provides configuration options to the kotlin generator
+type KotlinOption func(c *kotlinConfig)
+
+type kotlinConfig struct {
+	Package string
+}
+
+// KotlinPackage sets the package of the generated source; omitted by default
+func KotlinPackage(name string) KotlinOption {
+	return func(c *kotlinConfig) {
+		c.Package = name
+	}
+}
+
+var kotlinKeywords = map[string]bool{
+	"as": true, "break": true, "class": true, "continue": true, "do": true, "else": true,
+	"false": true, "for": true, "fun": true, "if": true, "in": true, "interface": true,
+	"is": true, "null": true, "object": true, "package": true, "return": true, "super": true,
+	"this": true, "throw": true, "true": true, "try": true, "typealias": true, "typeof": true,
+	"val": true, "var": true, "when": true, "while": true,
+}
+
+// Kotlin writes the definitions of the api to w as kotlin data classes serializable with kotlinx.serialization;
+// the string properties with enum values are typed by enum classes
+func Kotlin(w io.Writer, api *swag.API, options ...KotlinOption) error {
+	cfg := &kotlinConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	mapper := &typeMapper{
+		scalars: map[string]string{
+			"integer":       "Long",
+			"integer:int32": "Int",
+			"number":        "Double",
+			"number:float":  "Float",
+			"boolean":       "Boolean",
+			"string":        "String",
+			"object":        "Map<String, JsonElement>",
+			"":              "JsonElement",
+		},
+		list: func(elem string) string {
+			return "List<" + elem + ">"
+		},
+		field: func(name string) string {
+			name = camelName(name)
+			if kotlinKeywords[name] {
+				return "`" + name + "`"
+			}
+			return name
+		},
+		enumCase: constantName,
+	}
+	models, enums := buildModels(api, mapper)
+	return kotlinTemplate.Execute(w, map[string]interface{}{
+		"Config": cfg,
+		"Models": models,
+		"Enums":  enums,
+		"Json":   mapper.used["object"] || mapper.used[""],
+	})
+}
+
+var kotlinTemplate = template.Must(template.New("kotlin").Funcs(template.FuncMap{
+	"quote": func(s string) string {
+		return quote(s, "$")
+	},
+}).Parse(`// Code generated by swag. DO NOT EDIT.
+{{- if .Config.Package}}
+
+package {{.Config.Package}}
+{{- end}}
+
+import kotlinx.serialization.SerialName
+import kotlinx.serialization.Serializable
+{{- if .Json}}
+import kotlinx.serialization.json.JsonElement
+{{- end}}
+{{range .Models}}
+{{if .Description}}/** {{.Description}} */
+{{end -}}
+{{if .Alias -}}
+typealias {{.Name}} = {{.Alias}}
+{{else if .Fields -}}
+@Serializable
+data class {{.Name}}(
+{{- range .Fields}}
+{{- if .Description}}
+    /** {{.Description}} */
+{{- end}}
+    @SerialName({{quote .JSON}}) val {{.Name}}: {{.Type}}{{if not .Required}}? = null{{end}},
+{{- end}}
+)
+{{else -}}
+@Serializable
+class {{.Name}}
+{{end -}}
+{{end -}}
+{{range .Enums}}
+@Serializable
+enum class {{.Name}} {
+{{- range .Cases}}
+    @SerialName({{quote .Value}}) {{.Name}},
+{{- end}}
+}
+{{end -}}
+`))
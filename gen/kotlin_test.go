@@ -0,0 +1,66 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/endpoint"
+)
+
+type Order struct {
+	ID        int32                  `json:"id" required:"true"`
+	Status    string                 `json:"status" enum:"placed,in-transit,delivered" desc:"order status"`
+	Priority  int                    `json:"priority" enum:"1,2"`
+	Pets      []Pet                  `json:"pets"`
+	Metadata  map[string]interface{} `json:"metadata"`
+	Protected bool                   `json:"in"`
+}
+
+func newOrderAPI() *swag.API {
+	api := swag.New()
+	api.AddEndpoint(
+		endpoint.New(http.MethodGet, "/orders",
+			endpoint.Response(http.StatusOK, "success", endpoint.Schema(Order{})),
+		),
+	)
+	return api
+}
+
+func TestKotlin(t *testing.T) {
+	var buf bytes.Buffer
+	err := Kotlin(&buf, newOrderAPI(), KotlinPackage("com.example.api"))
+	assert.Nil(t, err)
+
+	source := buf.String()
+	assert.Contains(t, source, "package com.example.api")
+	assert.Contains(t, source, "import kotlinx.serialization.json.JsonElement")
+	assert.Contains(t, source, "data class Order(")
+	assert.Contains(t, source, `@SerialName("id") val id: Int,`)
+	assert.Contains(t, source, "/** order status */")
+	assert.Contains(t, source, `@SerialName("status") val status: OrderStatus? = null,`)
+	assert.Contains(t, source, "/** one of 1, 2 */")
+	assert.Contains(t, source, `@SerialName("pets") val pets: List<Pet>? = null,`)
+	assert.Contains(t, source, `@SerialName("metadata") val metadata: JsonElement? = null,`)
+	assert.Contains(t, source, "@SerialName(\"in\") val `in`: Boolean? = null,")
+	assert.Contains(t, source, "data class Pet(")
+	assert.Contains(t, source, "enum class OrderStatus {")
+	assert.Contains(t, source, `@SerialName("in-transit") IN_TRANSIT,`)
+}
@@ -0,0 +1,221 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/zc2638/swag"
+)
+
+// model is a definition described independently of the target language
+type model struct {
+	Name        string
+	Description string
+	// Alias is the aliased type of the definitions which are not objects
+	Alias  string
+	Fields []modelField
+}
+
+type modelField struct {
+	Name        string
+	JSON        string
+	Type        string
+	Description string
+	Required    bool
+}
+
+type modelEnum struct {
+	Name  string
+	Cases []modelEnumCase
+}
+
+type modelEnumCase struct {
+	Name  string
+	Value string
+}
+
+// typeMapper maps the swagger types to the types of a target language
+type typeMapper struct {
+	// scalars is keyed by type or by type:format, with "object" for free-form objects and "" for any value
+	scalars map[string]string
+	list    func(elem string) string
+	// field and enumCase convert the names into identifiers of the target language
+	field    func(name string) string
+	enumCase func(value string) string
+	// used records the scalar keys which were used
+	used map[string]bool
+}
+
+func (m *typeMapper) scalar(typ, format string) string {
+	key := typ
+	if _, ok := m.scalars[typ+":"+format]; ok {
+		key = typ + ":" + format
+	} else if _, ok := m.scalars[typ]; !ok {
+		key = ""
+	}
+	if m.used == nil {
+		m.used = make(map[string]bool)
+	}
+	m.used[key] = true
+	return m.scalars[key]
+}
+
+// buildModels describes the definitions of the api for the mapper, sorted by name;
+// the string properties with enum values are described by dedicated enums
+func buildModels(api *swag.API, m *typeMapper) ([]model, []modelEnum) {
+	names := typeNames(api.Definitions)
+	refName := func(ref string) string {
+		name := strings.TrimPrefix(ref, "#/definitions/")
+		if v, ok := names[name]; ok {
+			return v
+		}
+		return exportName(name)
+	}
+	typeOf := func(typ, format, ref string, items *swag.Items) string {
+		switch {
+		case ref != "":
+			return refName(ref)
+		case typ == "array" && items != nil && items.Ref != "":
+			return m.list(refName(items.Ref))
+		case typ == "array" && items != nil:
+			return m.list(m.scalar(items.Type, items.Format))
+		case typ == "array":
+			return m.list(m.scalar("", ""))
+		}
+		return m.scalar(typ, format)
+	}
+
+	defs := make([]string, 0, len(api.Definitions))
+	for name := range api.Definitions {
+		defs = append(defs, name)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return names[defs[i]] < names[defs[j]]
+	})
+
+	var (
+		models []model
+		enums  []modelEnum
+	)
+	for _, name := range defs {
+		obj := api.Definitions[name]
+		md := model{Name: names[name], Description: comment(obj.Description)}
+		if obj.Type != "object" {
+			md.Alias = m.scalar(obj.Type, obj.Format)
+			models = append(models, md)
+			continue
+		}
+
+		required := make(map[string]bool, len(obj.Required))
+		for _, v := range obj.Required {
+			required[v] = true
+		}
+		props := make([]string, 0, len(obj.Properties))
+		for p := range obj.Properties {
+			props = append(props, p)
+		}
+		sort.Strings(props)
+		for _, p := range props {
+			prop := obj.Properties[p]
+			field := modelField{
+				Name:        m.field(p),
+				JSON:        p,
+				Type:        typeOf(prop.Type, prop.Format, prop.Ref, prop.Items),
+				Description: comment(prop.Description),
+				Required:    required[p],
+			}
+			if len(prop.Enum) > 0 {
+				if prop.Type == "string" {
+					enum := modelEnum{Name: md.Name + exportName(p)}
+					for _, v := range prop.Enum {
+						enum.Cases = append(enum.Cases, modelEnumCase{Name: m.enumCase(v), Value: v})
+					}
+					enums = append(enums, enum)
+					field.Type = enum.Name
+				} else {
+					values := "one of " + strings.Join(prop.Enum, ", ")
+					if field.Description != "" {
+						values = field.Description + " (" + values + ")"
+					}
+					field.Description = values
+				}
+			}
+			md.Fields = append(md.Fields, field)
+		}
+		models = append(models, md)
+	}
+	return models, enums
+}
+
+// comment flattens the text into a single line fit for a comment of any of the target languages
+func comment(text string) string {
+	return strings.ReplaceAll(strings.Join(strings.Fields(text), " "), "*/", "* /")
+}
+
+// camelName converts the name into a lower camel case identifier, e.g. created_at to createdAt
+func camelName(name string) string {
+	s := []rune(exportName(name))
+	for i := 0; i < len(s) && unicode.IsUpper(s[i]); i++ {
+		if i > 0 && i+1 < len(s) && !unicode.IsUpper(s[i+1]) {
+			break
+		}
+		s[i] = unicode.ToLower(s[i])
+	}
+	return string(s)
+}
+
+// constantName converts the value into an upper snake case identifier, e.g. in-transit to IN_TRANSIT
+func constantName(value string) string {
+	var b strings.Builder
+	var prev rune
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			r = '_'
+		} else if unicode.IsUpper(r) && unicode.IsLower(prev) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+		prev = r
+	}
+	name := strings.Trim(b.String(), "_")
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "V" + name
+	}
+	return name
+}
+
+// quote returns the text as a double-quoted string literal, escaping the extra characters
+func quote(text string, escape string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range text {
+		switch {
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '"' || r == '\\' || strings.ContainsRune(escape, r):
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,604 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/types"
+)
+
+// ServerOption provides configuration options to the server generator
+type ServerOption func(c *serverConfig)
+
+type serverConfig struct {
+	Package string
+}
+
+// ServerPackage sets the package name of the generated source; defaults to "api"
+func ServerPackage(name string) ServerOption {
+	return func(c *serverConfig) {
+		c.Package = name
+	}
+}
+
+type serverField struct {
+	Name    string
+	Type    string
+	Tag     string
+	Comment string
+}
+
+type serverType struct {
+	Name    string
+	Comment string
+	// Underlying is set for the definitions which are not objects
+	Underlying string
+	Fields     []serverField
+}
+
+type serverParam struct {
+	Field    string
+	Name     string
+	In       string
+	Type     string
+	Comment  string
+	Required bool
+	Bind     string
+}
+
+type serverOperation struct {
+	Name    string
+	Summary string
+	Method  string
+	Path    string
+	Params  []serverParam
+	Status  int
+	Body    string
+}
+
+// Server writes the go source of a server scaffolded from the api to w: a struct per definition,
+// a Server interface with one method per operation taking and returning typed request and response structs,
+// and a Handler function binding an implementation of the interface to the routes of the api
+func Server(w io.Writer, api *swag.API, options ...ServerOption) error {
+	cfg := &serverConfig{Package: "api"}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	g := &serverGenerator{names: typeNames(api.Definitions), responses: api.Responses}
+	defs := make([]string, 0, len(api.Definitions))
+	for name := range api.Definitions {
+		defs = append(defs, name)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return g.names[defs[i]] < g.names[defs[j]]
+	})
+	typeDefs := make([]serverType, 0, len(defs))
+	for _, name := range defs {
+		typeDefs = append(typeDefs, g.definition(name, api.Definitions[name]))
+	}
+
+	operations := make([]serverOperation, 0)
+	walk(api, func(p string, e *swag.Endpoint) {
+		operations = append(operations, g.operation(path.Join("/", api.BasePath, p), e))
+	})
+
+	var buf bytes.Buffer
+	err := serverTemplate.Execute(&buf, map[string]interface{}{
+		"Config":     cfg,
+		"Types":      typeDefs,
+		"Operations": operations,
+		"Time":       g.usesTime,
+		"Multipart":  g.usesMultipart,
+	})
+	if err != nil {
+		return err
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(source)
+	return err
+}
+
+type serverGenerator struct {
+	names         map[string]string
+	responses     map[string]swag.Response
+	usesTime      bool
+	usesMultipart bool
+}
+
+// typeNames maps the definitions to go type names, e.g. github.com_zc2638_swag.Pet to Pet,
+// falling back to the whole definition name when the short names collide
+func typeNames(definitions map[string]swag.Object) map[string]string {
+	count := make(map[string]int)
+	for name := range definitions {
+		count[shortName(name)]++
+	}
+	names := make(map[string]string, len(definitions))
+	for name := range definitions {
+		if short := shortName(name); count[short] == 1 {
+			names[name] = short
+		} else {
+			names[name] = exportName(name)
+		}
+	}
+	return names
+}
+
+func shortName(name string) string {
+	return exportName(name[strings.LastIndex(name, ".")+1:])
+}
+
+// exportName converts the name into an exported go identifier, e.g. created_at to CreatedAt
+func exportName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	s := b.String()
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		s = "X" + s
+	}
+	return s
+}
+
+func (g *serverGenerator) refName(ref string) string {
+	name := strings.TrimPrefix(ref, "#/definitions/")
+	if v, ok := g.names[name]; ok {
+		return v
+	}
+	return exportName(name)
+}
+
+// goType returns the go type of the schema; references are pointers if pointer is true,
+// so that recursive definitions remain valid
+func (g *serverGenerator) goType(typ, format, ref string, items *swag.Items, pointer bool) string {
+	if ref != "" {
+		if pointer {
+			return "*" + g.refName(ref)
+		}
+		return g.refName(ref)
+	}
+	switch typ {
+	case "array":
+		if items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + g.goType(items.Type, items.Format, items.Ref, items.Items, false)
+	case "integer":
+		if format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "string":
+		switch format {
+		case "date-time":
+			g.usesTime = true
+			return "time.Time"
+		case "byte":
+			return "[]byte"
+		}
+		return "string"
+	case "object":
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// mapType returns the go map type of an object whose values are described by additionalProperties
+func (g *serverGenerator) mapType(values *swag.Property) string {
+	typ := g.goType(values.Type, values.Format, values.Ref, values.Items, false)
+	if values.AdditionalProperties != nil {
+		typ = g.mapType(values.AdditionalProperties)
+	}
+	return "map[string]" + typ
+}
+
+func (g *serverGenerator) definition(name string, obj swag.Object) serverType {
+	t := serverType{Name: g.names[name], Comment: obj.Description}
+	if obj.Type == "object" && len(obj.Properties) == 0 && obj.AdditionalProperties != nil {
+		t.Underlying = g.mapType(obj.AdditionalProperties)
+		return t
+	}
+	if obj.Type != "object" {
+		t.Underlying = g.goType(obj.Type, obj.Format, "", nil, false)
+		return t
+	}
+
+	required := make(map[string]bool, len(obj.Required))
+	for _, v := range obj.Required {
+		required[v] = true
+	}
+	props := make([]string, 0, len(obj.Properties))
+	for p := range obj.Properties {
+		props = append(props, p)
+	}
+	sort.Strings(props)
+	for _, p := range props {
+		prop := obj.Properties[p]
+		typ := g.goType(prop.Type, prop.Format, prop.Ref, prop.Items, true)
+		if prop.Type == "object" && len(prop.Properties) > 0 {
+			typ = "map[string]interface{}"
+		}
+		if prop.AdditionalProperties != nil {
+			typ = g.mapType(prop.AdditionalProperties)
+		}
+		tag := p
+		if !required[p] {
+			tag += ",omitempty"
+		}
+		t.Fields = append(t.Fields, serverField{
+			Name:    exportName(p),
+			Type:    typ,
+			Tag:     fmt.Sprintf("`json:%q`", tag),
+			Comment: prop.Description,
+		})
+	}
+	return t
+}
+
+func (g *serverGenerator) operation(p string, e *swag.Endpoint) serverOperation {
+	op := serverOperation{
+		Name:    exportName(e.OperationID),
+		Summary: e.Summary,
+		Method:  e.Method,
+		Path:    p,
+		Status:  200,
+	}
+	for _, param := range e.Parameters {
+		op.Params = append(op.Params, g.parameter(param))
+	}
+
+	codes := make([]string, 0, len(e.Responses))
+	for code := range e.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		status, err := strconv.Atoi(code)
+		if err != nil || status < 200 || status >= 300 {
+			continue
+		}
+		op.Status = status
+		response := e.Responses[code]
+		if response.Ref != "" {
+			if named, ok := g.responses[strings.TrimPrefix(response.Ref, "#/responses/")]; ok {
+				response = named
+			}
+		}
+		if s := response.Schema; s != nil {
+			op.Body = g.goType(s.Type, s.Format, s.Ref, s.Items, false)
+		}
+		break
+	}
+	return op
+}
+
+func (g *serverGenerator) parameter(p swag.Parameter) serverParam {
+	param := serverParam{
+		Field:    exportName(p.Name),
+		Name:     p.Name,
+		In:       p.In,
+		Comment:  p.Description,
+		Required: p.Required,
+	}
+	if p.In == "body" {
+		if p.Schema != nil {
+			param.Type = g.goType(p.Schema.Type, p.Schema.Format, p.Schema.Ref, p.Schema.Items, false)
+		} else {
+			param.Type = "interface{}"
+		}
+		return param
+	}
+
+	field := "req." + param.Field
+	switch p.Type {
+	case types.Integer:
+		param.Type = "int64"
+		param.Bind = fmt.Sprintf("n, err := strconv.ParseInt(vs[0], 10, 64)\n"+
+			"if err != nil {\nbadRequest(w, %q, err)\nreturn\n}\n%s = n", p.Name, field)
+	case types.Number:
+		param.Type = "float64"
+		param.Bind = fmt.Sprintf("n, err := strconv.ParseFloat(vs[0], 64)\n"+
+			"if err != nil {\nbadRequest(w, %q, err)\nreturn\n}\n%s = n", p.Name, field)
+	case types.Boolean:
+		param.Type = "bool"
+		param.Bind = fmt.Sprintf("b, err := strconv.ParseBool(vs[0])\n"+
+			"if err != nil {\nbadRequest(w, %q, err)\nreturn\n}\n%s = b", p.Name, field)
+	case types.Array:
+		values := fmt.Sprintf("splitArray(vs[0], %q)", p.CollectionFormat)
+		if p.CollectionFormat == "multi" {
+			values = "vs"
+		}
+		elem, parse := "string", ""
+		if p.Items != nil {
+			elem, parse = g.parseItem(p.Name, p.Items.Type, p.Items.Format)
+		}
+		param.Type = "[]" + elem
+		if parse == "" {
+			param.Bind = fmt.Sprintf("%s = %s", field, values)
+		} else {
+			param.Bind = fmt.Sprintf("for _, v := range %s {\n%s\n%s = append(%s, item)\n}", values, parse, field, field)
+		}
+	case types.File:
+		g.usesMultipart = true
+		param.Type = "*multipart.FileHeader"
+	default:
+		param.Type = "string"
+		param.Bind = field + " = vs[0]"
+	}
+	return param
+}
+
+// parseItem returns the go type of the items of an array parameter, derived from their type and format,
+// along with the code parsing the string v into item; the code is empty for the string items
+func (g *serverGenerator) parseItem(name, typ, format string) (string, string) {
+	fail := fmt.Sprintf("if err != nil {\nbadRequest(w, %q, err)\nreturn\n}", name)
+	switch elem := g.goType(typ, format, "", nil, false); elem {
+	case "int64":
+		return elem, "item, err := strconv.ParseInt(v, 10, 64)\n" + fail
+	case "int32":
+		return elem, "n, err := strconv.ParseInt(v, 10, 32)\n" + fail + "\nitem := int32(n)"
+	case "float64":
+		return elem, "item, err := strconv.ParseFloat(v, 64)\n" + fail
+	case "float32":
+		return elem, "n, err := strconv.ParseFloat(v, 32)\n" + fail + "\nitem := float32(n)"
+	case "bool":
+		return elem, "item, err := strconv.ParseBool(v)\n" + fail
+	case "time.Time":
+		return elem, "item, err := time.Parse(time.RFC3339, v)\n" + fail
+	}
+	return "string", ""
+}
+
+var serverTemplate = template.Must(template.New("server").Parse(`// Code generated by swag. DO NOT EDIT.
+
+package {{.Config.Package}}
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	{{- if .Multipart}}
+	"mime/multipart"
+	{{- end}}
+	"net/http"
+	"strconv"
+	"strings"
+	{{- if .Time}}
+	"time"
+	{{- end}}
+)
+{{range .Types}}
+{{- if .Comment}}
+// {{.Name}} {{.Comment}}
+{{- end}}
+{{- if .Underlying}}
+type {{.Name}} {{.Underlying}}
+{{else}}
+type {{.Name}} struct {
+{{- range .Fields}}
+{{- if .Comment}}
+	// {{.Name}} {{.Comment}}
+{{- end}}
+	{{.Name}} {{.Type}} {{.Tag}}
+{{- end}}
+}
+{{end}}
+{{- end}}
+{{- range .Operations}}
+// {{.Name}}Request is the request of {{.Method}} {{.Path}}
+type {{.Name}}Request struct {
+{{- range .Params}}
+{{- if .Comment}}
+	// {{.Field}} {{.Comment}}
+{{- end}}
+	{{.Field}} {{.Type}}
+{{- end}}
+}
+
+// {{.Name}}Response is the response of {{.Method}} {{.Path}}
+type {{.Name}}Response struct {
+	// Status is the status code of the response; defaults to {{.Status}}
+	Status int
+{{- if .Body}}
+	Body {{.Body}}
+{{- end}}
+}
+{{end}}
+// Server is implemented by the service serving the api
+type Server interface {
+{{- range .Operations}}
+	// {{.Name}} {{if .Summary}}{{.Summary}}{{else}}serves {{.Method}} {{.Path}}{{end}}
+	{{.Name}}(ctx context.Context, req *{{.Name}}Request) (*{{.Name}}Response, error)
+{{- end}}
+}
+
+type route struct {
+	method string
+	path   string
+	handle func(w http.ResponseWriter, r *http.Request, params map[string]string)
+}
+
+// Handler returns the http handler routing the requests of the api to the server
+func Handler(srv Server) http.Handler {
+	routes := []route{
+{{- range .Operations}}
+		{method: {{printf "%q" .Method}}, path: {{printf "%q" .Path}}, handle: func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+			req := &{{.Name}}Request{}
+{{- range .Params}}
+{{- if eq .In "body"}}
+			if err := json.NewDecoder(r.Body).Decode(&req.{{.Field}}); err != nil{{if not .Required}} && !errors.Is(err, io.EOF){{end}} {
+				badRequest(w, {{printf "%q" .Name}}, err)
+				return
+			}
+{{- else if eq .Type "*multipart.FileHeader"}}
+			if _, header, err := r.FormFile({{printf "%q" .Name}}); err == nil {
+				req.{{.Field}} = header
+			}{{if .Required}} else {
+				badRequest(w, {{printf "%q" .Name}}, err)
+				return
+			}{{end}}
+{{- else}}
+			if vs := lookup(r, params, {{printf "%q" .In}}, {{printf "%q" .Name}}); len(vs) > 0 {
+				{{.Bind}}
+			}{{if .Required}} else {
+				badRequest(w, {{printf "%q" .Name}}, errMissing)
+				return
+			}{{end}}
+{{- end}}
+{{- end}}
+			resp, err := srv.{{.Name}}(r.Context(), req)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if resp == nil {
+				resp = &{{.Name}}Response{}
+			}
+			status := resp.Status
+			if status == 0 {
+				status = {{.Status}}
+			}
+{{- if .Body}}
+			writeJSON(w, status, resp.Body)
+{{- else}}
+			w.WriteHeader(status)
+{{- end}}
+		}},
+{{- end}}
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed := false
+		for _, rt := range routes {
+			params, ok := matchPath(rt.path, r.URL.Path)
+			if !ok {
+				continue
+			}
+			if rt.method != r.Method {
+				allowed = true
+				continue
+			}
+			rt.handle(w, r, params)
+			return
+		}
+		if allowed {
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		http.NotFound(w, r)
+	})
+}
+
+var errMissing = errors.New("missing required parameter")
+
+func matchPath(pattern, p string) (map[string]string, bool) {
+	patterns := strings.Split(strings.Trim(pattern, "/"), "/")
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	if len(patterns) != len(segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, s := range patterns {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			params[s[1:len(s)-1]] = segments[i]
+			continue
+		}
+		if s != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func lookup(r *http.Request, params map[string]string, in, name string) []string {
+	switch in {
+	case "path":
+		if v, ok := params[name]; ok {
+			return []string{v}
+		}
+	case "query":
+		return r.URL.Query()[name]
+	case "header":
+		return r.Header.Values(name)
+	case "formData":
+		if r.Form == nil {
+			_ = r.ParseMultipartForm(32 << 20)
+		}
+		return r.Form[name]
+	}
+	return nil
+}
+
+func splitArray(v, collectionFormat string) []string {
+	sep := ","
+	switch collectionFormat {
+	case "ssv":
+		sep = " "
+	case "tsv":
+		sep = "\t"
+	case "pipes":
+		sep = "|"
+	}
+	return strings.Split(v, sep)
+}
+
+func badRequest(w http.ResponseWriter, name string, err error) {
+	http.Error(w, "invalid parameter "+name+": "+err.Error(), http.StatusBadRequest)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// reference the imports only used by some operations
+var (
+	_ = strconv.Itoa
+	_ = io.EOF
+)
+`))
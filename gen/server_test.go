@@ -0,0 +1,95 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/endpoint"
+	"github.com/zc2638/swag/types"
+)
+
+func TestServer(t *testing.T) {
+	var buf bytes.Buffer
+	err := Server(&buf, newAPI(), ServerPackage("petstore"))
+	assert.Nil(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "server.go", buf.Bytes(), 0)
+	assert.Nil(t, err)
+
+	source := buf.String()
+	assert.Contains(t, source, "package petstore")
+	assert.Contains(t, source, "type Pet struct {")
+	assert.Contains(t, source, "Id   int64  `json:\"id,omitempty\"`")
+	assert.Contains(t, source, "GetPetsId(ctx context.Context, req *GetPetsIdRequest) (*GetPetsIdResponse, error)")
+	assert.Contains(t, source, "PostPets(ctx context.Context, req *PostPetsRequest) (*PostPetsResponse, error)")
+	assert.Contains(t, source, "Verbose bool")
+	assert.Contains(t, source, "Body Pet")
+	assert.Contains(t, source, `{method: "GET", path: "/pets/{id}"`)
+}
+
+func TestExportName(t *testing.T) {
+	assert.Equal(t, "CreatedAt", exportName("created_at"))
+	assert.Equal(t, "GetPetsId", exportName("getPetsId"))
+	assert.Equal(t, "X2fa", exportName("2fa"))
+}
+
+func TestServerParameterRefs(t *testing.T) {
+	api := newAPI()
+	api.Parameters = map[string]swag.Parameter{"limit": {In: "query", Name: "limit", Type: types.Integer}}
+	api.AddEndpoint(endpoint.New(http.MethodGet, "/pets", endpoint.ParamRef("limit")))
+
+	var buf bytes.Buffer
+	assert.Nil(t, Server(&buf, api))
+	assert.Contains(t, buf.String(), "Limit int64")
+}
+
+func TestServerTypedArraysAndMaps(t *testing.T) {
+	api := newAPI()
+	api.AddEndpoint(endpoint.New(http.MethodGet, "/pets",
+		endpoint.QueryArray("ids", types.Integer, "csv", "the pets", false),
+		endpoint.QueryArray("weights", types.Number, "multi", "the weights", false),
+		endpoint.QueryArray("names", types.String, "csv", "the names", false),
+	))
+	api.Definitions["Labels"] = swag.Object{Type: "object", AdditionalProperties: &swag.Property{Type: "string"}}
+	api.Definitions["Stock"] = swag.Object{Type: "object", Properties: map[string]swag.Property{
+		"counts": {Type: "object", AdditionalProperties: &swag.Property{Type: "integer", Format: "int32"}},
+		"matrix": {Type: "array", Items: &swag.Items{Type: "array", Items: &swag.Items{Type: "number"}}},
+	}}
+
+	var buf bytes.Buffer
+	assert.Nil(t, Server(&buf, api))
+	_, err := parser.ParseFile(token.NewFileSet(), "server.go", buf.Bytes(), 0)
+	assert.Nil(t, err)
+
+	source := buf.String()
+	assert.Contains(t, source, "Ids []int64")
+	assert.Contains(t, source, `for _, v := range splitArray(vs[0], "csv") {`)
+	assert.Contains(t, source, "item, err := strconv.ParseInt(v, 10, 64)")
+	assert.Contains(t, source, "req.Ids = append(req.Ids, item)")
+	assert.Contains(t, source, "Weights []float64")
+	assert.Contains(t, source, "for _, v := range vs {")
+	assert.Contains(t, source, "Names []string")
+	assert.Contains(t, source, "type Labels map[string]string")
+	assert.Contains(t, source, "map[string]int32")
+	assert.Contains(t, source, "[][]float64")
+}
@@ -0,0 +1,151 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/zc2638/swag"
+)
+
+// SQLOption provides configuration options to the sql generator
+type SQLOption func(c *sqlConfig)
+
+type sqlConfig struct {
+	Schema string
+}
+
+// SQLSchema qualifies the generated tables with the schema, e.g. staging
+func SQLSchema(name string) SQLOption {
+	return func(c *sqlConfig) {
+		c.Schema = name
+	}
+}
+
+// SQL writes a CREATE TABLE sketch of every flat definition of the api to w,
+// a column per property typed after it and nullable unless the property is required.
+// It is experimental: the definitions with nested objects or arrays are skipped and reported
+func SQL(w io.Writer, api *swag.API, options ...SQLOption) ([]LossyConversion, error) {
+	cfg := &sqlConfig{}
+	for _, opt := range options {
+		opt(cfg)
+	}
+
+	names := typeNames(api.Definitions)
+	defs := make([]string, 0, len(api.Definitions))
+	for name := range api.Definitions {
+		defs = append(defs, name)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return names[defs[i]] < names[defs[j]]
+	})
+
+	var (
+		b      strings.Builder
+		losses []LossyConversion
+	)
+	b.WriteString("-- Code generated by swag. DO NOT EDIT.\n")
+	b.WriteString("-- Experimental sketch of the definitions of the api, to be reviewed before use.\n")
+	for _, name := range defs {
+		obj := api.Definitions[name]
+		table := names[name]
+		if obj.Type != "object" || len(obj.Properties) == 0 {
+			losses = append(losses, LossyConversion{Definition: table, Reason: "not an object with properties"})
+			continue
+		}
+
+		required := make(map[string]bool, len(obj.Required))
+		for _, v := range obj.Required {
+			required[v] = true
+		}
+		props := make([]string, 0, len(obj.Properties))
+		for p := range obj.Properties {
+			props = append(props, p)
+		}
+		sort.Strings(props)
+
+		columns := make([]string, 0, len(props))
+		var nested []string
+		for _, p := range props {
+			prop := obj.Properties[p]
+			typ := sqlType(prop)
+			if typ == "" {
+				nested = append(nested, p)
+				continue
+			}
+			column := "    " + sqlIdentifier(p) + " " + typ
+			if required[p] {
+				column += " NOT NULL"
+			}
+			columns = append(columns, column)
+		}
+		if len(nested) > 0 {
+			losses = append(losses, LossyConversion{
+				Definition: table,
+				Reason:     "not flat, nested properties: " + strings.Join(nested, ", "),
+			})
+			continue
+		}
+
+		qualified := sqlIdentifier(table)
+		if cfg.Schema != "" {
+			qualified = sqlIdentifier(cfg.Schema) + "." + qualified
+		}
+		b.WriteString("\nCREATE TABLE " + qualified + " (\n")
+		b.WriteString(strings.Join(columns, ",\n"))
+		b.WriteString("\n);\n")
+	}
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return nil, err
+	}
+	return losses, nil
+}
+
+// sqlType returns the column type of the property, or an empty string if it is not a scalar
+func sqlType(prop swag.Property) string {
+	if prop.Ref != "" {
+		return ""
+	}
+	switch prop.Type {
+	case "integer":
+		if prop.Format == "int32" {
+			return "INTEGER"
+		}
+		return "BIGINT"
+	case "number":
+		if prop.Format == "float" {
+			return "REAL"
+		}
+		return "DOUBLE PRECISION"
+	case "boolean":
+		return "BOOLEAN"
+	case "string":
+		switch prop.Format {
+		case "date-time":
+			return "TIMESTAMP"
+		case "date":
+			return "DATE"
+		}
+		return "TEXT"
+	}
+	return ""
+}
+
+// sqlIdentifier returns the name in snake case as a quoted identifier, since names like order are reserved
+func sqlIdentifier(name string) string {
+	return `"` + strings.ToLower(constantName(name)) + `"`
+}
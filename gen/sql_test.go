@@ -0,0 +1,59 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag/endpoint"
+)
+
+type Shipment struct {
+	TrackingID string    `json:"trackingId" required:"true"`
+	Weight     float32   `json:"weight"`
+	ShippedAt  time.Time `json:"shipped_at"`
+}
+
+func TestSQL(t *testing.T) {
+	api := newOrderAPI()
+	api.AddEndpoint(endpoint.New(http.MethodGet, "/shipments",
+		endpoint.Response(http.StatusOK, "success", endpoint.Schema(Shipment{})),
+	))
+
+	var buf bytes.Buffer
+	losses, err := SQL(&buf, api, SQLSchema("staging"))
+	assert.Nil(t, err)
+
+	source := buf.String()
+	assert.Contains(t, source, "CREATE TABLE \"staging\".\"pet\" (\n    \"id\" BIGINT,\n    \"name\" TEXT\n);\n")
+	assert.Contains(t, source, "CREATE TABLE \"staging\".\"shipment\" (\n")
+	assert.Contains(t, source, "    \"shipped_at\" TIMESTAMP,\n")
+	assert.Contains(t, source, "    \"tracking_id\" TEXT NOT NULL,\n")
+	assert.Contains(t, source, "    \"weight\" REAL\n")
+	assert.NotContains(t, source, `"order"`)
+	assert.Equal(t, []LossyConversion{
+		{Definition: "Order", Reason: "not flat, nested properties: metadata, pets"},
+	}, losses)
+}
+
+func TestSQLIdentifier(t *testing.T) {
+	assert.Equal(t, `"created_at"`, sqlIdentifier("createdAt"))
+	assert.Equal(t, `"order"`, sqlIdentifier("Order"))
+}
@@ -0,0 +1,164 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"io"
+	"text/template"
+	"unicode"
+
+	"github.com/zc2638/swag"
+)
+
+var swiftKeywords = map[string]bool{
+	"as": true, "break": true, "case": true, "class": true, "continue": true, "default": true,
+	"do": true, "else": true, "enum": true, "extension": true, "false": true, "for": true,
+	"func": true, "if": true, "import": true, "in": true, "init": true, "internal": true,
+	"is": true, "let": true, "nil": true, "private": true, "protocol": true, "public": true,
+	"repeat": true, "return": true, "self": true, "static": true, "struct": true, "super": true,
+	"switch": true, "throw": true, "true": true, "try": true, "var": true, "where": true, "while": true,
+}
+
+func swiftName(name string) string {
+	if swiftKeywords[name] {
+		return "`" + name + "`"
+	}
+	return name
+}
+
+// Swift writes the definitions of the api to w as swift Codable structs;
+// the string properties with enum values are typed by String enums.
+// The date-time properties are typed by Date, so the decoder needs the iso8601 date decoding strategy
+func Swift(w io.Writer, api *swag.API) error {
+	mapper := &typeMapper{
+		scalars: map[string]string{
+			"integer":          "Int64",
+			"integer:int32":    "Int32",
+			"number":           "Double",
+			"number:float":     "Float",
+			"boolean":          "Bool",
+			"string":           "String",
+			"string:date-time": "Date",
+			"string:byte":      "Data",
+			"object":           "[String: JSONValue]",
+			"":                 "JSONValue",
+		},
+		list: func(elem string) string {
+			return "[" + elem + "]"
+		},
+		field: func(name string) string {
+			return swiftName(camelName(name))
+		},
+		enumCase: func(value string) string {
+			name := camelName(value)
+			for _, r := range value {
+				if unicode.IsDigit(r) {
+					// exportName prefixed the name with X
+					name = "v" + name[1:]
+				}
+				if unicode.IsLetter(r) || unicode.IsDigit(r) {
+					break
+				}
+			}
+			return swiftName(name)
+		},
+	}
+	models, enums := buildModels(api, mapper)
+	return swiftTemplate.Execute(w, map[string]interface{}{
+		"Models": models,
+		"Enums":  enums,
+		"Json":   mapper.used["object"] || mapper.used[""],
+	})
+}
+
+var swiftTemplate = template.Must(template.New("swift").Funcs(template.FuncMap{
+	"quote": func(s string) string {
+		return quote(s, "")
+	},
+}).Parse(`// Code generated by swag. DO NOT EDIT.
+
+import Foundation
+{{range .Models}}
+{{if .Description}}/// {{.Description}}
+{{end -}}
+{{if .Alias -}}
+public typealias {{.Name}} = {{.Alias}}
+{{else -}}
+public struct {{.Name}}: Codable {
+{{- range .Fields}}
+{{- if .Description}}
+    /// {{.Description}}
+{{- end}}
+    public var {{.Name}}: {{.Type}}{{if not .Required}}?{{end}}
+{{- end}}
+{{- if .Fields}}
+
+    enum CodingKeys: String, CodingKey {
+{{- range .Fields}}
+        case {{.Name}} = {{quote .JSON}}
+{{- end}}
+    }
+{{- end}}
+}
+{{end -}}
+{{end -}}
+{{range .Enums}}
+public enum {{.Name}}: String, Codable {
+{{- range .Cases}}
+    case {{.Name}} = {{quote .Value}}
+{{- end}}
+}
+{{end -}}
+{{if .Json}}
+/// JSONValue is any json value
+public enum JSONValue: Codable {
+    case string(String)
+    case number(Double)
+    case bool(Bool)
+    case object([String: JSONValue])
+    case array([JSONValue])
+    case null
+
+    public init(from decoder: Decoder) throws {
+        let container = try decoder.singleValueContainer()
+        if container.decodeNil() {
+            self = .null
+        } else if let v = try? container.decode(Bool.self) {
+            self = .bool(v)
+        } else if let v = try? container.decode(Double.self) {
+            self = .number(v)
+        } else if let v = try? container.decode(String.self) {
+            self = .string(v)
+        } else if let v = try? container.decode([JSONValue].self) {
+            self = .array(v)
+        } else {
+            self = .object(try container.decode([String: JSONValue].self))
+        }
+    }
+
+    public func encode(to encoder: Encoder) throws {
+        var container = encoder.singleValueContainer()
+        switch self {
+        case .string(let v): try container.encode(v)
+        case .number(let v): try container.encode(v)
+        case .bool(let v): try container.encode(v)
+        case .object(let v): try container.encode(v)
+        case .array(let v): try container.encode(v)
+        case .null: try container.encodeNil()
+        }
+    }
+}
+{{end -}}
+`))
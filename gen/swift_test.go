@@ -0,0 +1,47 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwift(t *testing.T) {
+	var buf bytes.Buffer
+	err := Swift(&buf, newOrderAPI())
+	assert.Nil(t, err)
+
+	source := buf.String()
+	assert.Contains(t, source, "public struct Order: Codable {")
+	assert.Contains(t, source, "public var id: Int32\n")
+	assert.Contains(t, source, "/// order status")
+	assert.Contains(t, source, "public var status: OrderStatus?")
+	assert.Contains(t, source, "public var pets: [Pet]?")
+	assert.Contains(t, source, "public var metadata: JSONValue?")
+	assert.Contains(t, source, "public var `in`: Bool?")
+	assert.Contains(t, source, "case `in` = \"in\"")
+	assert.Contains(t, source, "public enum OrderStatus: String, Codable {")
+	assert.Contains(t, source, `case inTransit = "in-transit"`)
+	assert.Contains(t, source, "public enum JSONValue: Codable {")
+}
+
+func TestCamelName(t *testing.T) {
+	assert.Equal(t, "createdAt", camelName("created_at"))
+	assert.Equal(t, "id", camelName("ID"))
+	assert.Equal(t, "httpCode", camelName("HTTPCode"))
+}
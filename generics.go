@@ -0,0 +1,66 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package swag
+
+import (
+	"reflect"
+)
+
+// Data wraps a response body of type T in the data field; its definition is named after T, e.g. UserResponse
+type Data[T any] struct {
+	Data T `json:"data"`
+}
+
+// SwaggerName names the definition after T
+func (Data[T]) SwaggerName() string {
+	return elemName[T]() + "Response"
+}
+
+// List wraps the items of type T along with their total count; its definition is named after T, e.g. UserList
+type List[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+// SwaggerName names the definition after T
+func (List[T]) SwaggerName() string {
+	return elemName[T]() + "List"
+}
+
+// DataOf returns the prototype of the data wrapper of T, e.g.
+//
+//	endpoint.Response(http.StatusOK, "the user", endpoint.SchemaResponseOption(swag.DataOf[User]()))
+func DataOf[T any]() Data[T] {
+	return Data[T]{}
+}
+
+// ListOf returns the prototype of the list wrapper of T, e.g.
+//
+//	endpoint.Response(http.StatusOK, "the users", endpoint.SchemaResponseOption(swag.ListOf[User]()))
+func ListOf[T any]() List[T] {
+	return List[T]{}
+}
+
+// elemName returns the definition name of T, dereferencing the pointers
+func elemName[T any]() string {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return makeName(t)
+}
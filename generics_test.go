@@ -0,0 +1,47 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.18
+// +build go1.18
+
+package swag
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataOf(t *testing.T) {
+	assert.Equal(t, "github.com_zc2638_swag.PersonResponse", DefinitionName(DataOf[Person]()))
+	assert.Equal(t, "github.com_zc2638_swag.PersonList", DefinitionName(ListOf[*Person]()))
+
+	api := New()
+	api.AddEndpoint(&Endpoint{Method: http.MethodGet, Path: "/people", Responses: map[string]Response{
+		"200": {Description: "the people", Schema: MakeSchema(ListOf[Person]())},
+		"201": {Description: "the person", Schema: MakeSchema(DataOf[Person]())},
+	}})
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag.PersonList", api.Paths["/people"].Get.Responses["200"].Schema.Ref)
+
+	list, ok := api.Definitions["github.com_zc2638_swag.PersonList"]
+	assert.True(t, ok)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag.Person", list.Properties["items"].Items.Ref)
+	assert.Equal(t, "integer", list.Properties["total"].Type)
+
+	data, ok := api.Definitions["github.com_zc2638_swag.PersonResponse"]
+	assert.True(t, ok)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag.Person", data.Properties["data"].Ref)
+	assert.Contains(t, api.Definitions, "github.com_zc2638_swag.Person")
+}
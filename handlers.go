@@ -0,0 +1,107 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Handlers represents the handlers of the documentation suite of the api;
+// the UI and Redoc pages load the spec from the swagger.json next to their mount point
+type Handlers struct {
+	// JSON serves the spec as json
+	JSON http.Handler
+	// YAML serves the spec as yaml
+	YAML http.Handler
+	// UI serves the swagger ui files by their path without the mount prefix
+	UI http.Handler
+	// Redoc serves the redoc page
+	Redoc http.Handler
+	// Health reports that the documentation is served
+	Health http.Handler
+	// Operation serves the documentation of the operation whose operationId ends the request path
+	Operation http.Handler
+	// Pages serves a swagger ui page by tag, with an index, by their path without the mount prefix
+	Pages http.Handler
+	// LazyUI serves the swagger ui files by their path without the mount prefix,
+	// fetching the spec of one tag of the pages at a time
+	LazyUI http.Handler
+	// Docs serves the page of the renderer of the api, see option.Renderer
+	Docs http.Handler
+	// Build serves the build info of the api, see option.Build
+	Build http.Handler
+}
+
+// Handlers returns the handlers of the documentation suite of the api;
+// mount them under several prefixes with different filters by cloning the api, e.g.
+//
+//	api.Handlers().Mount(mux, "/internal/docs")
+//	public := api.Clone()
+//	public.Filters = []swag.Filter{...}
+//	public.Handlers().Mount(mux, "/docs")
+func (a *API) Handlers() *Handlers {
+	return &Handlers{
+		JSON: a.Handler(),
+		YAML: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var buf bytes.Buffer
+			if err := a.requestDoc(req).EncodeYAMLContext(req.Context(), &buf); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/yaml")
+			body, done := compressWriter(w, req)
+			defer done()
+			w.WriteHeader(http.StatusOK)
+			_, _ = buf.WriteTo(body)
+		}),
+		UI:    uiFiles("../swagger.json", uiConfig{}),
+		Redoc: RedocHandler("swagger.json"),
+		Health: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"status":"ok"}`))
+		}),
+		Operation: a.OperationHandler(),
+		Pages:     a.PagesHandler(),
+		LazyUI:    a.LazyUIHandler("../pages/"),
+		Docs:      RenderHandler("swagger.json", a.Renderer),
+		Build:     a.BuildHandler(),
+	}
+}
+
+// Mount registers the handlers on the mux under the prefix:
+// swagger.json, swagger.yaml, ui/, redoc, docs, health, build, operations/{operationId}, pages/ and lazy-ui/
+func (h *Handlers) Mount(mux *http.ServeMux, prefix string) {
+	prefix = "/" + strings.Trim(prefix, "/")
+	ui := path.Join(prefix, "ui")
+
+	mux.Handle(path.Join(prefix, "swagger.json"), h.JSON)
+	mux.Handle(path.Join(prefix, "swagger.yaml"), h.YAML)
+	mux.Handle(path.Join(prefix, "redoc"), h.Redoc)
+	mux.Handle(path.Join(prefix, "docs"), h.Docs)
+	mux.Handle(path.Join(prefix, "health"), h.Health)
+	mux.Handle(path.Join(prefix, "build"), h.Build)
+	mux.Handle(path.Join(prefix, "operations")+"/", h.Operation)
+	mux.Handle(ui+"/", http.StripPrefix(ui, h.UI))
+	mux.Handle(ui, http.RedirectHandler(ui+"/", http.StatusFound))
+	pages := path.Join(prefix, "pages")
+	mux.Handle(pages+"/", http.StripPrefix(pages, h.Pages))
+	mux.Handle(pages, http.RedirectHandler(pages+"/", http.StatusFound))
+	lazy := path.Join(prefix, "lazy-ui")
+	mux.Handle(lazy+"/", http.StripPrefix(lazy, h.LazyUI))
+	mux.Handle(lazy, http.RedirectHandler(lazy+"/", http.StatusFound))
+}
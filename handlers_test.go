@@ -0,0 +1,87 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandlers_Mount(t *testing.T) {
+	api := New()
+	api.AddEndpoint(
+		&Endpoint{Path: "/users", Method: http.MethodGet},
+		&Endpoint{Path: "/admin/users", Method: http.MethodDelete},
+	)
+	public := api.Clone()
+	public.Filters = []Filter{func(p string, _ *Endpoint) bool {
+		return !strings.HasPrefix(p, "/admin")
+	}}
+
+	mux := http.NewServeMux()
+	api.Handlers().Mount(mux, "/internal/docs")
+	public.Handlers().Mount(mux, "/docs/")
+
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	w := get("/internal/docs/swagger.json")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "/admin/users")
+
+	w = get("/docs/swagger.json")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "/admin/users")
+
+	w = get("/docs/swagger.yaml")
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), "swagger: \"2.0\"\n")
+	assert.Contains(t, w.Body.String(), "/users:")
+
+	w = get("/docs/ui")
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/docs/ui/", w.Header().Get("Location"))
+
+	w = get("/docs/ui/")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `url: "../swagger.json"`)
+
+	w = get("/docs/ui/swagger-ui.css")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = get("/internal/docs/redoc")
+	assert.Contains(t, w.Body.String(), `Redoc.init("swagger.json", {}`)
+
+	w = get("/docs/health")
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
+
+func TestAPI_EncodeYAML(t *testing.T) {
+	api := New()
+	api.Info.Version = "1.0"
+	api.Info.Description = "first\nsecond"
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.EncodeYAML(&buf))
+	assert.True(t, strings.HasPrefix(buf.String(), "swagger: \"2.0\"\ninfo:\n  description: |-\n    first\n    second\n  version: \"1.0\"\n"))
+}
@@ -0,0 +1,48 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"reflect"
+	"sync"
+)
+
+// PropertyHook adjusts the property documenting the field of a struct
+type PropertyHook func(field reflect.StructField, p *Property)
+
+var propertyHooks = struct {
+	sync.RWMutex
+	hooks []PropertyHook
+}{}
+
+// AddPropertyHook registers the hook invoked for every reflected struct field, after its tags were applied,
+// so that the conventions of the codebase are documented without tagging each field,
+// e.g. any field named ID has the uuid format; the hooks run in the order they were added
+func AddPropertyHook(hook PropertyHook) {
+	propertyHooks.Lock()
+	defer propertyHooks.Unlock()
+
+	propertyHooks.hooks = append(propertyHooks.hooks, hook)
+}
+
+// applyPropertyHooks runs the registered hooks on the property of the field
+func applyPropertyHooks(field reflect.StructField, p *Property) {
+	propertyHooks.RLock()
+	defer propertyHooks.RUnlock()
+
+	for _, hook := range propertyHooks.hooks {
+		hook(field, p)
+	}
+}
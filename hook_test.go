@@ -0,0 +1,53 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Hooked struct {
+	ID        string `json:"id"`
+	OwnerID   string `json:"owner_id" format:"int64"`
+	UpdatedAt string `json:"updated_at"`
+	Name      string `json:"name"`
+}
+
+func TestAddPropertyHook(t *testing.T) {
+	defer func(hooks []PropertyHook) {
+		propertyHooks.hooks = hooks
+	}(propertyHooks.hooks)
+
+	AddPropertyHook(func(field reflect.StructField, p *Property) {
+		if strings.HasSuffix(field.Name, "ID") && p.Format == "" {
+			p.Format = "uuid"
+		}
+	})
+	AddPropertyHook(func(field reflect.StructField, p *Property) {
+		if strings.HasSuffix(field.Tag.Get("json"), "_at") {
+			p.Format = "date-time"
+		}
+	})
+
+	obj := define(Hooked{})[makeName(reflect.TypeOf(Hooked{}))]
+	assert.Equal(t, "uuid", obj.Properties["id"].Format)
+	assert.Equal(t, "int64", obj.Properties["owner_id"].Format)
+	assert.Equal(t, "date-time", obj.Properties["updated_at"].Format)
+	assert.Equal(t, "", obj.Properties["name"].Format)
+}
@@ -0,0 +1,90 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import "strings"
+
+// DefinitionMode represents how the references to an internal definition are rendered
+type DefinitionMode int
+
+const (
+	// InlineDefinition replaces the references with the schema of the definition
+	InlineDefinition DefinitionMode = iota
+	// OpaqueDefinition replaces the references with a bare object schema
+	OpaqueDefinition
+)
+
+const definitionPrefix = "#/definitions/"
+
+// hideDefinitions removes the internal definitions from the decoded document,
+// and replaces the references to them according to their mode
+func (a *API) hideDefinitions(doc interface{}) interface{} {
+	m, ok := doc.(map[string]interface{})
+	if !ok {
+		return doc
+	}
+	definitions, _ := m["definitions"].(map[string]interface{})
+
+	internal := make(map[string]interface{})
+	for name := range a.InternalDefinitions {
+		if def, ok := definitions[name]; ok {
+			internal[name] = def
+			delete(definitions, name)
+		}
+	}
+	if definitions != nil && len(definitions) == 0 {
+		delete(m, "definitions")
+	}
+
+	h := &definitionHider{
+		modes:    a.InternalDefinitions,
+		internal: internal,
+		visiting: make(map[string]bool),
+	}
+	return h.replace(doc)
+}
+
+type definitionHider struct {
+	modes    map[string]DefinitionMode
+	internal map[string]interface{}
+	visiting map[string]bool
+}
+
+func (h *definitionHider) replace(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok && strings.HasPrefix(ref, definitionPrefix) {
+			name := strings.TrimPrefix(ref, definitionPrefix)
+			if def, ok := h.internal[name]; ok {
+				// recursive definitions can not be inlined
+				if h.modes[name] == OpaqueDefinition || h.visiting[name] {
+					return map[string]interface{}{"type": "object"}
+				}
+				h.visiting[name] = true
+				inlined := h.replace(copyJSON(def))
+				delete(h.visiting, name)
+				return inlined
+			}
+		}
+		for k, item := range value {
+			value[k] = h.replace(item)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = h.replace(item)
+		}
+	}
+	return v
+}
@@ -0,0 +1,71 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Meta struct {
+	Trace string `json:"trace"`
+	Next  *Meta  `json:"next"`
+}
+
+type Credentials struct {
+	Key string `json:"key"`
+}
+
+type Shipment struct {
+	ID      string        `json:"id"`
+	Meta    Meta          `json:"meta"`
+	Secrets []Credentials `json:"secrets"`
+}
+
+func TestInternalDefinitions(t *testing.T) {
+	api := New()
+	api.AddEndpoint(&Endpoint{
+		Path:   "/shipments",
+		Method: http.MethodPost,
+		Parameters: []Parameter{
+			{In: "body", Name: "body", Schema: MakeSchema(Shipment{})},
+		},
+		Responses: map[string]Response{
+			"200": {Description: "ok", Schema: MakeSchema(Meta{})},
+		},
+	})
+	api.InternalDefinitions = map[string]DefinitionMode{
+		DefinitionName(&Meta{}):         InlineDefinition,
+		DefinitionName([]Credentials{}): OpaqueDefinition,
+	}
+
+	doc := decodeDoc(t, api)
+	assert.Len(t, doc.Definitions, 1)
+	shipment := doc.Definitions["github.com_zc2638_swag.Shipment"]
+	assert.Equal(t, Property{Type: "object", Properties: map[string]Property{
+		"trace": {Type: "string"},
+		"next":  {Type: "object"},
+	}}, shipment.Properties["meta"])
+	assert.Equal(t, &Items{Type: "object"}, shipment.Properties["secrets"].Items)
+
+	response := doc.Paths["/shipments"].Post.Responses["200"].Schema
+	assert.Equal(t, "object", response.Type)
+	assert.Empty(t, response.Ref)
+
+	// the api itself is left untouched
+	assert.Len(t, api.Definitions, 3)
+}
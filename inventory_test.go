@@ -0,0 +1,84 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newInventoryAPI() *API {
+	api := New()
+	api.Security = &SecurityRequirement{Requirements: []map[string][]string{{"apiKey": {}}}}
+	api.AddEndpoint(
+		&Endpoint{Path: "/pets", Method: http.MethodGet, Tags: []string{"pets", "public"}},
+		&Endpoint{
+			Path: "/pets", Method: http.MethodPost, Owner: "pets-team", Deprecated: true,
+			Security: &SecurityRequirement{Requirements: []map[string][]string{{"oauth": {"write"}, "apiKey": {}}, {"basic": {}}}},
+		},
+		&Endpoint{Path: "/health", Method: http.MethodGet, Security: &SecurityRequirement{DisableSecurity: true}},
+	)
+	return api
+}
+
+func TestExportInventory(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, ExportInventory(newInventoryAPI(), &buf, FormatCSV))
+	assert.Equal(t, "method,path,operationId,tags,auth,owner,deprecated,version\n"+
+		"GET,/health,getHealth,,none,,false,\n"+
+		"GET,/pets,getPets,\"pets, public\",apiKey,,false,\n"+
+		"POST,/pets,postPets,,apiKey & oauth | basic,pets-team,true,\n", buf.String())
+
+	assert.Error(t, ExportInventory(newInventoryAPI(), &buf, "pdf"))
+}
+
+func TestExportInventoryVersions(t *testing.T) {
+	api := newInventoryAPI()
+	api.Versioning = &Versioning{Header: "Accept", Versions: []string{"1", "2"}}
+	api.AddEndpoint(&Endpoint{Path: "/pets", Method: http.MethodGet, Owner: "pets-v2", Versions: []string{"2"}})
+
+	var buf bytes.Buffer
+	assert.NoError(t, ExportInventory(api, &buf, FormatCSV))
+	assert.Equal(t, "method,path,operationId,tags,auth,owner,deprecated,version\n"+
+		"GET,/health,getHealth,,none,,false,\n"+
+		"GET,/pets,getPets,\"pets, public\",apiKey,,false,\n"+
+		"GET,/pets,getPets,,apiKey,pets-v2,false,2\n"+
+		"POST,/pets,postPets,,apiKey & oauth | basic,pets-team,true,\n", buf.String())
+}
+
+func TestExportInventoryXLSX(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, ExportInventory(newInventoryAPI(), &buf, FormatXLSX))
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	var sheet []byte
+	for _, f := range zr.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			rc, err := f.Open()
+			assert.NoError(t, err)
+			sheet, _ = ioutil.ReadAll(rc)
+			_ = rc.Close()
+		}
+	}
+	assert.Len(t, zr.File, 5)
+	assert.Contains(t, string(sheet), `<c r="A1" t="inlineStr"><is><t>method</t></is></c>`)
+	assert.Contains(t, string(sheet), `<c r="E4" t="inlineStr"><is><t>apiKey &amp; oauth | basic</t></is></c>`)
+}
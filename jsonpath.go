@@ -0,0 +1,318 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonNode represents a value matched by a json path together with the container holding it
+type jsonNode struct {
+	value  interface{}
+	parent interface{}
+	key    interface{}
+}
+
+// set replaces the value of the node inside its container
+func (n *jsonNode) set(v interface{}) {
+	switch parent := n.parent.(type) {
+	case map[string]interface{}:
+		parent[n.key.(string)] = v
+	case []interface{}:
+		parent[n.key.(int)] = v
+	}
+	n.value = v
+}
+
+type jsonFilter struct {
+	keys     []string
+	operator string
+	value    interface{}
+}
+
+func (f *jsonFilter) match(v interface{}) bool {
+	for _, key := range f.keys {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if v, ok = m[key]; !ok {
+			return false
+		}
+	}
+	switch f.operator {
+	case "":
+		return true
+	case "==":
+		return jsonEqual(v, f.value)
+	case "!=":
+		return !jsonEqual(v, f.value)
+	}
+	return false
+}
+
+type jsonSegment struct {
+	recursive bool
+	wildcard  bool
+	names     []string
+	index     *int
+	filter    *jsonFilter
+}
+
+// parseJSONPath parses the supported subset of json path:
+// $, .name, ['name'], [n], [*], .*, ..name and [?(@.key == 'value')]
+func parseJSONPath(expr string) ([]jsonSegment, error) {
+	if !strings.HasPrefix(expr, "$") {
+		return nil, fmt.Errorf("invalid json path %q: must start with $", expr)
+	}
+	rest := expr[1:]
+	segments := make([]jsonSegment, 0)
+	for rest != "" {
+		var seg jsonSegment
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			seg.recursive = true
+			rest = rest[2:]
+			if strings.HasPrefix(rest, "[") {
+				break
+			}
+			fallthrough
+		case strings.HasPrefix(rest, "."):
+			rest = strings.TrimPrefix(rest, ".")
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			name := rest[:end]
+			rest = rest[end:]
+			if name == "" {
+				return nil, fmt.Errorf("invalid json path %q: empty name", expr)
+			}
+			if name == "*" {
+				seg.wildcard = true
+			} else {
+				seg.names = []string{name}
+			}
+			segments = append(segments, seg)
+			continue
+		case strings.HasPrefix(rest, "["):
+		default:
+			return nil, fmt.Errorf("invalid json path %q: unexpected %q", expr, rest)
+		}
+
+		end := closingBracket(rest)
+		if end < 0 {
+			return nil, fmt.Errorf("invalid json path %q: missing ]", expr)
+		}
+		content := strings.TrimSpace(rest[1:end])
+		rest = rest[end+1:]
+		if err := parseBracket(content, &seg); err != nil {
+			return nil, fmt.Errorf("invalid json path %q: %v", expr, err)
+		}
+		segments = append(segments, seg)
+	}
+	return segments, nil
+}
+
+func closingBracket(s string) int {
+	var quote byte
+	for i := 1; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '\'' || s[i] == '"':
+			quote = s[i]
+		case s[i] == ']':
+			return i
+		}
+	}
+	return -1
+}
+
+func parseBracket(content string, seg *jsonSegment) error {
+	switch {
+	case content == "*":
+		seg.wildcard = true
+	case strings.HasPrefix(content, "?"):
+		filter, err := parseFilter(content[1:])
+		if err != nil {
+			return err
+		}
+		seg.filter = filter
+	case strings.HasPrefix(content, "'") || strings.HasPrefix(content, `"`):
+		for _, part := range strings.Split(content, ",") {
+			name, err := unquote(strings.TrimSpace(part))
+			if err != nil {
+				return err
+			}
+			seg.names = append(seg.names, name)
+		}
+	default:
+		index, err := strconv.Atoi(content)
+		if err != nil {
+			return fmt.Errorf("unsupported selector [%s]", content)
+		}
+		seg.index = &index
+	}
+	return nil
+}
+
+func parseFilter(expr string) (*jsonFilter, error) {
+	expr = strings.TrimSpace(expr)
+	if strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+
+	filter := &jsonFilter{}
+	left := expr
+	for _, operator := range []string{"==", "!="} {
+		if i := strings.Index(expr, operator); i >= 0 {
+			filter.operator = operator
+			left = strings.TrimSpace(expr[:i])
+			right := strings.TrimSpace(expr[i+len(operator):])
+			if strings.HasPrefix(right, "'") {
+				v, err := unquote(right)
+				if err != nil {
+					return nil, err
+				}
+				filter.value = v
+			} else if err := json.Unmarshal([]byte(right), &filter.value); err != nil {
+				return nil, fmt.Errorf("invalid filter value %s", right)
+			}
+			break
+		}
+	}
+	if left != "@" && !strings.HasPrefix(left, "@.") {
+		return nil, fmt.Errorf("unsupported filter %s", expr)
+	}
+	if left != "@" {
+		filter.keys = strings.Split(left[2:], ".")
+	}
+	return filter, nil
+}
+
+func unquote(s string) (string, error) {
+	if len(s) < 2 || (s[0] != '\'' && s[0] != '"') || s[len(s)-1] != s[0] {
+		return "", fmt.Errorf("invalid quoted name %s", s)
+	}
+	return s[1 : len(s)-1], nil
+}
+
+// queryJSONPath returns the nodes of doc matched by the json path expression
+func queryJSONPath(doc interface{}, expr string) ([]*jsonNode, error) {
+	segments, err := parseJSONPath(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []*jsonNode{{value: doc}}
+	for _, seg := range segments {
+		if seg.recursive {
+			nodes = descendants(nodes)
+		}
+		next := make([]*jsonNode, 0)
+		for _, node := range nodes {
+			next = append(next, selectChildren(node, seg)...)
+		}
+		nodes = next
+	}
+	return nodes, nil
+}
+
+func descendants(nodes []*jsonNode) []*jsonNode {
+	result := make([]*jsonNode, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, node)
+		result = append(result, descendants(children(node))...)
+	}
+	return result
+}
+
+func children(node *jsonNode) []*jsonNode {
+	result := make([]*jsonNode, 0)
+	switch v := node.value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			result = append(result, &jsonNode{value: v[k], parent: v, key: k})
+		}
+	case []interface{}:
+		for i, item := range v {
+			result = append(result, &jsonNode{value: item, parent: v, key: i})
+		}
+	}
+	return result
+}
+
+func selectChildren(node *jsonNode, seg jsonSegment) []*jsonNode {
+	switch {
+	case seg.wildcard:
+		return children(node)
+	case seg.filter != nil:
+		result := make([]*jsonNode, 0)
+		for _, child := range children(node) {
+			if seg.filter.match(child.value) {
+				result = append(result, child)
+			}
+		}
+		return result
+	case seg.index != nil:
+		list, ok := node.value.([]interface{})
+		if !ok {
+			return nil
+		}
+		index := *seg.index
+		if index < 0 {
+			index += len(list)
+		}
+		if index < 0 || index >= len(list) {
+			return nil
+		}
+		return []*jsonNode{{value: list[index], parent: list, key: index}}
+	}
+
+	m, ok := node.value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	result := make([]*jsonNode, 0, len(seg.names))
+	for _, name := range seg.names {
+		if v, ok := m[name]; ok {
+			result = append(result, &jsonNode{value: v, parent: m, key: name})
+		}
+	}
+	return result
+}
+
+func jsonEqual(a, b interface{}) bool {
+	if n, ok := a.(json.Number); ok {
+		a, _ = n.Float64()
+	}
+	if n, ok := b.(json.Number); ok {
+		b, _ = n.Float64()
+	}
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,176 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"context"
+	"mime"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Limit returns a middleware enforcing the body size and timeout limits of the endpoint,
+// and the size and content type limits of its file parameters;
+// oversized requests are answered with 413, unaccepted files with 415 and timed out requests with 408
+func Limit(e *Endpoint, next http.Handler) http.Handler {
+	uploads := uploadLimits(e)
+	if e.MaxBodyBytes <= 0 && e.Timeout <= 0 && len(uploads) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if e.MaxBodyBytes > 0 {
+			if req.ContentLength > e.MaxBodyBytes {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				return
+			}
+			req.Body = http.MaxBytesReader(w, req.Body, e.MaxBodyBytes)
+		}
+		if len(uploads) > 0 {
+			if code := checkUploads(req, uploads); code != 0 {
+				w.WriteHeader(code)
+				return
+			}
+		}
+		if e.Timeout <= 0 {
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), e.Timeout)
+		defer cancel()
+
+		tw := &timeoutWriter{header: make(http.Header)}
+		done := make(chan struct{})
+		panicChan := make(chan interface{}, 1)
+		go func() {
+			defer func() {
+				if p := recover(); p != nil {
+					panicChan <- p
+				}
+			}()
+			next.ServeHTTP(tw, req.WithContext(ctx))
+			close(done)
+		}()
+
+		select {
+		case p := <-panicChan:
+			panic(p)
+		case <-done:
+			tw.mu.Lock()
+			defer tw.mu.Unlock()
+			for k, v := range tw.header {
+				w.Header()[k] = v
+			}
+			if tw.code == 0 {
+				tw.code = http.StatusOK
+			}
+			w.WriteHeader(tw.code)
+			_, _ = w.Write(tw.buf.Bytes())
+		case <-ctx.Done():
+			tw.mu.Lock()
+			defer tw.mu.Unlock()
+			tw.timedOut = true
+			w.WriteHeader(http.StatusRequestTimeout)
+		}
+	})
+}
+
+// uploadLimits returns the form data parameters of the endpoint limiting the size or the content type of the files
+func uploadLimits(e *Endpoint) []Parameter {
+	var result []Parameter
+	for _, p := range e.Parameters {
+		if p.In == "formData" && (p.MaxSize > 0 || len(p.ContentTypes) > 0) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// checkUploads parses the multipart form of the request, which stays available to the handler,
+// and returns the status answering the files breaking the limits, or 0
+func checkUploads(req *http.Request, uploads []Parameter) int {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		return 0
+	}
+	if err := req.ParseMultipartForm(32 << 20); err != nil {
+		if strings.Contains(err.Error(), "request body too large") {
+			return http.StatusRequestEntityTooLarge
+		}
+		return http.StatusBadRequest
+	}
+	for _, p := range uploads {
+		for _, file := range req.MultipartForm.File[p.Name] {
+			if p.MaxSize > 0 && file.Size > p.MaxSize {
+				return http.StatusRequestEntityTooLarge
+			}
+			if len(p.ContentTypes) > 0 && !acceptsContentType(p.ContentTypes, file.Header.Get("Content-Type")) {
+				return http.StatusUnsupportedMediaType
+			}
+		}
+	}
+	return 0
+}
+
+// acceptsContentType reports whether the content type matches one of the accepted media types, e.g. image/*
+func acceptsContentType(accepted []string, contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, v := range accepted {
+		if v == mediaType || v == "*/*" ||
+			(strings.HasSuffix(v, "/*") && strings.HasPrefix(mediaType, strings.TrimSuffix(v, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// timeoutWriter buffers the response until the handler completes in time
+type timeoutWriter struct {
+	mu       sync.Mutex
+	header   http.Header
+	buf      bytes.Buffer
+	code     int
+	timedOut bool
+}
+
+func (w *timeoutWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *timeoutWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if w.code == 0 {
+		w.code = http.StatusOK
+	}
+	return w.buf.Write(p)
+}
+
+func (w *timeoutWriter) WriteHeader(code int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.timedOut || w.code != 0 {
+		return
+	}
+	w.code = code
+}
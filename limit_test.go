@@ -0,0 +1,140 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimit(t *testing.T) {
+	e := &Endpoint{MaxBodyBytes: 4, Timeout: 50 * time.Millisecond}
+	handler := Limit(e, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if _, err := io.ReadAll(req.Body); err != nil {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		if req.URL.Query().Get("sleep") != "" {
+			<-req.Context().Done()
+			return
+		}
+		w.Header().Set("X-Test", "ok")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, "done")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("abc")))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "ok", w.Header().Get("X-Test"))
+	assert.Equal(t, "done", w.Body.String())
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/", strings.NewReader("abcdef")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/", io.NopCloser(strings.NewReader("abcdef")))
+	req.ContentLength = -1
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/?sleep=1", nil))
+	assert.Equal(t, http.StatusRequestTimeout, w.Code)
+}
+
+func TestLimitTimeout(t *testing.T) {
+	release := make(chan struct{})
+	written := make(chan error, 1)
+	e := &Endpoint{Timeout: time.Millisecond}
+	handler := Limit(e, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		<-req.Context().Done()
+		<-release
+		w.WriteHeader(http.StatusCreated)
+		_, err := io.WriteString(w, "late")
+		written <- err
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusRequestTimeout, w.Code)
+
+	// the handler outliving the request cannot write to the response anymore
+	close(release)
+	assert.Equal(t, http.ErrHandlerTimeout, <-written)
+	assert.Empty(t, w.Body.String())
+}
+
+func TestLimitNone(t *testing.T) {
+	next := http.NotFoundHandler()
+	assert.NotNil(t, Limit(&Endpoint{}, next))
+}
+
+func TestEndpoint_MaxBodyBytesJSON(t *testing.T) {
+	data, err := json.Marshal(&Endpoint{MaxBodyBytes: 10, Timeout: time.Second})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"x-max-body-size":10}`, string(data))
+}
+
+func TestLimitUploads(t *testing.T) {
+	e := &Endpoint{Parameters: []Parameter{
+		{In: "formData", Name: "avatar", Type: "file", MaxSize: 8, ContentTypes: []string{"image/*"}},
+		{In: "formData", Name: "note", Type: "string"},
+	}}
+	handler := Limit(e, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// the parsed form stays available to the handler
+		_, header, err := req.FormFile("avatar")
+		if !assert.NoError(t, err) {
+			return
+		}
+		_, _ = io.WriteString(w, header.Filename+" "+req.FormValue("note"))
+	}))
+
+	upload := func(contentType, content string) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		h := make(textproto.MIMEHeader)
+		h.Set("Content-Disposition", `form-data; name="avatar"; filename="me.png"`)
+		h.Set("Content-Type", contentType)
+		part, _ := mw.CreatePart(h)
+		_, _ = io.WriteString(part, content)
+		_ = mw.WriteField("note", "hello")
+		_ = mw.Close()
+
+		req := httptest.NewRequest(http.MethodPost, "/avatars", &body)
+		req.Header.Set("Content-Type", mw.FormDataContentType())
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := upload("image/png", "12345678")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "me.png hello", w.Body.String())
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, upload("image/png", "123456789").Code)
+	assert.Equal(t, http.StatusUnsupportedMediaType, upload("application/pdf", "1234").Code)
+}
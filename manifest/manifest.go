@@ -0,0 +1,248 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/endpoint"
+	"github.com/zc2638/swag/types"
+)
+
+// Manifest represents a yaml manifest of routes
+type Manifest struct {
+	Routes []Route `yaml:"routes"`
+}
+
+// Route represents a route declared in the manifest
+type Route struct {
+	Method      string              `yaml:"method"`
+	Path        string              `yaml:"path"`
+	Summary     string              `yaml:"summary"`
+	Description string              `yaml:"description"`
+	OperationID string              `yaml:"operationId"`
+	Tags        []string            `yaml:"tags"`
+	Handler     string              `yaml:"handler"`
+	Deprecated  bool                `yaml:"deprecated"`
+	Parameters  []Parameter         `yaml:"parameters"`
+	Body        string              `yaml:"body"`
+	Response    string              `yaml:"response"`
+	Responses   map[string]Response `yaml:"responses"`
+}
+
+// Parameter represents a non-body parameter declared in the manifest
+type Parameter struct {
+	Name        string `yaml:"name"`
+	In          string `yaml:"in"`
+	Type        string `yaml:"type"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+	Default     string `yaml:"default"`
+}
+
+// Response represents a response declared in the manifest
+type Response struct {
+	Description string `yaml:"description"`
+	Model       string `yaml:"model"`
+}
+
+// Resolver returns the prototype registered with the model name
+type Resolver func(name string) (interface{}, bool)
+
+type loader struct {
+	resolve  Resolver
+	handlers map[string]interface{}
+}
+
+// Option provides configuration options to the manifest loader
+type Option func(l *loader)
+
+// WithResolver sets the resolver used to look up the models referenced by name;
+// defaults to the types registered with swag.RegisterType
+func WithResolver(resolve Resolver) Option {
+	return func(l *loader) {
+		l.resolve = resolve
+	}
+}
+
+// WithHandlers binds the handlers referenced by name from the manifest routes
+func WithHandlers(handlers map[string]interface{}) Option {
+	return func(l *loader) {
+		l.handlers = handlers
+	}
+}
+
+// LoadFile reads the manifest file at the specified path and builds its endpoints
+func LoadFile(path string, options ...Option) ([]*swag.Endpoint, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f, options...)
+}
+
+// Load reads the yaml manifest and builds its endpoints;
+// models are referenced by name, prefixed with [] for arrays
+func Load(r io.Reader, options ...Option) ([]*swag.Endpoint, error) {
+	l := &loader{resolve: swag.TypeByName}
+	for _, opt := range options {
+		opt(l)
+	}
+
+	var m Manifest
+	if err := yaml.NewDecoder(r).Decode(&m); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	endpoints := make([]*swag.Endpoint, 0, len(m.Routes))
+	for _, route := range m.Routes {
+		e, err := l.build(route)
+		if err != nil {
+			return nil, fmt.Errorf("route %s %s: %v", route.Method, route.Path, err)
+		}
+		endpoints = append(endpoints, e)
+	}
+	return endpoints, nil
+}
+
+func (l *loader) build(route Route) (*swag.Endpoint, error) {
+	if route.Method == "" || route.Path == "" {
+		return nil, fmt.Errorf("method and path are required")
+	}
+
+	opts := []endpoint.Option{
+		endpoint.Summary(route.Summary),
+		endpoint.Description(route.Description),
+	}
+	if route.OperationID != "" {
+		opts = append(opts, endpoint.OperationID(route.OperationID))
+	}
+	if len(route.Tags) > 0 {
+		opts = append(opts, endpoint.Tags(route.Tags...))
+	}
+	if route.Deprecated {
+		opts = append(opts, endpoint.Deprecated())
+	}
+	if route.Handler != "" {
+		handler, ok := l.handlers[route.Handler]
+		if !ok {
+			return nil, fmt.Errorf("unknown handler %q", route.Handler)
+		}
+		opts = append(opts, endpoint.Handler(handler))
+	}
+
+	for _, p := range route.Parameters {
+		opt, err := parameter(p)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, opt)
+	}
+
+	if route.Body != "" {
+		prototype, err := l.model(route.Body)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, endpoint.Body(prototype, "", true))
+	}
+
+	responses := route.Responses
+	if route.Response != "" {
+		if responses == nil {
+			responses = make(map[string]Response)
+		}
+		if _, ok := responses["200"]; !ok {
+			responses["200"] = Response{Description: "success", Model: route.Response}
+		}
+	}
+	codes := make([]string, 0, len(responses))
+	for code := range responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		status, err := strconv.Atoi(code)
+		if err != nil && code != "default" {
+			return nil, fmt.Errorf("invalid response code %q", code)
+		}
+		response := responses[code]
+		var responseOpts []endpoint.ResponseOption
+		if response.Model != "" {
+			prototype, err := l.model(response.Model)
+			if err != nil {
+				return nil, err
+			}
+			responseOpts = append(responseOpts, endpoint.SchemaResponseOption(prototype))
+		}
+		if code == "default" {
+			opts = append(opts, endpoint.DefaultResponse(response.Description, responseOpts...))
+			continue
+		}
+		opts = append(opts, endpoint.Response(status, response.Description, responseOpts...))
+	}
+	return endpoint.New(route.Method, route.Path, opts...), nil
+}
+
+func (l *loader) model(name string) (interface{}, error) {
+	isArray := strings.HasPrefix(name, "[]")
+	name = strings.TrimPrefix(name, "[]")
+	prototype, ok := l.resolve(name)
+	if !ok {
+		return nil, fmt.Errorf("unknown model %q", name)
+	}
+	if isArray {
+		t := reflect.SliceOf(reflect.TypeOf(prototype))
+		return reflect.MakeSlice(t, 0, 0).Interface(), nil
+	}
+	return prototype, nil
+}
+
+func parameter(p Parameter) (endpoint.Option, error) {
+	typ := types.ParameterType(p.Type)
+	if typ == "" {
+		typ = types.String
+	}
+	switch p.In {
+	case "path":
+		return endpoint.PathDefault(p.Name, typ, p.Description, p.Default, true), nil
+	case "query", "":
+		return endpoint.QueryDefault(p.Name, typ, p.Description, p.Default, p.Required), nil
+	case "formData":
+		return endpoint.FormData(p.Name, typ, p.Description, p.Required), nil
+	case "header":
+		return func(e *swag.Endpoint) {
+			e.Parameters = append(e.Parameters, swag.Parameter{
+				In:          "header",
+				Name:        p.Name,
+				Type:        typ,
+				Description: p.Description,
+				Required:    p.Required,
+				Default:     p.Default,
+			})
+		}, nil
+	}
+	return nil, fmt.Errorf("unsupported parameter location %q", p.In)
+}
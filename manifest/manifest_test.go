@@ -0,0 +1,121 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag"
+)
+
+type Pet struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+type Error struct {
+	Message string `json:"message"`
+}
+
+func resolve(name string) (interface{}, bool) {
+	models := map[string]interface{}{
+		"Pet":   Pet{},
+		"Error": Error{},
+	}
+	v, ok := models[name]
+	return v, ok
+}
+
+func TestLoadFile(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	endpoints, err := LoadFile("testdata/routes.yaml",
+		WithResolver(resolve),
+		WithHandlers(map[string]interface{}{"getPet": handler}),
+	)
+	assert.Nil(t, err)
+	assert.Len(t, endpoints, 2)
+
+	get := endpoints[0]
+	assert.Equal(t, http.MethodGet, get.Method)
+	assert.Equal(t, "/pets/{id}", get.Path)
+	assert.Equal(t, "Find pet by ID", get.Summary)
+	assert.Equal(t, []string{"pets"}, get.Tags)
+	assert.NotNil(t, get.Handler)
+	assert.Equal(t, []swag.Parameter{
+		{In: "path", Name: "id", Type: "integer", Description: "ID of pet", Required: true},
+		{In: "header", Name: "X-Request-ID", Type: "string"},
+	}, get.Parameters)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag_manifest.Pet", get.Responses["200"].Schema.Ref)
+	assert.Equal(t, "not found", get.Responses["404"].Description)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag_manifest.Error", get.Responses["404"].Schema.Ref)
+	assert.Equal(t, "unexpected error", get.Responses["default"].Description)
+
+	post := endpoints[1]
+	assert.Equal(t, "array", post.Parameters[0].Schema.Type)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag_manifest.Pet", post.Parameters[0].Schema.Items.Ref)
+
+	api := swag.New()
+	api.AddEndpoint(endpoints...)
+	assert.Len(t, api.Definitions, 2)
+}
+
+func TestLoadRegistered(t *testing.T) {
+	swag.RegisterType("ManifestPet", Pet{})
+	endpoints, err := Load(strings.NewReader("routes: [{method: get, path: /pets, response: '[]ManifestPet'}]"))
+	assert.Nil(t, err)
+	assert.Len(t, endpoints, 1)
+	assert.Equal(t, "#/definitions/github.com_zc2638_swag_manifest.Pet", endpoints[0].Responses["200"].Schema.Items.Ref)
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		want     string
+	}{
+		{
+			name:     "unknown model",
+			manifest: "routes: [{method: get, path: /, response: Unknown}]",
+			want:     `unknown model "Unknown"`,
+		},
+		{
+			name:     "unknown handler",
+			manifest: "routes: [{method: get, path: /, handler: none}]",
+			want:     `unknown handler "none"`,
+		},
+		{
+			name:     "parameter location",
+			manifest: "routes: [{method: get, path: /, parameters: [{name: a, in: cookie}]}]",
+			want:     `unsupported parameter location "cookie"`,
+		},
+		{
+			name:     "missing path",
+			manifest: "routes: [{method: get}]",
+			want:     "method and path are required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(strings.NewReader(tt.manifest), WithResolver(resolve))
+			if assert.NotNil(t, err) {
+				assert.Contains(t, err.Error(), tt.want)
+			}
+		})
+	}
+}
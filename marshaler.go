@@ -0,0 +1,156 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+var jsonSchemas = struct {
+	sync.RWMutex
+	overrides map[reflect.Type]Property
+	inferred  map[reflect.Type]*Property
+}{
+	overrides: make(map[reflect.Type]Property),
+	inferred:  make(map[reflect.Type]*Property),
+}
+
+// RegisterJSONSchema documents the type with the specified property instead of reflecting upon it,
+// e.g. a json.Marshaler whose json cannot be inferred from its zero value
+func RegisterJSONSchema(prototype interface{}, p Property) {
+	t := reflect.TypeOf(prototype)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	p.GoType = t
+
+	jsonSchemas.Lock()
+	defer jsonSchemas.Unlock()
+	jsonSchemas.overrides[t] = p
+}
+
+// lookupJSONSchema returns the property of the type registered with RegisterJSONSchema
+func lookupJSONSchema(t reflect.Type) (Property, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	jsonSchemas.RLock()
+	defer jsonSchemas.RUnlock()
+	p, ok := jsonSchemas.overrides[t]
+	return p, ok
+}
+
+// lookupMarshaler returns the property inferred from the json of the zero value
+// of a json.Marshaler struct without exported fields, whose fields tell nothing about its json
+func lookupMarshaler(t reflect.Type) (Property, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	jsonSchemas.RLock()
+	inferred, ok := jsonSchemas.inferred[t]
+	jsonSchemas.RUnlock()
+	if !ok {
+		inferred = inferJSONSchema(t)
+		jsonSchemas.Lock()
+		jsonSchemas.inferred[t] = inferred
+		jsonSchemas.Unlock()
+	}
+	if inferred == nil {
+		return Property{}, false
+	}
+	return *inferred, true
+}
+
+// isOpaqueMarshaler reports whether the struct encodes itself to json without exposing any exported field
+func isOpaqueMarshaler(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct || !reflect.PtrTo(t).Implements(marshalerType) {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if isJSONField(t.Field(i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func inferJSONSchema(t reflect.Type) (p *Property) {
+	if !isOpaqueMarshaler(t) {
+		return nil
+	}
+	defer func() {
+		// the zero value may not be valid for the custom encoding
+		if recover() != nil {
+			p = nil
+		}
+	}()
+
+	data, err := json.Marshal(reflect.New(t).Interface())
+	if err != nil {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var sample interface{}
+	if err := decoder.Decode(&sample); err != nil || sample == nil {
+		return nil
+	}
+	inferred := withoutExamples(sampleProperty(sample))
+	inferred.GoType = t
+	return &inferred
+}
+
+// withoutExamples drops the examples inferred from the zero value, which tell nothing about the actual values
+func withoutExamples(p Property) Property {
+	p.Example = ""
+	for name, child := range p.Properties {
+		p.Properties[name] = withoutExamples(child)
+	}
+	if p.Items != nil {
+		for name, child := range p.Items.Properties {
+			p.Items.Properties[name] = withoutExamples(child)
+		}
+	}
+	return p
+}
+
+// isJSONField reports whether encoding/json encodes the field, unless it is ignored with json:"-"
+func isJSONField(field reflect.StructField) bool {
+	return field.Tag.Get("json") != "-" && !isUnexported(field)
+}
+
+// isUnexported reports whether the field is hidden from encoding/json: unexported fields are,
+// except the embedded structs whose exported fields are promoted
+func isUnexported(field reflect.StructField) bool {
+	if field.PkgPath == "" {
+		return false
+	}
+	if !field.Anonymous {
+		return true
+	}
+	t := field.Type
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() != reflect.Struct
+}
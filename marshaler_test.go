@@ -0,0 +1,107 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Price struct {
+	cents    int64
+	currency string
+}
+
+func (m Price) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"amount":   fmt.Sprintf("%d.%02d", m.cents/100, m.cents%100),
+		"currency": m.currency,
+	})
+}
+
+type WalletID struct {
+	value uint64
+}
+
+func (id *WalletID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("acc_%d", id.value))
+}
+
+type Handle struct {
+	value *string
+}
+
+func (h Handle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(*h.value)
+}
+
+type Color struct {
+	rgb uint32
+}
+
+func (c Color) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.rgb)
+}
+
+type auditFields struct {
+	CreatedBy string `json:"createdBy"`
+}
+
+type Wallet struct {
+	auditFields
+	ID      WalletID   `json:"id"`
+	Balance Price      `json:"balance"`
+	History []Price    `json:"history"`
+	Handle  Handle     `json:"handle"`
+	Color   Color      `json:"color"`
+	Owners  []WalletID `json:"owners"`
+}
+
+func TestMarshalerProperties(t *testing.T) {
+	RegisterJSONSchema(Color{}, Property{Type: "string", Pattern: "^#[0-9a-f]{6}$"})
+
+	obj := defineObject(Wallet{}, "")
+	assert.Equal(t, []string{"balance", "color", "createdBy", "handle", "history", "id", "owners"}, propertyNames(obj))
+
+	assert.Equal(t, "string", obj.Properties["createdBy"].Type)
+	assert.Equal(t, "string", obj.Properties["id"].Type)
+	assert.Empty(t, obj.Properties["id"].Example)
+	assert.Equal(t, &Items{Type: "string"}, obj.Properties["owners"].Items)
+
+	balance := obj.Properties["balance"]
+	assert.Equal(t, "object", balance.Type)
+	assert.Empty(t, balance.Ref)
+	assert.Equal(t, map[string]Property{"amount": {Type: "string"}, "currency": {Type: "string"}}, balance.Properties)
+	assert.Equal(t, balance.Properties, obj.Properties["history"].Items.Properties)
+
+	// the zero value of the handle can not be encoded
+	assert.Equal(t, "#/definitions/"+DefinitionName(Handle{}), obj.Properties["handle"].Ref)
+
+	assert.Equal(t, "string", obj.Properties["color"].Type)
+	assert.Equal(t, "^#[0-9a-f]{6}$", obj.Properties["color"].Pattern)
+}
+
+func propertyNames(obj Object) []string {
+	names := make([]string, 0, len(obj.Properties))
+	for name := range obj.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,105 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"unicode"
+)
+
+// Namer is implemented by the models naming their definition, e.g. the instantiations of a generic wrapper;
+// the method is called on the zero value of the model
+type Namer interface {
+	SwaggerName() string
+}
+
+var namerType = reflect.TypeOf((*Namer)(nil)).Elem()
+
+// NamingPolicy returns the property name of a go field without a name tag
+type NamingPolicy func(fieldName string) string
+
+var namingPolicy atomic.Value
+
+func init() {
+	namingPolicy.Store(NamingPolicy(NamingIdentity))
+}
+
+// SetNamingPolicy sets the naming policy applied to the fields without a name tag; defaults to NamingIdentity
+func SetNamingPolicy(policy NamingPolicy) {
+	if policy == nil {
+		policy = NamingIdentity
+	}
+	namingPolicy.Store(policy)
+}
+
+func propertyName(fieldName string) string {
+	return namingPolicy.Load().(NamingPolicy)(fieldName)
+}
+
+// NamingIdentity keeps the go field name, e.g. UserID
+func NamingIdentity(fieldName string) string {
+	return fieldName
+}
+
+// NamingSnakeCase converts the go field name to snake case, e.g. UserID to user_id
+func NamingSnakeCase(fieldName string) string {
+	words := splitWords(fieldName)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
+
+// NamingCamelCase converts the go field name to camel case, e.g. HTTPServer to httpServer
+func NamingCamelCase(fieldName string) string {
+	words := splitWords(fieldName)
+	if len(words) > 0 {
+		words[0] = strings.ToLower(words[0])
+	}
+	return strings.Join(words, "")
+}
+
+// splitWords splits the go identifier into words, keeping the acronyms together, e.g. HTTPServerID to HTTP, Server, ID
+func splitWords(s string) []string {
+	runes := []rune(s)
+	words := make([]string, 0)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		boundary := false
+		switch {
+		case cur == '_':
+			if i > start {
+				words = append(words, string(runes[start:i]))
+			}
+			start = i + 1
+			continue
+		case unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			boundary = true
+		case unicode.IsUpper(prev) && unicode.IsUpper(cur) && i+1 < len(runes) && unicode.IsLower(runes[i+1]):
+			boundary = true
+		}
+		if boundary && i > start {
+			words = append(words, string(runes[start:i]))
+			start = i
+		}
+	}
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
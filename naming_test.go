@@ -0,0 +1,77 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamingPolicy(t *testing.T) {
+	tests := []struct {
+		name  string
+		snake string
+		camel string
+	}{
+		{name: "ID", snake: "id", camel: "id"},
+		{name: "UserID", snake: "user_id", camel: "userID"},
+		{name: "HTTPServer", snake: "http_server", camel: "httpServer"},
+		{name: "CreatedAt", snake: "created_at", camel: "createdAt"},
+		{name: "Address2Line", snake: "address2_line", camel: "address2Line"},
+		{name: "Legacy_Name", snake: "legacy_name", camel: "legacyName"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.name, NamingIdentity(tt.name))
+			assert.Equal(t, tt.snake, NamingSnakeCase(tt.name))
+			assert.Equal(t, tt.camel, NamingCamelCase(tt.name))
+		})
+	}
+}
+
+type LegacyOrder struct {
+	OrderID   string
+	CreatedAt string `json:",omitempty"`
+	Total     int    `json:"amount"`
+}
+
+func TestSetNamingPolicy(t *testing.T) {
+	SetNamingPolicy(NamingSnakeCase)
+	defer SetNamingPolicy(nil)
+
+	obj := define(LegacyOrder{})[DefinitionName(LegacyOrder{})]
+	assert.Contains(t, obj.Properties, "order_id")
+	assert.Contains(t, obj.Properties, "created_at")
+	assert.Contains(t, obj.Properties, "amount")
+
+	SetNamingPolicy(nil)
+	obj = define(LegacyOrder{})[DefinitionName(LegacyOrder{})]
+	assert.Contains(t, obj.Properties, "OrderID")
+}
+
+type Renamed struct {
+	Owner Person `json:"owner"`
+}
+
+func (Renamed) SwaggerName() string {
+	return "Ownership"
+}
+
+func TestNamer(t *testing.T) {
+	assert.Equal(t, "Ownership", DefinitionName(Renamed{}))
+	assert.Equal(t, "Ownership", DefinitionName(&Renamed{}))
+	assert.Equal(t, "#/definitions/Ownership", MakeSchema([]Renamed{}).Items.Ref)
+}
@@ -0,0 +1,420 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package oas3 provides the typed object model of OpenAPI 3 documents,
+// which can be inspected and mutated before serialization
+package oas3
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// Document represents the root object of an OpenAPI 3 document
+type Document struct {
+	OpenAPI      string                `json:"openapi"`
+	Info         Info                  `json:"info"`
+	Servers      []Server              `json:"servers,omitempty"`
+	Paths        map[string]*PathItem  `json:"paths"`
+	Components   *Components           `json:"components,omitempty"`
+	Security     []SecurityRequirement `json:"security,omitempty"`
+	Tags         []Tag                 `json:"tags,omitempty"`
+	ExternalDocs *ExternalDocs         `json:"externalDocs,omitempty"`
+	Extensions   Extensions            `json:"-"`
+}
+
+// Info represents the metadata of the api
+type Info struct {
+	Title          string     `json:"title"`
+	Description    string     `json:"description,omitempty"`
+	TermsOfService string     `json:"termsOfService,omitempty"`
+	Contact        *Contact   `json:"contact,omitempty"`
+	License        *License   `json:"license,omitempty"`
+	Version        string     `json:"version"`
+	Extensions     Extensions `json:"-"`
+}
+
+// Contact represents the contact information of the api
+type Contact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// License represents the license of the api
+type License struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+}
+
+// Server represents a server of the api
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// Tag represents a tag used by the operations
+type Tag struct {
+	Name         string        `json:"name"`
+	Description  string        `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
+}
+
+// ExternalDocs represents a reference to external documentation
+type ExternalDocs struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
+// SecurityRequirement maps the names of security schemes to the required scopes
+type SecurityRequirement map[string][]string
+
+// PathItem represents the operations available on a path
+type PathItem struct {
+	Summary     string       `json:"summary,omitempty"`
+	Description string       `json:"description,omitempty"`
+	Get         *Operation   `json:"get,omitempty"`
+	Put         *Operation   `json:"put,omitempty"`
+	Post        *Operation   `json:"post,omitempty"`
+	Delete      *Operation   `json:"delete,omitempty"`
+	Options     *Operation   `json:"options,omitempty"`
+	Head        *Operation   `json:"head,omitempty"`
+	Patch       *Operation   `json:"patch,omitempty"`
+	Trace       *Operation   `json:"trace,omitempty"`
+	Parameters  []*Parameter `json:"parameters,omitempty"`
+}
+
+// Operations returns the operations of the path item by their upper case method
+func (p *PathItem) Operations() map[string]*Operation {
+	result := make(map[string]*Operation)
+	for method, op := range map[string]*Operation{
+		http.MethodGet:     p.Get,
+		http.MethodPut:     p.Put,
+		http.MethodPost:    p.Post,
+		http.MethodDelete:  p.Delete,
+		http.MethodOptions: p.Options,
+		http.MethodHead:    p.Head,
+		http.MethodPatch:   p.Patch,
+		http.MethodTrace:   p.Trace,
+	} {
+		if op != nil {
+			result[method] = op
+		}
+	}
+	return result
+}
+
+// Operation returns the operation of the method, or nil if there is none
+func (p *PathItem) Operation(method string) *Operation {
+	return p.Operations()[strings.ToUpper(method)]
+}
+
+// Operation represents a single api operation on a path
+type Operation struct {
+	Tags         []string               `json:"tags,omitempty"`
+	Summary      string                 `json:"summary,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs          `json:"externalDocs,omitempty"`
+	OperationID  string                 `json:"operationId,omitempty"`
+	Parameters   []*Parameter           `json:"parameters,omitempty"`
+	RequestBody  *RequestBody           `json:"requestBody,omitempty"`
+	Responses    map[string]*Response   `json:"responses"`
+	Deprecated   bool                   `json:"deprecated,omitempty"`
+	Security     *[]SecurityRequirement `json:"security,omitempty"`
+	Servers      []Server               `json:"servers,omitempty"`
+	Extensions   Extensions             `json:"-"`
+}
+
+// Parameter represents a parameter of an operation
+type Parameter struct {
+	Ref         string      `json:"$ref,omitempty"`
+	Name        string      `json:"name,omitempty"`
+	In          string      `json:"in,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required,omitempty"`
+	Deprecated  bool        `json:"deprecated,omitempty"`
+	Style       string      `json:"style,omitempty"`
+	Explode     *bool       `json:"explode,omitempty"`
+	Schema      *Schema     `json:"schema,omitempty"`
+	Example     interface{} `json:"example,omitempty"`
+	Extensions  Extensions  `json:"-"`
+}
+
+// RequestBody represents the request body of an operation
+type RequestBody struct {
+	Ref         string                `json:"$ref,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Required    bool                  `json:"required,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType represents the schema and examples of a media type
+type MediaType struct {
+	Schema   *Schema             `json:"schema,omitempty"`
+	Example  interface{}         `json:"example,omitempty"`
+	Examples map[string]*Example `json:"examples,omitempty"`
+}
+
+// Example represents a named example
+type Example struct {
+	Ref           string      `json:"$ref,omitempty"`
+	Summary       string      `json:"summary,omitempty"`
+	Description   string      `json:"description,omitempty"`
+	Value         interface{} `json:"value,omitempty"`
+	ExternalValue string      `json:"externalValue,omitempty"`
+}
+
+// Response represents a response of an operation
+type Response struct {
+	Ref         string                `json:"$ref,omitempty"`
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+	Extensions  Extensions            `json:"-"`
+}
+
+// Header represents a response header
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Deprecated  bool    `json:"deprecated,omitempty"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// Components holds the reusable objects of the document
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	Responses       map[string]*Response       `json:"responses,omitempty"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty"`
+	Examples        map[string]*Example        `json:"examples,omitempty"`
+	RequestBodies   map[string]*RequestBody    `json:"requestBodies,omitempty"`
+	Headers         map[string]*Header         `json:"headers,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme represents a security scheme of the api
+type SecurityScheme struct {
+	Type             string      `json:"type"`
+	Description      string      `json:"description,omitempty"`
+	Name             string      `json:"name,omitempty"`
+	In               string      `json:"in,omitempty"`
+	Scheme           string      `json:"scheme,omitempty"`
+	BearerFormat     string      `json:"bearerFormat,omitempty"`
+	Flows            *OAuthFlows `json:"flows,omitempty"`
+	OpenIDConnectURL string      `json:"openIdConnectUrl,omitempty"`
+	Extensions       Extensions  `json:"-"`
+}
+
+// OAuthFlows represents the supported oauth flows
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+// OAuthFlow represents the configuration of an oauth flow
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// Types represents the type keyword of a schema, a single type or,
+// as of OpenAPI 3.1, a list of types such as string and null
+type Types []string
+
+// MarshalJSON encodes a single type as a string
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+	return json.Marshal([]string(t))
+}
+
+// UnmarshalJSON decodes either a string or a list of strings
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = Types{s}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*t = list
+	return nil
+}
+
+// Is reports whether the types include the specified type
+func (t Types) Is(typ string) bool {
+	for _, v := range t {
+		if v == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// Schema represents a schema, JSON Schema 2020-12 as of OpenAPI 3.1;
+// the exclusive bounds are flags on Minimum and Maximum in OpenAPI 3.0, and the bounds themselves as of 3.1
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 Types              `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Default              interface{}        `json:"default,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Const                interface{}        `json:"const,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Example              interface{}        `json:"example,omitempty"`
+	Examples             []interface{}      `json:"examples,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     interface{}        `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     interface{}        `json:"exclusiveMaximum,omitempty"`
+	MultipleOf           *float64           `json:"multipleOf,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+	ReadOnly             bool               `json:"readOnly,omitempty"`
+	WriteOnly            bool               `json:"writeOnly,omitempty"`
+	Deprecated           bool               `json:"deprecated,omitempty"`
+	XML                  *XML               `json:"xml,omitempty"`
+	Extensions           Extensions         `json:"-"`
+}
+
+// XML represents the xml naming of a schema
+type XML struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+	Attribute bool   `json:"attribute,omitempty"`
+	Wrapped   bool   `json:"wrapped,omitempty"`
+}
+
+// MarshalJSON encodes the Document together with its extensions
+func (d Document) MarshalJSON() ([]byte, error) {
+	type alias Document
+	return marshalExtensible(alias(d), d.Extensions)
+}
+
+// UnmarshalJSON decodes the Document together with its extensions
+func (d *Document) UnmarshalJSON(data []byte) error {
+	type alias Document
+	ext, err := unmarshalExtensible(data, (*alias)(d))
+	d.Extensions = ext
+	return err
+}
+
+// MarshalJSON encodes the Info together with its extensions
+func (i Info) MarshalJSON() ([]byte, error) {
+	type alias Info
+	return marshalExtensible(alias(i), i.Extensions)
+}
+
+// UnmarshalJSON decodes the Info together with its extensions
+func (i *Info) UnmarshalJSON(data []byte) error {
+	type alias Info
+	ext, err := unmarshalExtensible(data, (*alias)(i))
+	i.Extensions = ext
+	return err
+}
+
+// MarshalJSON encodes the Operation together with its extensions
+func (o Operation) MarshalJSON() ([]byte, error) {
+	type alias Operation
+	return marshalExtensible(alias(o), o.Extensions)
+}
+
+// UnmarshalJSON decodes the Operation together with its extensions
+func (o *Operation) UnmarshalJSON(data []byte) error {
+	type alias Operation
+	ext, err := unmarshalExtensible(data, (*alias)(o))
+	o.Extensions = ext
+	return err
+}
+
+// MarshalJSON encodes the Parameter together with its extensions
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	type alias Parameter
+	return marshalExtensible(alias(p), p.Extensions)
+}
+
+// UnmarshalJSON decodes the Parameter together with its extensions
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	type alias Parameter
+	ext, err := unmarshalExtensible(data, (*alias)(p))
+	p.Extensions = ext
+	return err
+}
+
+// MarshalJSON encodes the Response together with its extensions
+func (r Response) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return json.Marshal(map[string]string{"$ref": r.Ref})
+	}
+	type alias Response
+	return marshalExtensible(alias(r), r.Extensions)
+}
+
+// UnmarshalJSON decodes the Response together with its extensions
+func (r *Response) UnmarshalJSON(data []byte) error {
+	type alias Response
+	ext, err := unmarshalExtensible(data, (*alias)(r))
+	r.Extensions = ext
+	return err
+}
+
+// MarshalJSON encodes the SecurityScheme together with its extensions
+func (s SecurityScheme) MarshalJSON() ([]byte, error) {
+	type alias SecurityScheme
+	return marshalExtensible(alias(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes the SecurityScheme together with its extensions
+func (s *SecurityScheme) UnmarshalJSON(data []byte) error {
+	type alias SecurityScheme
+	ext, err := unmarshalExtensible(data, (*alias)(s))
+	s.Extensions = ext
+	return err
+}
+
+// MarshalJSON encodes the Schema together with its extensions
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type alias Schema
+	return marshalExtensible(alias(s), s.Extensions)
+}
+
+// UnmarshalJSON decodes the Schema together with its extensions
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type alias Schema
+	ext, err := unmarshalExtensible(data, (*alias)(s))
+	s.Extensions = ext
+	return err
+}
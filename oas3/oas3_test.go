@@ -0,0 +1,94 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oas3
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypes(t *testing.T) {
+	data, err := json.Marshal(Types{"string"})
+	assert.NoError(t, err)
+	assert.Equal(t, `"string"`, string(data))
+
+	data, err = json.Marshal(Types{"string", "null"})
+	assert.NoError(t, err)
+	assert.Equal(t, `["string","null"]`, string(data))
+
+	var v Types
+	assert.NoError(t, json.Unmarshal([]byte(`"integer"`), &v))
+	assert.Equal(t, Types{"integer"}, v)
+	assert.NoError(t, json.Unmarshal([]byte(`["integer","null"]`), &v))
+	assert.True(t, v.Is("null"))
+	assert.Error(t, json.Unmarshal([]byte(`1`), &v))
+}
+
+func TestExtensions(t *testing.T) {
+	op := Operation{
+		OperationID: "getUser",
+		Responses:   map[string]*Response{"200": {Description: "ok"}},
+		Extensions:  Extensions{"x-rate-limit": 10, "x-internal": true},
+	}
+	data, err := json.Marshal(op)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"operationId":"getUser","responses":{"200":{"description":"ok"}},"x-internal":true,"x-rate-limit":10}`, string(data))
+
+	var decoded Operation
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "getUser", decoded.OperationID)
+	assert.Equal(t, Extensions{"x-internal": true, "x-rate-limit": float64(10)}, decoded.Extensions)
+
+	data, err = json.Marshal(Schema{Extensions: Extensions{"x-unit": "s"}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"x-unit":"s"}`, string(data))
+}
+
+func TestDocument(t *testing.T) {
+	data := []byte(`{
+		"openapi": "3.1.0",
+		"info": {"title": "api", "version": "1.0"},
+		"paths": {
+			"/users/{id}": {
+				"get": {
+					"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}],
+					"responses": {"200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/User"}}}}}
+				}
+			}
+		},
+		"components": {"schemas": {"User": {"type": "object", "properties": {"name": {"type": ["string", "null"]}}}}},
+		"x-logo": "logo.png"
+	}`)
+
+	var doc Document
+	assert.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "logo.png", doc.Extensions["x-logo"])
+
+	item := doc.Paths["/users/{id}"]
+	assert.Len(t, item.Operations(), 1)
+	assert.Nil(t, item.Operation(http.MethodPost))
+	get := item.Operation("get")
+	assert.Equal(t, "#/components/schemas/User", get.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Equal(t, Types{"string", "null"}, doc.Components.Schemas["User"].Properties["name"].Type)
+
+	get.Summary = "get a user"
+	out, err := json.Marshal(doc)
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"summary":"get a user"`)
+	assert.Contains(t, string(out), `"x-logo":"logo.png"`)
+}
@@ -0,0 +1,689 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/zc2638/swag/oas3"
+)
+
+const (
+	// OpenAPI3 is the OpenAPI 3.0 version emitted by ToOpenAPI3
+	OpenAPI3 = "3.0.3"
+	// OpenAPI31 is the OpenAPI 3.1 version, whose schemas are JSON Schema 2020-12
+	OpenAPI31 = "3.1.0"
+)
+
+// OpenAPIHook mutates the typed OpenAPI 3 document before it is encoded,
+// e.g. to add servers or extensions the swagger definition cannot express
+type OpenAPIHook func(doc *oas3.Document) error
+
+const (
+	componentsPrefix          = "#/components/schemas/"
+	responsesPrefix           = "#/responses/"
+	componentResponsesPrefix  = "#/components/responses/"
+	parametersPrefix          = "#/parameters/"
+	componentParametersPrefix = "#/components/parameters/"
+)
+
+// ToOpenAPI3 returns the OpenAPI 3.0 document converted from the swagger definition,
+// with components/schemas, requestBody and servers instead of definitions and body parameters
+func (a *API) ToOpenAPI3() (map[string]interface{}, error) {
+	doc := a.Clone()
+	doc.OpenAPI = ""
+
+	var buf bytes.Buffer
+	if err := doc.Encode(&buf); err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(&buf)
+	decoder.UseNumber()
+
+	var v map[string]interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return convertOpenAPI3(v, OpenAPI3), nil
+}
+
+// OpenAPIDocument returns the typed OpenAPI 3 document converted from the swagger definition,
+// of the version of the api or 3.0.3 by default, which can be inspected and mutated before serialization
+func (a *API) OpenAPIDocument() (*oas3.Document, error) {
+	version := a.OpenAPI
+	if version == "" {
+		version = OpenAPI3
+	}
+	doc := a.Clone()
+	doc.OpenAPI = ""
+	doc.OpenAPIHooks = nil
+
+	var buf bytes.Buffer
+	if err := doc.Encode(&buf); err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(&buf)
+	decoder.UseNumber()
+
+	var v map[string]interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return typedOpenAPI3(convertOpenAPI3(v, version))
+}
+
+// typedOpenAPI3 decodes the converted document into its typed model
+func typedOpenAPI3(v map[string]interface{}) (*oas3.Document, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var doc oas3.Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+// applyOpenAPIHooks runs the hooks of the api against the typed model of the converted document
+func (a *API) applyOpenAPIHooks(v map[string]interface{}) (interface{}, error) {
+	doc, err := typedOpenAPI3(v)
+	if err != nil {
+		return nil, err
+	}
+	for _, hook := range a.OpenAPIHooks {
+		if err := hook(doc); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var result interface{}
+	if err := decoder.Decode(&result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// convertOpenAPI3 converts the decoded swagger 2.0 document into an OpenAPI 3 document of the version
+func convertOpenAPI3(doc map[string]interface{}, version string) map[string]interface{} {
+	result := map[string]interface{}{
+		"openapi": version,
+	}
+	for k, v := range doc {
+		switch k {
+		case "swagger", "host", "basePath", "schemes", "consumes", "produces",
+			"definitions", "securityDefinitions", "paths", "parameters", "responses":
+		default:
+			result[k] = v
+		}
+	}
+
+	result["servers"] = servers(doc)
+
+	components := make(map[string]interface{})
+	if definitions, ok := doc["definitions"].(map[string]interface{}); ok && len(definitions) > 0 {
+		components["schemas"] = definitions
+	}
+	// the named body and formData parameters have no equivalent, and are inlined in the operations instead
+	named, _ := doc["parameters"].(map[string]interface{})
+	parameters := make(map[string]interface{}, len(named))
+	for name, item := range named {
+		if m, ok := item.(map[string]interface{}); ok && m["in"] != "body" && m["in"] != "formData" {
+			parameters[name] = convertParameter(m)
+		}
+	}
+	if len(parameters) > 0 {
+		components["parameters"] = parameters
+	}
+	if responses, ok := doc["responses"].(map[string]interface{}); ok && len(responses) > 0 {
+		produces := stringList(doc["produces"])
+		converted := make(map[string]interface{}, len(responses))
+		for name, item := range responses {
+			if m, ok := item.(map[string]interface{}); ok {
+				converted[name] = convertResponse(m, produces)
+			}
+		}
+		components["responses"] = converted
+	}
+	if schemes, ok := doc["securityDefinitions"].(map[string]interface{}); ok && len(schemes) > 0 {
+		securitySchemes := make(map[string]interface{}, len(schemes))
+		for name, scheme := range schemes {
+			if m, ok := scheme.(map[string]interface{}); ok {
+				securitySchemes[name] = securityScheme(m)
+			}
+		}
+		components["securitySchemes"] = securitySchemes
+	}
+	if len(components) > 0 {
+		result["components"] = components
+	}
+
+	consumes := stringList(doc["consumes"])
+	produces := stringList(doc["produces"])
+	if paths, ok := doc["paths"].(map[string]interface{}); ok {
+		converted := make(map[string]interface{}, len(paths))
+		for p, item := range paths {
+			if operations, ok := item.(map[string]interface{}); ok {
+				converted[p] = convertPathItem(operations, consumes, produces, named)
+			}
+		}
+		result["paths"] = converted
+	} else {
+		result["paths"] = map[string]interface{}{}
+	}
+
+	renameRefs(result)
+	walkSchemas(result, func(schema map[string]interface{}) {
+		convertSchema(schema, version)
+	})
+	return result
+}
+
+// walkSchemas calls fn with every schema of the OpenAPI 3 document, nested schemas included
+func walkSchemas(doc map[string]interface{}, fn func(schema map[string]interface{})) {
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		schema, ok := v.(map[string]interface{})
+		if !ok {
+			return
+		}
+		fn(schema)
+		if properties, ok := schema["properties"].(map[string]interface{}); ok {
+			for _, p := range properties {
+				walk(p)
+			}
+		}
+		walk(schema["items"])
+		walk(schema["additionalProperties"])
+		for _, k := range []string{"allOf", "anyOf", "oneOf"} {
+			list, _ := schema[k].([]interface{})
+			for _, item := range list {
+				walk(item)
+			}
+		}
+	}
+
+	// schema keys are found in parameters, media types and headers, of the operations and the components
+	var find func(v interface{})
+	find = func(v interface{}) {
+		switch value := v.(type) {
+		case map[string]interface{}:
+			for k, item := range value {
+				if k == "schema" {
+					walk(item)
+				} else {
+					find(item)
+				}
+			}
+		case []interface{}:
+			for _, item := range value {
+				find(item)
+			}
+		}
+	}
+	find(doc["paths"])
+
+	if components, ok := doc["components"].(map[string]interface{}); ok {
+		for k, items := range components {
+			if k != "schemas" {
+				find(items)
+				continue
+			}
+			schemas, _ := items.(map[string]interface{})
+			for _, schema := range schemas {
+				walk(schema)
+			}
+		}
+	}
+}
+
+// convertSchema replaces the swagger 2.0 extensions of the schema with the keywords of the OpenAPI version:
+// nullable for 3.0, and type arrays, const and examples of JSON Schema 2020-12 for 3.1
+func convertSchema(schema map[string]interface{}, version string) {
+	nullable, _ := schema["x-nullable"].(bool)
+	delete(schema, "x-nullable")
+
+	if !strings.HasPrefix(version, "3.1") {
+		if nullable {
+			schema["nullable"] = true
+		}
+		return
+	}
+
+	if nullable {
+		if typ, ok := schema["type"].(string); ok {
+			schema["type"] = []interface{}{typ, "null"}
+		} else if _, ok := schema["$ref"]; ok {
+			schema["anyOf"] = []interface{}{
+				map[string]interface{}{"$ref": schema["$ref"]},
+				map[string]interface{}{"type": "null"},
+			}
+			delete(schema, "$ref")
+		}
+	}
+	// the exclusive bounds are numbers instead of flags on minimum and maximum,
+	// e.g. {minimum: 0, exclusiveMinimum: true} as {exclusiveMinimum: 0}; a flag without its bound is dropped
+	for _, bound := range []string{"minimum", "maximum"} {
+		key := "exclusive" + strings.ToUpper(bound[:1]) + bound[1:]
+		if exclusive, ok := schema[key].(bool); ok {
+			delete(schema, key)
+			if v, ok := schema[bound]; ok && exclusive {
+				schema[key] = v
+				delete(schema, bound)
+			}
+		}
+	}
+	if v, ok := schema["x-const"]; ok {
+		schema["const"] = typedValue(schema["type"], v)
+		delete(schema, "x-const")
+		delete(schema, "enum")
+	}
+	if v, ok := schema["example"]; ok {
+		schema["examples"] = []interface{}{typedValue(schema["type"], v)}
+		delete(schema, "example")
+	}
+}
+
+// typedValue converts the string value to the json type of the schema, e.g. "1" of an integer to 1
+func typedValue(typ, v interface{}) interface{} {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	if list, ok := typ.([]interface{}); ok && len(list) > 0 {
+		typ = list[0]
+	}
+	switch typ {
+	case "integer", "number", "boolean":
+		decoder := json.NewDecoder(strings.NewReader(s))
+		decoder.UseNumber()
+		var value interface{}
+		if err := decoder.Decode(&value); err == nil {
+			return value
+		}
+	}
+	return v
+}
+
+func servers(doc map[string]interface{}) []interface{} {
+	host, _ := doc["host"].(string)
+	basePath, _ := doc["basePath"].(string)
+	if basePath == "" {
+		basePath = "/"
+	}
+	if host == "" {
+		return []interface{}{map[string]interface{}{"url": basePath}}
+	}
+
+	schemes := stringList(doc["schemes"])
+	if len(schemes) == 0 {
+		schemes = []string{"http"}
+	}
+	result := make([]interface{}, 0, len(schemes))
+	for _, scheme := range schemes {
+		url := scheme + "://" + host + strings.TrimSuffix(basePath, "/")
+		result = append(result, map[string]interface{}{"url": url})
+	}
+	return result
+}
+
+func securityScheme(scheme map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	if description, ok := scheme["description"]; ok {
+		result["description"] = description
+	}
+
+	switch scheme["type"] {
+	case "basic":
+		result["type"] = "http"
+		result["scheme"] = "basic"
+	case "apiKey":
+		for k, v := range scheme {
+			result[k] = v
+		}
+		if name, ok := scheme["x-scheme"]; ok {
+			delete(result, "name")
+			delete(result, "in")
+			delete(result, "x-scheme")
+			result["type"] = "http"
+			result["scheme"] = name
+			if format, ok := scheme["x-bearer-format"]; ok {
+				delete(result, "x-bearer-format")
+				result["bearerFormat"] = format
+			}
+		}
+	case "oauth2":
+		result["type"] = "oauth2"
+		flow := map[string]interface{}{
+			"scopes": map[string]interface{}{},
+		}
+		if scopes, ok := scheme["scopes"]; ok {
+			flow["scopes"] = scopes
+		}
+		if v, ok := scheme["authorizationUrl"]; ok {
+			flow["authorizationUrl"] = v
+		}
+		if v, ok := scheme["tokenUrl"]; ok {
+			flow["tokenUrl"] = v
+		}
+		name, _ := scheme["flow"].(string)
+		switch name {
+		case "accessCode":
+			name = "authorizationCode"
+		case "application":
+			name = "clientCredentials"
+		}
+		result["flows"] = map[string]interface{}{name: flow}
+	default:
+		for k, v := range scheme {
+			result[k] = v
+		}
+	}
+	return result
+}
+
+func convertPathItem(operations map[string]interface{}, consumes, produces []string, named map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(operations))
+	for method, operation := range operations {
+		if op, ok := operation.(map[string]interface{}); ok {
+			result[method] = convertOperation(op, consumes, produces, named)
+		} else {
+			result[method] = operation
+		}
+	}
+	return result
+}
+
+func convertOperation(op map[string]interface{}, consumes, produces []string, named map[string]interface{}) map[string]interface{} {
+	if v := stringList(op["consumes"]); len(v) > 0 {
+		consumes = v
+	}
+	if len(consumes) == 0 {
+		consumes = []string{"application/json"}
+	}
+	if v := stringList(op["produces"]); len(v) > 0 {
+		produces = v
+	}
+	if len(produces) == 0 {
+		produces = []string{"application/json"}
+	}
+
+	result := make(map[string]interface{}, len(op))
+	for k, v := range op {
+		switch k {
+		case "consumes", "produces", "parameters", "responses":
+		case "callbacks":
+			callbacks, _ := v.(map[string]interface{})
+			converted := make(map[string]interface{}, len(callbacks))
+			for name, item := range callbacks {
+				expressions, _ := item.(map[string]interface{})
+				callback := make(map[string]interface{}, len(expressions))
+				for expression, pathItem := range expressions {
+					if operations, ok := pathItem.(map[string]interface{}); ok {
+						callback[expression] = convertPathItem(operations, consumes, produces, named)
+					}
+				}
+				converted[name] = callback
+			}
+			result[k] = converted
+		default:
+			result[k] = v
+		}
+	}
+
+	params, _ := op["parameters"].([]interface{})
+	parameters := make([]interface{}, 0, len(params))
+	formProperties := make(map[string]interface{})
+	formRequired := make([]interface{}, 0)
+	encoding := make(map[string]interface{})
+	multipart := false
+	for _, item := range params {
+		param, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if ref, ok := param["$ref"].(string); ok {
+			target, _ := named[strings.TrimPrefix(ref, parametersPrefix)].(map[string]interface{})
+			if target == nil || (target["in"] != "body" && target["in"] != "formData") {
+				parameters = append(parameters, param)
+				continue
+			}
+			param = target
+		}
+		switch param["in"] {
+		case "body":
+			content := mediaTypes(consumes, param["schema"])
+			if example, ok := param["x-example"]; ok {
+				for _, v := range content {
+					v.(map[string]interface{})["example"] = example
+				}
+			}
+			body := map[string]interface{}{
+				"content": content,
+			}
+			if description, ok := param["description"]; ok {
+				body["description"] = description
+			}
+			if required, _ := param["required"].(bool); required {
+				body["required"] = true
+			}
+			result["requestBody"] = body
+		case "formData":
+			name, _ := param["name"].(string)
+			property := parameterSchema(param)
+			if description, ok := param["description"]; ok {
+				property["description"] = description
+			}
+			if property["format"] == "binary" {
+				multipart = true
+			}
+			if size, ok := param["x-max-size"]; ok {
+				property["x-max-size"] = size
+			}
+			if contentTypes := stringList(param["x-content-types"]); len(contentTypes) > 0 {
+				encoding[name] = map[string]interface{}{"contentType": strings.Join(contentTypes, ", ")}
+			}
+			formProperties[name] = property
+			if required, _ := param["required"].(bool); required {
+				formRequired = append(formRequired, name)
+			}
+		default:
+			parameters = append(parameters, convertParameter(param))
+		}
+	}
+	if len(parameters) > 0 {
+		result["parameters"] = parameters
+	}
+	if len(formProperties) > 0 {
+		schema := map[string]interface{}{
+			"type":       "object",
+			"properties": formProperties,
+		}
+		if len(formRequired) > 0 {
+			schema["required"] = formRequired
+		}
+		mediaType := "application/x-www-form-urlencoded"
+		if multipart || containsString(consumes, "multipart/form-data") {
+			mediaType = "multipart/form-data"
+		}
+		content := map[string]interface{}{"schema": schema}
+		if mediaType == "multipart/form-data" && len(encoding) > 0 {
+			content["encoding"] = encoding
+		}
+		result["requestBody"] = map[string]interface{}{
+			"content": map[string]interface{}{mediaType: content},
+		}
+	}
+
+	if responses, ok := op["responses"].(map[string]interface{}); ok {
+		converted := make(map[string]interface{}, len(responses))
+		for code, item := range responses {
+			response, ok := item.(map[string]interface{})
+			if !ok {
+				converted[code] = item
+				continue
+			}
+			converted[code] = convertResponse(response, produces)
+		}
+		result["responses"] = converted
+	}
+	return result
+}
+
+func convertParameter(param map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range param {
+		switch k {
+		case "type", "format", "items", "enum", "default", "collectionFormat",
+			"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf",
+			"minLength", "maxLength", "pattern", "minItems", "maxItems", "uniqueItems":
+		default:
+			result[k] = v
+		}
+	}
+	result["schema"] = parameterSchema(param)
+
+	switch param["collectionFormat"] {
+	case "csv":
+		result["style"] = "form"
+		result["explode"] = false
+		if param["in"] == "path" || param["in"] == "header" {
+			result["style"] = "simple"
+		}
+	case "multi":
+		result["style"] = "form"
+		result["explode"] = true
+	case "ssv":
+		result["style"] = "spaceDelimited"
+		result["explode"] = false
+	case "pipes":
+		result["style"] = "pipeDelimited"
+		result["explode"] = false
+	}
+	return result
+}
+
+// parameterSchema moves the type related fields of the swagger 2.0 parameter into a schema
+func parameterSchema(param map[string]interface{}) map[string]interface{} {
+	schema := make(map[string]interface{})
+	for _, k := range []string{"type", "format", "items", "enum", "default",
+		"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf",
+		"minLength", "maxLength", "pattern", "minItems", "maxItems", "uniqueItems"} {
+		if v, ok := param[k]; ok && v != "" {
+			schema[k] = v
+		}
+	}
+	if schema["type"] == "file" {
+		schema["type"] = "string"
+		schema["format"] = "binary"
+	}
+	return schema
+}
+
+func convertResponse(response map[string]interface{}, produces []string) map[string]interface{} {
+	if ref, ok := response["$ref"]; ok {
+		return map[string]interface{}{"$ref": ref}
+	}
+	result := make(map[string]interface{}, len(response))
+	for k, v := range response {
+		switch k {
+		case "schema", "headers", "examples":
+		default:
+			result[k] = v
+		}
+	}
+	if _, ok := result["description"]; !ok {
+		result["description"] = ""
+	}
+	if schema, ok := response["schema"]; ok {
+		content := mediaTypes(produces, schema)
+		if examples, ok := response["examples"].(map[string]interface{}); ok {
+			for mediaType, example := range examples {
+				if v, ok := content[mediaType].(map[string]interface{}); ok {
+					v["example"] = example
+				}
+			}
+		}
+		result["content"] = content
+	}
+	if headers, ok := response["headers"].(map[string]interface{}); ok && len(headers) > 0 {
+		converted := make(map[string]interface{}, len(headers))
+		for name, item := range headers {
+			header, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			v := map[string]interface{}{"schema": parameterSchema(header)}
+			if description, ok := header["description"]; ok {
+				v["description"] = description
+			}
+			if required, ok := header["x-required"]; ok {
+				v["required"] = required
+			}
+			converted[name] = v
+		}
+		result["headers"] = converted
+	}
+	return result
+}
+
+func mediaTypes(types []string, schema interface{}) map[string]interface{} {
+	content := make(map[string]interface{}, len(types))
+	for _, mediaType := range types {
+		content[mediaType] = map[string]interface{}{"schema": copyJSON(schema)}
+	}
+	return content
+}
+
+// renameRefs points the references to definitions, parameters and responses at their components
+func renameRefs(v interface{}) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok && strings.HasPrefix(ref, definitionPrefix) {
+			value["$ref"] = componentsPrefix + strings.TrimPrefix(ref, definitionPrefix)
+		} else if ok && strings.HasPrefix(ref, responsesPrefix) {
+			value["$ref"] = componentResponsesPrefix + strings.TrimPrefix(ref, responsesPrefix)
+		} else if ok && strings.HasPrefix(ref, parametersPrefix) {
+			value["$ref"] = componentParametersPrefix + strings.TrimPrefix(ref, parametersPrefix)
+		}
+		for _, item := range value {
+			renameRefs(item)
+		}
+	case []interface{}:
+		for _, item := range value {
+			renameRefs(item)
+		}
+	}
+}
+
+func stringList(v interface{}) []string {
+	list, _ := v.([]interface{})
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
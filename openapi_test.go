@@ -0,0 +1,415 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag/oas3"
+	"github.com/zc2638/swag/types"
+)
+
+func newOpenAPITestAPI() *API {
+	api := New()
+	api.Host = "api.example.com"
+	api.BasePath = "/v1"
+	api.Schemes = []string{"https"}
+	api.SecurityDefinitions = map[string]SecurityScheme{
+		"basic": {Type: "basic"},
+		"jwt":   BearerSecurity("jwt"),
+		"key":   {Type: "apiKey", Name: "X-Key", In: "header"},
+		"oauth": {
+			Type:             "oauth2",
+			Flow:             "accessCode",
+			AuthorizationURL: "https://auth.example.com/authorize",
+			TokenURL:         "https://auth.example.com/token",
+			Scopes:           map[string]string{"read": "read access"},
+		},
+	}
+	api.AddEndpoint(
+		&Endpoint{
+			Path:     "/homes/{id}",
+			Method:   http.MethodPut,
+			Produces: []string{"application/json", "application/xml"},
+			Parameters: []Parameter{
+				{In: "path", Name: "id", Type: types.Integer, Required: true},
+				{In: "body", Name: "body", Description: "the home", Required: true, Schema: MakeSchema(Home{})},
+			},
+			Responses: map[string]Response{
+				"200": {
+					Description: "ok",
+					Schema:      MakeSchema(Home{}),
+					Headers: map[string]Header{
+						"X-Rate-Limit":  {Type: types.Integer, Description: "the limit"},
+						"X-Rate-Policy": {Type: types.String, Enum: []string{"burst", "steady"}, Default: "steady", Required: true},
+					},
+				},
+			},
+		},
+		&Endpoint{
+			Path:   "/avatars",
+			Method: http.MethodPost,
+			Parameters: []Parameter{
+				{In: "formData", Name: "file", Type: "file", Required: true, MaxSize: 1024, ContentTypes: []string{"image/png", "image/jpeg"}},
+				{In: "formData", Name: "note", Type: types.String},
+			},
+			Responses: map[string]Response{"204": {Description: "uploaded"}},
+		},
+	)
+	return api
+}
+
+func TestAPI_ToOpenAPI3(t *testing.T) {
+	doc, err := newOpenAPITestAPI().ToOpenAPI3()
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(doc)
+	assert.NoError(t, err)
+
+	var v struct {
+		OpenAPI    string                   `json:"openapi"`
+		Swagger    string                   `json:"swagger"`
+		Servers    []map[string]string      `json:"servers"`
+		Components map[string]interface{}   `json:"components"`
+		Paths      map[string]interface{}   `json:"paths"`
+		Extra      []map[string]interface{} `json:"definitions"`
+	}
+	assert.NoError(t, json.Unmarshal(data, &v))
+	assert.Equal(t, OpenAPI3, v.OpenAPI)
+	assert.Empty(t, v.Swagger)
+	assert.Nil(t, v.Extra)
+	assert.Equal(t, []map[string]string{{"url": "https://api.example.com/v1"}}, v.Servers)
+	assert.Contains(t, v.Components["schemas"], DefinitionName(Home{}))
+
+	var put struct {
+		Parameters  []map[string]interface{} `json:"parameters"`
+		RequestBody struct {
+			Description string                            `json:"description"`
+			Required    bool                              `json:"required"`
+			Content     map[string]map[string]interface{} `json:"content"`
+		} `json:"requestBody"`
+		Responses map[string]struct {
+			Content map[string]map[string]interface{} `json:"content"`
+			Headers map[string]map[string]interface{} `json:"headers"`
+		} `json:"responses"`
+	}
+	operation, _ := json.Marshal(v.Paths["/homes/{id}"].(map[string]interface{})["put"])
+	assert.NoError(t, json.Unmarshal(operation, &put))
+	assert.Equal(t, []map[string]interface{}{
+		{"in": "path", "name": "id", "required": true, "schema": map[string]interface{}{"type": "integer"}},
+	}, put.Parameters)
+	assert.Equal(t, "the home", put.RequestBody.Description)
+	assert.True(t, put.RequestBody.Required)
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/" + DefinitionName(Home{})},
+		put.RequestBody.Content["application/json"]["schema"])
+	assert.Len(t, put.Responses["200"].Content, 2)
+	assert.Equal(t, map[string]interface{}{"type": "integer"}, put.Responses["200"].Headers["X-Rate-Limit"]["schema"])
+	assert.Equal(t, map[string]interface{}{
+		"schema":      map[string]interface{}{"type": "string", "enum": []interface{}{"burst", "steady"}, "default": "steady"},
+		"description": "",
+		"required":    true,
+	}, put.Responses["200"].Headers["X-Rate-Policy"])
+
+	upload := v.Paths["/avatars"].(map[string]interface{})["post"].(map[string]interface{})
+	content := upload["requestBody"].(map[string]interface{})["content"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"schema": map[string]interface{}{
+			"type": "object",
+			"properties": map[string]interface{}{
+				"file": map[string]interface{}{"type": "string", "format": "binary", "x-max-size": float64(1024)},
+				"note": map[string]interface{}{"type": "string"},
+			},
+			"required": []interface{}{"file"},
+		},
+		"encoding": map[string]interface{}{
+			"file": map[string]interface{}{"contentType": "image/png, image/jpeg"},
+		},
+	}, content["multipart/form-data"])
+	assert.NotContains(t, upload["responses"].(map[string]interface{})["204"], "content")
+
+	schemes := v.Components["securitySchemes"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "http", "scheme": "basic"}, schemes["basic"])
+	assert.Equal(t, map[string]interface{}{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"}, schemes["jwt"])
+	assert.Equal(t, map[string]interface{}{"type": "apiKey", "name": "X-Key", "in": "header"}, schemes["key"])
+	assert.Equal(t, map[string]interface{}{
+		"type": "oauth2",
+		"flows": map[string]interface{}{
+			"authorizationCode": map[string]interface{}{
+				"authorizationUrl": "https://auth.example.com/authorize",
+				"tokenUrl":         "https://auth.example.com/token",
+				"scopes":           map[string]interface{}{"read": "read access"},
+			},
+		},
+	}, schemes["oauth"])
+}
+
+func TestAPI_EncodeOpenAPI(t *testing.T) {
+	api := newOpenAPITestAPI()
+	api.OpenAPI = OpenAPI3
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	assert.Contains(t, buf.String(), `"openapi":"3.0.3"`)
+	assert.NotContains(t, buf.String(), `#/definitions/`)
+
+	// the swagger 2.0 rendering is left untouched
+	api.OpenAPI = ""
+	buf.Reset()
+	assert.NoError(t, api.Encode(&buf))
+	assert.Contains(t, buf.String(), `"swagger":"2.0"`)
+}
+
+type Release struct {
+	Kind    string  `json:"kind" const:"release"`
+	Version int     `json:"version" swag:"const=2"`
+	Notes   *string `json:"notes" nullable:"true" example:"first release"`
+	Size    int     `json:"size" example:"42"`
+	Home    *Home   `json:"home" swag:"nullable"`
+}
+
+func TestAPI_EncodeOpenAPI31(t *testing.T) {
+	api := New()
+	api.AddEndpoint(&Endpoint{
+		Path:   "/releases",
+		Method: http.MethodGet,
+		Responses: map[string]Response{
+			"200": {Description: "ok", Schema: MakeSchema(Release{})},
+		},
+	})
+
+	schemaOf := func(version string) map[string]interface{} {
+		api.OpenAPI = version
+		var buf bytes.Buffer
+		assert.NoError(t, api.Encode(&buf))
+		var doc struct {
+			Components struct {
+				Schemas map[string]map[string]interface{} `json:"schemas"`
+			} `json:"components"`
+		}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+		return doc.Components.Schemas[DefinitionName(Release{})]["properties"].(map[string]interface{})
+	}
+
+	properties := schemaOf(OpenAPI3)
+	assert.Equal(t, map[string]interface{}{
+		"type": "string", "nullable": true, "example": "first release",
+	}, properties["notes"])
+	assert.Equal(t, map[string]interface{}{
+		"type": "string", "enum": []interface{}{"release"}, "x-const": "release",
+	}, properties["kind"])
+
+	properties = schemaOf(OpenAPI31)
+	assert.Equal(t, map[string]interface{}{
+		"type": []interface{}{"string", "null"}, "examples": []interface{}{"first release"},
+	}, properties["notes"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "const": "release"}, properties["kind"])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "format": "int32", "const": float64(2)}, properties["version"])
+	assert.Equal(t, map[string]interface{}{"type": "integer", "format": "int32", "examples": []interface{}{float64(42)}}, properties["size"])
+	assert.Equal(t, map[string]interface{}{"anyOf": []interface{}{
+		map[string]interface{}{"$ref": "#/components/schemas/" + DefinitionName(Home{})},
+		map[string]interface{}{"type": "null"},
+	}}, properties["home"])
+}
+
+func TestAPI_OpenAPIDocument(t *testing.T) {
+	doc, err := newOpenAPITestAPI().OpenAPIDocument()
+	assert.NoError(t, err)
+	assert.Equal(t, OpenAPI3, doc.OpenAPI)
+	assert.Equal(t, "https://api.example.com/v1", doc.Servers[0].URL)
+	assert.Contains(t, doc.Components.Schemas, DefinitionName(Home{}))
+	assert.Equal(t, "http", doc.Components.SecuritySchemes["basic"].Type)
+
+	put := doc.Paths["/homes/{id}"].Operation(http.MethodPut)
+	assert.NotNil(t, put)
+	assert.Equal(t, "id", put.Parameters[0].Name)
+	assert.Equal(t, oas3.Types{"integer"}, put.Parameters[0].Schema.Type)
+	assert.True(t, put.RequestBody.Required)
+	assert.Equal(t, "#/components/schemas/"+DefinitionName(Home{}),
+		put.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "ok", put.Responses["200"].Description)
+}
+
+func TestAPI_OpenAPIHooks(t *testing.T) {
+	api := newOpenAPITestAPI()
+	api.OpenAPI = OpenAPI3
+	api.OpenAPIHooks = []OpenAPIHook{
+		func(doc *oas3.Document) error {
+			doc.Servers = append(doc.Servers, oas3.Server{URL: "https://staging.example.com/v1"})
+			doc.Paths["/avatars"].Post.Extensions = oas3.Extensions{"x-rate-limit": 10}
+			return nil
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	assert.Contains(t, buf.String(), `{"url":"https://staging.example.com/v1"}`)
+	assert.Contains(t, buf.String(), `"x-rate-limit":10`)
+
+	api.OpenAPIHooks = append(api.OpenAPIHooks, func(doc *oas3.Document) error {
+		return errors.New("rejected")
+	})
+	assert.EqualError(t, api.Encode(&buf), "rejected")
+}
+
+func TestAPI_EncodeOpenAPIParameterConstraints(t *testing.T) {
+	minimum, maximum := 0.0, 100.0
+	api := New()
+	api.AddEndpoint(&Endpoint{
+		Path:   "/pets",
+		Method: http.MethodGet,
+		Parameters: []Parameter{
+			{In: "query", Name: "limit", Type: types.Integer, Minimum: &minimum, ExclusiveMinimum: true, Maximum: &maximum},
+		},
+	})
+
+	schemaOf := func(version string) map[string]interface{} {
+		api.OpenAPI = version
+		var buf bytes.Buffer
+		assert.NoError(t, api.Encode(&buf))
+		var doc struct {
+			Paths map[string]map[string]struct {
+				Parameters []struct {
+					Schema map[string]interface{} `json:"schema"`
+				} `json:"parameters"`
+			} `json:"paths"`
+		}
+		assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+		return doc.Paths["/pets"]["get"].Parameters[0].Schema
+	}
+
+	assert.Equal(t, map[string]interface{}{
+		"type": "integer", "minimum": float64(0), "exclusiveMinimum": true, "maximum": float64(100),
+	}, schemaOf(OpenAPI3))
+	assert.Equal(t, map[string]interface{}{
+		"type": "integer", "exclusiveMinimum": float64(0), "maximum": float64(100),
+	}, schemaOf(OpenAPI31))
+}
+
+func TestAPI_EncodeOpenAPI31ExclusiveComponents(t *testing.T) {
+	zero, hundred := 0.0, 100.0
+	api := New()
+	api.OpenAPI = OpenAPI31
+	api.Parameters = map[string]Parameter{
+		"limit": {In: "query", Name: "limit", Type: "integer", Minimum: &zero, ExclusiveMinimum: true, Maximum: &hundred},
+	}
+	api.AddEndpoint(&Endpoint{
+		Path:   "/pets",
+		Method: http.MethodGet,
+		Parameters: []Parameter{
+			{Ref: "#/parameters/limit"},
+			{In: "query", Name: "offset", Type: "integer", ExclusiveMaximum: true},
+		},
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	var doc struct {
+		Components struct {
+			Parameters map[string]struct {
+				Schema map[string]interface{} `json:"schema"`
+			} `json:"parameters"`
+		} `json:"components"`
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Schema map[string]interface{} `json:"schema"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, map[string]interface{}{
+		"type": "integer", "exclusiveMinimum": float64(0), "maximum": float64(100),
+	}, doc.Components.Parameters["limit"].Schema)
+	assert.Equal(t, map[string]interface{}{"type": "integer"}, doc.Paths["/pets"]["get"].Parameters[1].Schema)
+}
+
+func TestAPI_EncodeOpenAPIBodyExample(t *testing.T) {
+	api := New()
+	api.OpenAPI = OpenAPI3
+	api.AddEndpoint(&Endpoint{
+		Path:     "/homes",
+		Method:   http.MethodPost,
+		Consumes: []string{"application/json"},
+		Parameters: []Parameter{
+			{In: "body", Name: "body", Schema: MakeSchema(Home{}), Example: map[string]interface{}{"id": "h1"}},
+		},
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	var doc struct {
+		Paths map[string]map[string]struct {
+			RequestBody struct {
+				Content map[string]map[string]interface{} `json:"content"`
+			} `json:"requestBody"`
+		} `json:"paths"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assert.Equal(t, map[string]interface{}{"id": "h1"},
+		doc.Paths["/homes"]["post"].RequestBody.Content["application/json"]["example"])
+}
+
+func TestAPI_EncodeOpenAPIResponses(t *testing.T) {
+	api := New()
+	api.OpenAPI = OpenAPI3
+	api.Produces = []string{"application/json"}
+	api.Responses = map[string]Response{
+		"NotFound": {Description: "not found", Schema: MakeSchema(Home{})},
+	}
+	api.AddEndpoint(&Endpoint{
+		Path:      "/homes/{id}",
+		Method:    http.MethodGet,
+		Responses: map[string]Response{"404": {Ref: "#/responses/NotFound"}},
+	})
+
+	doc, err := api.OpenAPIDocument()
+	assert.NoError(t, err)
+	assert.Equal(t, "#/components/responses/NotFound", doc.Paths["/homes/{id}"].Get.Responses["404"].Ref)
+	notFound := doc.Components.Responses["NotFound"]
+	assert.Equal(t, "not found", notFound.Description)
+	assert.Equal(t, componentsPrefix+DefinitionName(Home{}), notFound.Content["application/json"].Schema.Ref)
+}
+
+func TestAPI_EncodeOpenAPIParameterRefs(t *testing.T) {
+	api := New()
+	api.OpenAPI = OpenAPI3
+	api.Parameters = map[string]Parameter{
+		"page": {In: "query", Name: "page", Type: types.Integer},
+		"home": {In: "body", Name: "body", Schema: MakeSchema(Home{}), Required: true},
+	}
+	api.AddDefinitions(Home{})
+	api.AddEndpoint(&Endpoint{
+		Path:       "/homes",
+		Method:     http.MethodPost,
+		Consumes:   []string{"application/json"},
+		Parameters: []Parameter{{Ref: "#/parameters/page"}, {Ref: "#/parameters/home"}},
+	})
+
+	doc, err := api.OpenAPIDocument()
+	assert.NoError(t, err)
+	op := doc.Paths["/homes"].Post
+	if assert.Len(t, op.Parameters, 1) {
+		assert.Equal(t, "#/components/parameters/page", op.Parameters[0].Ref)
+	}
+	assert.True(t, op.RequestBody.Required)
+	assert.Equal(t, componentsPrefix+DefinitionName(Home{}), op.RequestBody.Content["application/json"].Schema.Ref)
+	assert.Equal(t, "integer", doc.Components.Parameters["page"].Schema.Type[0])
+	assert.NotContains(t, doc.Components.Parameters, "home")
+}
@@ -0,0 +1,201 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// OperationDoc represents the documentation of a single operation, taken from the rendered definition,
+// in which the references to the parameters, responses and schemas are resolved into copies of them;
+// a reference closing a cycle is kept, and its component is kept at the location of the definition,
+// so that the reference, e.g. #/definitions/Node, resolves against the OperationDoc itself
+type OperationDoc struct {
+	Method    string                 `json:"method"`
+	Path      string                 `json:"path"`
+	Operation map[string]interface{} `json:"operation"`
+	// Definitions, Parameters and Responses are the swagger 2.0 components of the kept references
+	Definitions map[string]interface{} `json:"definitions,omitempty"`
+	Parameters  map[string]interface{} `json:"parameters,omitempty"`
+	Responses   map[string]interface{} `json:"responses,omitempty"`
+	// Components are the OpenAPI 3 components of the kept references
+	Components map[string]interface{} `json:"components,omitempty"`
+}
+
+// Operation returns the documentation of the operation with the specified operationId as rendered by Encode,
+// i.e. filtered, scoped to the audience and converted to OpenAPI 3 if configured;
+// it returns false if the operation is not rendered or the definition fails to render
+func (a *API) Operation(operationID string) (*OperationDoc, bool) {
+	doc, err := a.operation(context.Background(), operationID)
+	return doc, err == nil && doc != nil
+}
+
+// operation returns the documentation of the operation with the specified operationId, or nil if it is not rendered
+func (a *API) operation(ctx context.Context, operationID string) (*OperationDoc, error) {
+	var buf bytes.Buffer
+	if err := a.EncodeContext(ctx, &buf); err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(&buf)
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	basePath, _ := doc["basePath"].(string)
+	if servers, ok := doc["servers"].([]interface{}); ok && len(servers) > 0 {
+		if server, ok := servers[0].(map[string]interface{}); ok {
+			if v, ok := server["url"].(string); ok {
+				basePath = serverPath(v)
+			}
+		}
+	}
+	paths, _ := doc["paths"].(map[string]interface{})
+	for _, rawPath := range sortedKeys(paths) {
+		item, _ := paths[rawPath].(map[string]interface{})
+		for _, method := range sortedKeys(item) {
+			op, ok := item[method].(map[string]interface{})
+			if !ok || op["operationId"] != operationID {
+				continue
+			}
+			components := make(map[string]interface{})
+			resolved, _ := resolveRefs(doc, op, make(map[string]bool), components).(map[string]interface{})
+			result := &OperationDoc{
+				Method:    strings.ToUpper(method),
+				Path:      path.Join("/", basePath, rawPath),
+				Operation: resolved,
+			}
+			result.Definitions, _ = components["definitions"].(map[string]interface{})
+			result.Parameters, _ = components["parameters"].(map[string]interface{})
+			result.Responses, _ = components["responses"].(map[string]interface{})
+			result.Components, _ = components["components"].(map[string]interface{})
+			return result, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveRefs returns a copy of v in which the references to the components of the document are replaced
+// by resolved copies of them; a reference closing a cycle is kept, and embedRefs copies its components into dst
+func resolveRefs(doc map[string]interface{}, v interface{}, visiting map[string]bool, dst map[string]interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok && strings.HasPrefix(ref, "#/") {
+			src := lookupRef(doc, ref)
+			switch {
+			case src == nil:
+			case visiting[ref]:
+				embedRefs(doc, value, dst)
+				return value
+			default:
+				visiting[ref] = true
+				defer delete(visiting, ref)
+				return resolveRefs(doc, src, visiting, dst)
+			}
+		}
+		m := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			m[k] = resolveRefs(doc, item, visiting, dst)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(value))
+		for i, item := range value {
+			list[i] = resolveRefs(doc, item, visiting, dst)
+		}
+		return list
+	}
+	return v
+}
+
+// refTokens returns the unescaped tokens of the local json pointer reference, e.g. definitions and Pet of #/definitions/Pet
+func refTokens(ref string) []string {
+	tokens := strings.Split(strings.TrimPrefix(ref, "#/"), "/")
+	for i, token := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+	return tokens
+}
+
+// lookupRef returns the value of the document the local reference points to, or nil if there is none
+func lookupRef(doc map[string]interface{}, ref string) interface{} {
+	var src interface{} = doc
+	for _, token := range refTokens(ref) {
+		m, _ := src.(map[string]interface{})
+		src = m[token]
+	}
+	return src
+}
+
+// embedRefs copies the components of the document referenced by v transitively into the same location of dst
+func embedRefs(doc map[string]interface{}, v interface{}, dst map[string]interface{}) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := value["$ref"].(string); ok && strings.HasPrefix(ref, "#/") {
+			tokens := refTokens(ref)
+			src := lookupRef(doc, ref)
+			target := dst
+			for _, token := range tokens[:len(tokens)-1] {
+				if src == nil {
+					break
+				}
+				next, ok := target[token].(map[string]interface{})
+				if !ok {
+					next = make(map[string]interface{})
+					target[token] = next
+				}
+				target = next
+			}
+			if _, ok := target[tokens[len(tokens)-1]]; src != nil && !ok {
+				target[tokens[len(tokens)-1]] = src
+				embedRefs(doc, src, dst)
+			}
+		}
+		for _, item := range value {
+			embedRefs(doc, item, dst)
+		}
+	case []interface{}:
+		for _, item := range value {
+			embedRefs(doc, item, dst)
+		}
+	}
+}
+
+// OperationHandler returns a http.HandlerFunc that serves the documentation of the operation
+// whose operationId is the last segment of the request path, e.g. /docs/operations/{operationId}
+func (a *API) OperationHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		operationID := path.Base(req.URL.Path)
+		doc, err := a.requestDoc(req).operation(req.Context(), operationID)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if doc == nil {
+			http.NotFound(w, req)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(doc)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Street struct {
+	Name string `json:"name"`
+}
+
+type Home struct {
+	Street Street `json:"street"`
+}
+
+func TestAPI_OperationHandler(t *testing.T) {
+	api := New()
+	api.BasePath = "/v1"
+	api.AddEndpoint(
+		&Endpoint{
+			Path:   "/homes/{id}",
+			Method: http.MethodGet,
+			Responses: map[string]Response{
+				"200": {Description: "ok", Schema: MakeSchema(Home{})},
+			},
+		},
+		&Endpoint{Path: "/users", Method: http.MethodGet},
+	)
+	mux := http.NewServeMux()
+	api.Handlers().Mount(mux, "/docs")
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/operations/getHomesId", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var doc OperationDoc
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &doc))
+	assert.Equal(t, "GET", doc.Method)
+	assert.Equal(t, "/v1/homes/{id}", doc.Path)
+	assert.Equal(t, "getHomesId", doc.Operation["operationId"])
+	// the schemas are resolved
+	assert.Empty(t, doc.Definitions)
+	schema := doc.Operation["responses"].(map[string]interface{})["200"].(map[string]interface{})["schema"].(map[string]interface{})
+	street := schema["properties"].(map[string]interface{})["street"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"type": "string"}, street["properties"].(map[string]interface{})["name"])
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/operations/unknown", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+type Ledger struct {
+	Name    string `json:"name"`
+	Balance int    `json:"balance" audience:"internal"`
+}
+
+func TestAPI_Operation(t *testing.T) {
+	visibility := NewVisibility()
+	api := New()
+	api.Filters = []Filter{visibility.Filter()}
+	api.GlobalResponses = map[string]Response{"500": {Description: "internal error"}}
+	api.Parameters = map[string]Parameter{"limit": {In: "query", Name: "limit", Type: "integer"}}
+	api.AddEndpoint(
+		&Endpoint{
+			Path:       "/accounts",
+			Method:     http.MethodGet,
+			Parameters: []Parameter{{Ref: "#/parameters/limit"}},
+			Responses: map[string]Response{
+				"200": {Description: "ok", Schema: MakeSchema(Ledger{})},
+			},
+		},
+		&Endpoint{Path: "/accounts", Method: http.MethodPost},
+	)
+
+	doc, ok := api.Operation("getAccounts")
+	assert.True(t, ok)
+	assert.Contains(t, doc.Operation["responses"], "500")
+	assert.Equal(t, []interface{}{map[string]interface{}{"in": "query", "name": "limit", "required": false, "type": "integer"}}, doc.Operation["parameters"])
+	assert.Empty(t, doc.Parameters)
+	account := doc.Operation["responses"].(map[string]interface{})["200"].(map[string]interface{})["schema"].(map[string]interface{})
+	assert.Contains(t, account["properties"], "name")
+	assert.NotContains(t, account["properties"], "balance")
+
+	visibility.HideEndpoint(http.MethodGet, "/accounts")
+	_, ok = api.Operation("getAccounts")
+	assert.False(t, ok)
+
+	_, ok = api.Operation("postAccounts")
+	assert.True(t, ok)
+
+	visibility.ShowEndpoint(http.MethodGet, "/accounts")
+	api.OpenAPI = "3.0.3"
+	doc, ok = api.Operation("getAccounts")
+	if assert.True(t, ok) {
+		assert.Equal(t, "/accounts", doc.Path)
+		assert.Nil(t, doc.Definitions)
+		assert.Nil(t, doc.Components)
+		data, err := json.Marshal(doc)
+		assert.NoError(t, err)
+		assert.False(t, strings.Contains(string(data), "$ref"))
+		assert.NotContains(t, string(data), `"balance"`)
+	}
+}
+
+func TestAPI_OperationRecursive(t *testing.T) {
+	api := New()
+	api.AddEndpoint(&Endpoint{
+		Path:   "/trees",
+		Method: http.MethodGet,
+		Responses: map[string]Response{
+			"200": {Description: "ok", Schema: MakeSchema(TreeNode{})},
+		},
+	})
+
+	doc, ok := api.Operation("getTrees")
+	assert.True(t, ok)
+	schema := doc.Operation["responses"].(map[string]interface{})["200"].(map[string]interface{})["schema"].(map[string]interface{})
+	properties := schema["properties"].(map[string]interface{})
+	// the reference closing the cycle is kept and resolves against the document
+	ref := makeRef(DefinitionName(TreeNode{}))
+	assert.Equal(t, map[string]interface{}{"$ref": ref}, properties["children"].(map[string]interface{})["items"])
+	assert.Len(t, doc.Definitions, 3)
+	assert.Contains(t, doc.Definitions, DefinitionName(TreeNode{}))
+	assert.Contains(t, doc.Definitions, DefinitionName(Street{}))
+	// the resolved schemas are copies
+	assert.Equal(t, "object", properties["home"].(map[string]interface{})["type"])
+}
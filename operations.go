@@ -0,0 +1,143 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"sort"
+	"strings"
+)
+
+// SortField represents the field used to sort the operations listing
+type SortField string
+
+const (
+	SortByPath        SortField = "path"
+	SortByMethod      SortField = "method"
+	SortByOperationID SortField = "operationId"
+	SortBySummary     SortField = "summary"
+)
+
+type listOptions struct {
+	sortBy  SortField
+	desc    bool
+	keyword string
+	tag     string
+	method  string
+	offset  int
+	limit   int
+}
+
+// ListOption provides configuration options to the operations listing
+type ListOption func(o *listOptions)
+
+// SortBy sorts the operations by the specified field; ties are broken by path and method
+func SortBy(field SortField, desc bool) ListOption {
+	return func(o *listOptions) {
+		o.sortBy = field
+		o.desc = desc
+	}
+}
+
+// FilterKeyword only keeps the operations whose operationId, path, summary or tags contain the keyword
+func FilterKeyword(keyword string) ListOption {
+	return func(o *listOptions) {
+		o.keyword = keyword
+	}
+}
+
+// FilterTag only keeps the operations with the specified tag
+func FilterTag(tag string) ListOption {
+	return func(o *listOptions) {
+		o.tag = tag
+	}
+}
+
+// FilterMethod only keeps the operations with the specified http method
+func FilterMethod(method string) ListOption {
+	return func(o *listOptions) {
+		o.method = strings.ToUpper(method)
+	}
+}
+
+// Paginate returns at most limit operations starting at offset; a limit of zero means no limit
+func Paginate(offset, limit int) ListOption {
+	return func(o *listOptions) {
+		o.offset = offset
+		o.limit = limit
+	}
+}
+
+// Operations returns the summaries of the operations defined in the swagger doc and of their versioned variants,
+// sorted by path, method and version unless specified otherwise
+func (a *API) Operations(opts ...ListOption) []OperationSummary {
+	o := &listOptions{sortBy: SortByPath}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	list := make([]OperationSummary, 0)
+	for _, s := range a.summaries() {
+		if o.keyword != "" && !s.match(o.keyword) {
+			continue
+		}
+		if o.method != "" && s.Method != o.method {
+			continue
+		}
+		if o.tag != "" && !containsString(s.Tags, o.tag) {
+			continue
+		}
+		list = append(list, s)
+	}
+
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := list[i].field(o.sortBy), list[j].field(o.sortBy)
+		if o.desc {
+			return a > b
+		}
+		return a < b
+	})
+
+	if o.offset > 0 {
+		if o.offset > len(list) {
+			o.offset = len(list)
+		}
+		list = list[o.offset:]
+	}
+	if o.limit > 0 && o.limit < len(list) {
+		list = list[:o.limit]
+	}
+	return list
+}
+
+func (s OperationSummary) field(f SortField) string {
+	switch f {
+	case SortByMethod:
+		return s.Method
+	case SortByOperationID:
+		return s.OperationID
+	case SortBySummary:
+		return s.Summary
+	}
+	return s.Path
+}
+
+func containsString(list []string, v string) bool {
+	for _, item := range list {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPI_Operations(t *testing.T) {
+	api := New()
+	api.WithTag("billing", "").AddEndpoint(
+		&Endpoint{Path: "/invoices", Method: http.MethodGet, Summary: "List invoices"},
+		&Endpoint{Path: "/invoices", Method: http.MethodPost, Summary: "Create invoice"},
+	)
+	api.AddEndpoint(
+		&Endpoint{Path: "/users", Method: http.MethodGet, Summary: "A list of users"},
+		&Endpoint{Path: "/users/{id}", Method: http.MethodDelete, Summary: "Delete user", Deprecated: true},
+	)
+
+	ids := func(list []OperationSummary) []string {
+		result := make([]string, 0, len(list))
+		for _, v := range list {
+			result = append(result, v.OperationID)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name string
+		opts []ListOption
+		want []string
+	}{
+		{name: "default", want: []string{"getInvoices", "postInvoices", "getUsers", "deleteUsersId"}},
+		{name: "sort by summary", opts: []ListOption{SortBy(SortBySummary, false)}, want: []string{"getUsers", "postInvoices", "deleteUsersId", "getInvoices"}},
+		{name: "sort by method desc", opts: []ListOption{SortBy(SortByMethod, true)}, want: []string{"postInvoices", "getInvoices", "getUsers", "deleteUsersId"}},
+		{name: "tag", opts: []ListOption{FilterTag("billing")}, want: []string{"getInvoices", "postInvoices"}},
+		{name: "method", opts: []ListOption{FilterMethod("get")}, want: []string{"getInvoices", "getUsers"}},
+		{name: "keyword", opts: []ListOption{FilterKeyword("user")}, want: []string{"getUsers", "deleteUsersId"}},
+		{name: "page", opts: []ListOption{Paginate(1, 2)}, want: []string{"postInvoices", "getUsers"}},
+		{name: "page overflow", opts: []ListOption{Paginate(10, 2)}, want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ids(api.Operations(tt.opts...)))
+		})
+	}
+
+	list := api.Operations(FilterMethod(http.MethodDelete))
+	assert.Equal(t, []OperationSummary{{
+		OperationID: "deleteUsersId",
+		Method:      http.MethodDelete,
+		Path:        "/users/{id}",
+		Summary:     "Delete user",
+		Deprecated:  true,
+	}}, list)
+}
+
+func TestAPI_OperationsVersions(t *testing.T) {
+	api := New()
+	api.Versioning = &Versioning{Header: "Accept", Versions: []string{"1", "2"}}
+	api.AddEndpoint(
+		&Endpoint{Path: "/pets", Method: http.MethodGet, Summary: "v1"},
+		&Endpoint{Path: "/pets", Method: http.MethodGet, Summary: "v2", Versions: []string{"2"}},
+	)
+
+	list := api.Operations()
+	assert.Equal(t, []OperationSummary{
+		{OperationID: "getPets", Method: http.MethodGet, Path: "/pets", Summary: "v1"},
+		{OperationID: "getPets", Method: http.MethodGet, Path: "/pets", Summary: "v2", Version: "2"},
+	}, list)
+}
@@ -0,0 +1,166 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sort"
+)
+
+// OpsStatus is the body of the health and readiness endpoints of StandardOps
+type OpsStatus struct {
+	// Status is ok, or unavailable when a readiness check fails
+	Status string `json:"status" enum:"ok,unavailable"`
+	// Checks maps the readiness checks to ok or their error
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// OpsVersion is the body of the version endpoint of StandardOps
+type OpsVersion struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	GoVersion string `json:"goVersion"`
+}
+
+// OpsOption configures the endpoints returned by StandardOps
+type OpsOption func(c *opsConfig)
+
+type opsConfig struct {
+	tag     string
+	checks  map[string]func(ctx context.Context) error
+	version OpsVersion
+}
+
+// ReadinessCheck adds a check of the readiness endpoint, e.g. pinging the database;
+// the service is ready when all the checks succeed
+func ReadinessCheck(name string, check func(ctx context.Context) error) OpsOption {
+	return func(c *opsConfig) {
+		c.checks[name] = check
+	}
+}
+
+// OpsVersionInfo sets the version reported by the version endpoint;
+// defaults to the version of the main module read from the build info
+func OpsVersionInfo(version, commit string) OpsOption {
+	return func(c *opsConfig) {
+		c.version.Version = version
+		c.version.Commit = commit
+	}
+}
+
+// OpsTag sets the tag of the endpoints; defaults to ops
+func OpsTag(tag string) OpsOption {
+	return func(c *opsConfig) {
+		c.tag = tag
+	}
+}
+
+// StandardOps returns the documented operational endpoints of a service, with their http.HandlerFunc:
+// GET /healthz reporting that the service is alive, GET /readyz running the readiness checks,
+// and GET /version; they require no security, e.g.
+//
+//	api.AddEndpoint(swag.StandardOps(swag.ReadinessCheck("db", db.PingContext))...)
+func StandardOps(opts ...OpsOption) []*Endpoint {
+	c := opsConfig{
+		tag:     "ops",
+		checks:  make(map[string]func(ctx context.Context) error),
+		version: OpsVersion{Version: "unknown", GoVersion: runtime.Version()},
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		c.version.Version = info.Main.Version
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	status := MakeSchema(OpsStatus{})
+	return []*Endpoint{
+		{
+			Method:    http.MethodGet,
+			Path:      "/healthz",
+			Summary:   "Report that the service is alive",
+			Tags:      []string{c.tag},
+			Produces:  []string{"application/json"},
+			Handler:   http.HandlerFunc(c.health),
+			Responses: map[string]Response{"200": {Description: "the service is alive", Schema: status}},
+			Security:  &SecurityRequirement{DisableSecurity: true},
+		},
+		{
+			Method:   http.MethodGet,
+			Path:     "/readyz",
+			Summary:  "Report whether the service is ready to serve requests",
+			Tags:     []string{c.tag},
+			Produces: []string{"application/json"},
+			Handler:  http.HandlerFunc(c.ready),
+			Responses: map[string]Response{
+				"200": {Description: "the service is ready", Schema: status},
+				"503": {Description: "a readiness check failed", Schema: status},
+			},
+			Security: &SecurityRequirement{DisableSecurity: true},
+		},
+		{
+			Method:    http.MethodGet,
+			Path:      "/version",
+			Summary:   "Report the version of the service",
+			Tags:      []string{c.tag},
+			Produces:  []string{"application/json"},
+			Handler:   http.HandlerFunc(c.versionHandler),
+			Responses: map[string]Response{"200": {Description: "the version", Schema: MakeSchema(OpsVersion{})}},
+			Security:  &SecurityRequirement{DisableSecurity: true},
+		},
+	}
+}
+
+func (c *opsConfig) health(w http.ResponseWriter, _ *http.Request) {
+	writeOpsJSON(w, http.StatusOK, OpsStatus{Status: "ok"})
+}
+
+func (c *opsConfig) ready(w http.ResponseWriter, req *http.Request) {
+	names := make([]string, 0, len(c.checks))
+	for name := range c.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := OpsStatus{Status: "ok"}
+	code := http.StatusOK
+	for _, name := range names {
+		if result.Checks == nil {
+			result.Checks = make(map[string]string, len(names))
+		}
+		if err := c.checks[name](req.Context()); err != nil {
+			result.Checks[name] = err.Error()
+			result.Status = "unavailable"
+			code = http.StatusServiceUnavailable
+			continue
+		}
+		result.Checks[name] = "ok"
+	}
+	writeOpsJSON(w, code, result)
+}
+
+func (c *opsConfig) versionHandler(w http.ResponseWriter, _ *http.Request) {
+	writeOpsJSON(w, http.StatusOK, c.version)
+}
+
+func writeOpsJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
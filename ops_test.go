@@ -0,0 +1,75 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandardOps(t *testing.T) {
+	ready := true
+	api := New()
+	api.SecurityDefinitions = map[string]SecurityScheme{"api_key": {Type: "apiKey", Name: "X-Key", In: "header"}}
+	api.Security = &SecurityRequirement{Requirements: []map[string][]string{{"api_key": {}}}}
+	api.AddEndpoint(StandardOps(
+		ReadinessCheck("db", func(ctx context.Context) error {
+			if !ready {
+				return errors.New("connection refused")
+			}
+			return nil
+		}),
+		OpsVersionInfo("v1.2.3", "abc123"),
+	)...)
+
+	mux := http.NewServeMux()
+	api.Walk(func(path string, e *Endpoint) {
+		mux.Handle(path, e.Handler.(http.Handler))
+	})
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	w := get("/healthz")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+
+	w = get("/readyz")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok","checks":{"db":"ok"}}`, w.Body.String())
+
+	ready = false
+	w = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.JSONEq(t, `{"status":"unavailable","checks":{"db":"connection refused"}}`, w.Body.String())
+
+	w = get("/version")
+	assert.Contains(t, w.Body.String(), `"version":"v1.2.3","commit":"abc123"`)
+
+	readyz := api.Paths["/readyz"].Get
+	assert.Equal(t, []string{"ops"}, readyz.Tags)
+	assert.Contains(t, readyz.Responses, "503")
+	assert.Nil(t, api.EffectiveSecurity(readyz))
+	assert.Contains(t, api.Definitions, DefinitionName(OpsStatus{}))
+	assert.Equal(t, []string{"ok", "unavailable"}, api.Definitions[DefinitionName(OpsStatus{})].Properties["status"].Enum)
+	assert.NoError(t, api.Validate())
+}
@@ -15,6 +15,10 @@
 package option
 
 import (
+	"net/http"
+	"strconv"
+	"strings"
+
 	"github.com/zc2638/swag"
 )
 
@@ -25,6 +29,20 @@ func Description(v string) swag.Option {
 	}
 }
 
+// DescriptionSection appends a markdown section headed by the title to info.description,
+// replacing the placeholder of swag.New, so that the guides of the overview, e.g. authentication,
+// pagination and errors, are maintained apart
+func DescriptionSection(title, markdown string) swag.Option {
+	return func(api *swag.API) {
+		section := "## " + title + "\n\n" + strings.TrimSpace(markdown)
+		if api.Info.Description == "" || api.Info.Description == swag.DefaultDescription {
+			api.Info.Description = section
+			return
+		}
+		api.Info.Description = strings.TrimRight(api.Info.Description, "\n") + "\n\n" + section
+	}
+}
+
 // Version sets info.version
 func Version(v string) swag.Option {
 	return func(api *swag.API) {
@@ -56,6 +74,13 @@ func ContactEmail(v string) swag.Option {
 	}
 }
 
+// Contact sets info.contact.name, info.contact.url and info.contact.email
+func Contact(name, url, email string) swag.Option {
+	return func(api *swag.API) {
+		api.Info.Contact = &swag.Contact{Name: name, URL: url, Email: email}
+	}
+}
+
 // License sets both info.license.name and info.license.url
 func License(name, url string) swag.Option {
 	return func(api *swag.API) {
@@ -85,6 +110,211 @@ func Host(v string) swag.Option {
 	}
 }
 
+// ServeOrigin decides the host, basePath and schemes of the definition served for a request,
+// e.g. swag.ConfiguredOrigin to keep the ones set with Host, BasePath and Schemes
+func ServeOrigin(fn func(req *http.Request) swag.Origin) swag.Option {
+	return func(api *swag.API) {
+		api.ServeOrigin = fn
+	}
+}
+
+// Renderer selects the renderer of the docs page of the handlers, e.g. swag.RendererRapiDoc
+func Renderer(r swag.Renderer) swag.Option {
+	return func(api *swag.API) {
+		api.Renderer = r
+	}
+}
+
+// Consumes sets the global consumes
+func Consumes(v ...string) swag.Option {
+	return func(api *swag.API) {
+		api.Consumes = v
+	}
+}
+
+// Produces sets the global produces
+func Produces(v ...string) swag.Option {
+	return func(api *swag.API) {
+		api.Produces = v
+	}
+}
+
+// Compact omits per-operation produces/consumes matching the global defaults
+// and empty arrays/maps from the rendered definition; without global defaults,
+// the media types shared by most operations are rendered as the defaults
+func Compact() swag.Option {
+	return func(api *swag.API) {
+		api.Compact = true
+	}
+}
+
+// Indent pretty-prints the rendered definition using the specified indentation
+func Indent(v string) swag.Option {
+	return func(api *swag.API) {
+		api.Render.Indent = v
+	}
+}
+
+// DisableHTMLEscape keeps &, < and > unescaped in the rendered definition
+func DisableHTMLEscape() swag.Option {
+	return func(api *swag.API) {
+		api.Render.DisableHTMLEscape = true
+	}
+}
+
+// TypedEnums encodes the enum values of integer, number and boolean fields with their json type
+func TypedEnums() swag.Option {
+	return func(api *swag.API) {
+		api.Render.TypedEnums = true
+	}
+}
+
+// InlineSingleUse inlines the definitions referenced exactly once in the rendered definition
+func InlineSingleUse() swag.Option {
+	return func(api *swag.API) {
+		api.Render.InlineSingleUse = true
+	}
+}
+
+// OmitEmpty omits null values, empty strings and empty arrays/maps from the rendered definition
+func OmitEmpty() swag.Option {
+	return func(api *swag.API) {
+		api.Render.OmitEmpty = true
+	}
+}
+
+// Overlay applies the overlay documents to the rendered definition
+func Overlay(overlays ...*swag.Overlay) swag.Option {
+	return func(api *swag.API) {
+		api.Overlays = append(api.Overlays, overlays...)
+	}
+}
+
+// Filter omits the endpoints rejected by any of the filters from the rendered definition
+func Filter(filters ...swag.Filter) swag.Option {
+	return func(api *swag.API) {
+		api.Filters = append(api.Filters, filters...)
+	}
+}
+
+// Visibility allows endpoints and tags to be hidden from the rendered definition at runtime
+func Visibility(v *swag.Visibility) swag.Option {
+	return Filter(v.Filter())
+}
+
+// HeaderVersioning declares the header carrying the api version on all endpoints;
+// operations added with endpoint.Version are rendered in the document of the matching version
+func HeaderVersioning(header string, versions ...string) swag.Option {
+	return func(api *swag.API) {
+		if api.Versioning == nil {
+			api.Versioning = &swag.Versioning{}
+		}
+		api.Versioning.Header = header
+		api.Versioning.Versions = versions
+	}
+}
+
+// MethodNotAllowed documents a 405 response listing the methods allowed on the path on every operation
+func MethodNotAllowed() swag.Option {
+	return func(api *swag.API) {
+		api.MethodNotAllowed = true
+	}
+}
+
+// EnvVars whitelists the environment variables replacing their ${NAME} placeholders in the host,
+// the base path and the info when the definition is rendered, e.g. Host("${API_HOST}")
+func EnvVars(names ...string) swag.Option {
+	return func(api *swag.API) {
+		api.EnvVars = append(api.EnvVars, names...)
+	}
+}
+
+// Extension sets a vendor extension of the root of the definition; the key is prefixed with x- if needed
+func Extension(key string, value interface{}) swag.Option {
+	return func(api *swag.API) {
+		if api.Extensions == nil {
+			api.Extensions = make(map[string]interface{})
+		}
+		api.Extensions[swag.ExtensionKey(key)] = value
+	}
+}
+
+// InfoExtension sets a vendor extension of info, e.g. x-logo; the key is prefixed with x- if needed
+func InfoExtension(key string, value interface{}) swag.Option {
+	return func(api *swag.API) {
+		if api.Info.Extensions == nil {
+			api.Info.Extensions = make(map[string]interface{})
+		}
+		api.Info.Extensions[swag.ExtensionKey(key)] = value
+	}
+}
+
+// Build sets info.x-build to the build info of the binary with the commit and the build time,
+// served by the build handler of swag.Handlers too
+func Build(commit, time string) swag.Option {
+	return InfoExtension(swag.BuildExtension, swag.ReadBuildInfo(commit, time))
+}
+
+// GlobalResponses documents the responses on every operation, unless the operation already defines
+// a response for the code; the definitions of their schemas are added to the api
+func GlobalResponses(responses map[int]swag.Response) swag.Option {
+	return func(api *swag.API) {
+		if api.GlobalResponses == nil {
+			api.GlobalResponses = make(map[string]swag.Response, len(responses))
+		}
+		for code, r := range responses {
+			api.GlobalResponses[strconv.Itoa(code)] = r
+			if r.Schema != nil && r.Schema.Prototype != nil {
+				api.AddDefinitions(r.Schema.Prototype)
+			}
+		}
+	}
+}
+
+// ParameterDefinition adds the parameter to the parameters section of the api under the name,
+// to be referenced by the endpoints with endpoint.ParamRef
+func ParameterDefinition(name string, p swag.Parameter) swag.Option {
+	return func(api *swag.API) {
+		if api.Parameters == nil {
+			api.Parameters = make(map[string]swag.Parameter)
+		}
+		api.Parameters[name] = p
+		if p.Schema != nil && p.Schema.Prototype != nil {
+			api.AddDefinitions(p.Schema.Prototype)
+		}
+	}
+}
+
+// ResponseDefinition adds the response to the responses section of the api under the name,
+// to be referenced by the endpoints with endpoint.ResponseRef
+func ResponseDefinition(name string, response swag.Response) swag.Option {
+	return func(api *swag.API) {
+		if api.Responses == nil {
+			api.Responses = make(map[string]swag.Response)
+		}
+		api.Responses[name] = response
+		if response.Schema != nil && response.Schema.Prototype != nil {
+			api.AddDefinitions(response.Schema.Prototype)
+		}
+	}
+}
+
+// Envelope wraps the response bodies of the operations and of the responses section in the field of the envelope model,
+// e.g. data; the responses of the envelope type itself, e.g. the errors, are left as is, see swag.Envelope
+func Envelope(prototype interface{}, field string) swag.Option {
+	return func(api *swag.API) {
+		api.Envelope = &swag.Envelope{Prototype: prototype, Field: field}
+	}
+}
+
+// GlobalResponsesOptIn documents the global responses only on the operations using them,
+// see endpoint.UseGlobalResponses
+func GlobalResponsesOptIn() swag.Option {
+	return func(api *swag.API) {
+		api.GlobalResponsesOptIn = true
+	}
+}
+
 // Endpoints allows the endpoints to be added dynamically to the Api
 func Endpoints(endpoints ...*swag.Endpoint) swag.Option {
 	return func(api *swag.API) {
@@ -92,7 +322,61 @@ func Endpoints(endpoints ...*swag.Endpoint) swag.Option {
 	}
 }
 
-// Security sets a default security scheme for all endpoints in the API.
+// Definitions adds the definitions of the prototypes even if no endpoint references them
+func Definitions(prototypes ...interface{}) swag.Option {
+	return func(api *swag.API) {
+		api.AddDefinitions(prototypes...)
+	}
+}
+
+// InternalDefinitions leaves the definitions of the prototypes out of the rendered definition,
+// and renders the references to them according to the mode
+func InternalDefinitions(mode swag.DefinitionMode, prototypes ...interface{}) swag.Option {
+	return func(api *swag.API) {
+		if api.InternalDefinitions == nil {
+			api.InternalDefinitions = make(map[string]swag.DefinitionMode)
+		}
+		for _, prototype := range prototypes {
+			api.InternalDefinitions[swag.DefinitionName(prototype)] = mode
+		}
+	}
+}
+
+// Audience selects the audience the properties are rendered for; see swag.API.Audience
+func Audience(audience string) swag.Option {
+	return func(api *swag.API) {
+		api.Audience = audience
+	}
+}
+
+// InferTags tags the endpoints added afterwards without any tag after the first static segment of their path
+func InferTags() swag.Option {
+	return func(api *swag.API) {
+		api.InferTags = true
+	}
+}
+
+// OpenAPIVersion renders an OpenAPI 3 document of the version, e.g. 3.0.3, instead of swagger 2.0
+func OpenAPIVersion(version string) swag.Option {
+	return func(api *swag.API) {
+		api.OpenAPI = version
+	}
+}
+
+// OpenAPIHooks appends the hooks mutating the typed OpenAPI 3 document before it is encoded
+func OpenAPIHooks(hooks ...swag.OpenAPIHook) swag.Option {
+	return func(api *swag.API) {
+		api.OpenAPIHooks = append(api.OpenAPIHooks, hooks...)
+	}
+}
+
+// GlobalSecurity is the same as Security.
+func GlobalSecurity(scheme string, scopes ...string) swag.Option {
+	return Security(scheme, scopes...)
+}
+
+// Security sets a default security scheme for all endpoints in the API;
+// an endpoint overrides it with endpoint.Security, or opts out with endpoint.NoSecurity.
 func Security(scheme string, scopes ...string) swag.Option {
 	return func(api *swag.API) {
 		if api.Security == nil {
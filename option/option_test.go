@@ -20,6 +20,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/oas3"
 )
 
 func TestDescription(t *testing.T) {
@@ -29,6 +30,18 @@ func TestDescription(t *testing.T) {
 	assert.Equal(t, "zc", api.Info.Description)
 }
 
+func TestDescriptionSection(t *testing.T) {
+	api := swag.New(
+		Description("The pet store.\n"),
+		DescriptionSection("Authentication", "Send a bearer token.\n"),
+		DescriptionSection("Pagination", "Use `page` and `size`."),
+	)
+	assert.Equal(t, "The pet store.\n\n## Authentication\n\nSend a bearer token.\n\n## Pagination\n\nUse `page` and `size`.", api.Info.Description)
+
+	api = swag.New(DescriptionSection("Errors", "Errors are json."))
+	assert.Equal(t, "## Errors\n\nErrors are json.", api.Info.Description)
+}
+
 func TestVersion(t *testing.T) {
 	api := swag.New(
 		Version("zc"),
@@ -57,6 +70,13 @@ func TestContactEmail(t *testing.T) {
 	assert.Equal(t, "zc", api.Info.Contact.Email)
 }
 
+func TestContact(t *testing.T) {
+	api := swag.New(
+		Contact("API Team", "https://example.com/support", "api@example.com"),
+	)
+	assert.Equal(t, &swag.Contact{Name: "API Team", URL: "https://example.com/support", Email: "api@example.com"}, api.Info.Contact)
+}
+
 func TestLicense(t *testing.T) {
 	api := swag.New(
 		License("name", "url"),
@@ -86,6 +106,122 @@ func TestHost(t *testing.T) {
 	assert.Equal(t, "zc", api.Host)
 }
 
+func TestConsumesProduces(t *testing.T) {
+	api := swag.New(
+		Consumes("application/json"),
+		Produces("application/json", "application/xml"),
+	)
+	assert.Equal(t, []string{"application/json"}, api.Consumes)
+	assert.Equal(t, []string{"application/json", "application/xml"}, api.Produces)
+}
+
+func TestCompact(t *testing.T) {
+	api := swag.New(
+		Compact(),
+	)
+	assert.True(t, api.Compact)
+}
+
+func TestRender(t *testing.T) {
+	api := swag.New(
+		Indent("  "),
+		DisableHTMLEscape(),
+		OmitEmpty(),
+		TypedEnums(),
+		InlineSingleUse(),
+	)
+	assert.Equal(t, swag.RenderOptions{
+		Indent:            "  ",
+		DisableHTMLEscape: true,
+		OmitEmpty:         true,
+		TypedEnums:        true,
+		InlineSingleUse:   true,
+	}, api.Render)
+}
+
+func TestOverlay(t *testing.T) {
+	o := &swag.Overlay{Overlay: "1.0.0"}
+	api := swag.New(
+		Overlay(o),
+	)
+	assert.Equal(t, []*swag.Overlay{o}, api.Overlays)
+}
+
+func TestEnvVars(t *testing.T) {
+	api := swag.New(
+		EnvVars("API_HOST"),
+		EnvVars("API_VERSION"),
+	)
+	assert.Equal(t, []string{"API_HOST", "API_VERSION"}, api.EnvVars)
+}
+
+func TestExtension(t *testing.T) {
+	api := swag.New(
+		Extension("x-tagGroups", []string{"pets"}),
+		InfoExtension("logo", map[string]string{"url": "logo.png"}),
+	)
+	assert.Equal(t, []string{"pets"}, api.Extensions["x-tagGroups"])
+	assert.Equal(t, map[string]string{"url": "logo.png"}, api.Info.Extensions["x-logo"])
+}
+
+func TestGlobalResponses(t *testing.T) {
+	type Problem struct {
+		Title string `json:"title"`
+	}
+	api := swag.New(
+		GlobalResponses(map[int]swag.Response{
+			401: {Description: "unauthorized"},
+			500: {Description: "internal error", Schema: swag.MakeSchema(Problem{})},
+		}),
+		GlobalResponsesOptIn(),
+	)
+	assert.Len(t, api.GlobalResponses, 2)
+	assert.Equal(t, "unauthorized", api.GlobalResponses["401"].Description)
+	assert.Contains(t, api.Definitions, swag.DefinitionName(Problem{}))
+	assert.True(t, api.GlobalResponsesOptIn)
+}
+
+func TestParameterDefinition(t *testing.T) {
+	api := swag.New(
+		ParameterDefinition("page", swag.Parameter{In: "query", Name: "page", Type: "integer"}),
+	)
+	assert.Equal(t, "page", api.Parameters["page"].Name)
+}
+
+func TestResponseDefinition(t *testing.T) {
+	type Problem struct {
+		Title string `json:"title"`
+	}
+	api := swag.New(
+		ResponseDefinition("NotFound", swag.Response{Description: "not found", Schema: swag.MakeSchema(Problem{})}),
+	)
+	assert.Equal(t, "not found", api.Responses["NotFound"].Description)
+	assert.Contains(t, api.Definitions, swag.DefinitionName(Problem{}))
+}
+
+func TestFilter(t *testing.T) {
+	api := swag.New(
+		Filter(func(string, *swag.Endpoint) bool { return true }),
+		Visibility(swag.NewVisibility()),
+	)
+	assert.Len(t, api.Filters, 2)
+}
+
+func TestHeaderVersioning(t *testing.T) {
+	api := swag.New(
+		HeaderVersioning("Accept", "1", "2"),
+	)
+	assert.Equal(t, "Accept", api.Versioning.Header)
+	assert.Equal(t, []string{"1", "2"}, api.Versioning.Versions)
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	api := swag.New(
+		MethodNotAllowed(),
+	)
+	assert.True(t, api.MethodNotAllowed)
+}
+
 func TestSecurity(t *testing.T) {
 	api := swag.New(
 		Security("basic"),
@@ -93,3 +229,97 @@ func TestSecurity(t *testing.T) {
 	assert.Len(t, api.Security.Requirements, 1)
 	assert.Contains(t, api.Security.Requirements[0], "basic")
 }
+
+func TestGlobalSecurity(t *testing.T) {
+	api := swag.New(
+		GlobalSecurity("oauth", "read"),
+	)
+	assert.Equal(t, []map[string][]string{{"oauth": {"read"}}}, api.Security.Requirements)
+}
+
+func TestDefinitions(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Customer struct {
+		Address Address `json:"address"`
+	}
+	api := swag.New(
+		Definitions(Customer{}),
+	)
+	assert.Len(t, api.Definitions, 2)
+}
+
+func TestInternalDefinitions(t *testing.T) {
+	type Envelope struct{}
+	api := swag.New(
+		InternalDefinitions(swag.OpaqueDefinition, &Envelope{}),
+	)
+	assert.Equal(t, map[string]swag.DefinitionMode{
+		swag.DefinitionName(Envelope{}): swag.OpaqueDefinition,
+	}, api.InternalDefinitions)
+}
+
+func TestAudience(t *testing.T) {
+	api := swag.New(
+		Audience("internal"),
+	)
+	assert.Equal(t, "internal", api.Audience)
+}
+
+func TestInferTags(t *testing.T) {
+	api := swag.New(
+		InferTags(),
+		Endpoints(&swag.Endpoint{Method: "GET", Path: "/billing/invoices"}),
+	)
+	assert.Equal(t, []string{"billing"}, api.Paths["/billing/invoices"].Get.Tags)
+}
+
+func TestOpenAPIVersion(t *testing.T) {
+	api := swag.New(
+		OpenAPIVersion(swag.OpenAPI3),
+	)
+	assert.Equal(t, "3.0.3", api.OpenAPI)
+}
+
+func TestOpenAPIHooks(t *testing.T) {
+	hook := func(doc *oas3.Document) error { return nil }
+	api := swag.New(
+		OpenAPIHooks(hook),
+	)
+	assert.Len(t, api.OpenAPIHooks, 1)
+}
+
+func TestServeOrigin(t *testing.T) {
+	api := swag.New(
+		ServeOrigin(swag.ConfiguredOrigin),
+	)
+	assert.NotNil(t, api.ServeOrigin)
+}
+
+func TestRenderer(t *testing.T) {
+	api := swag.New(
+		Renderer(swag.RendererRapiDoc),
+	)
+	assert.NotNil(t, api.Renderer)
+}
+
+func TestBuild(t *testing.T) {
+	api := swag.New(
+		Build("0a1b2c3", "2022-06-01T00:00:00Z"),
+	)
+	b, ok := api.Build()
+	assert.True(t, ok)
+	assert.Equal(t, "0a1b2c3", b.Commit)
+	assert.Equal(t, "2022-06-01T00:00:00Z", b.Time)
+}
+
+func TestEnvelope(t *testing.T) {
+	type Reply struct {
+		Code int `json:"code"`
+	}
+	api := swag.New(
+		Envelope(Reply{}, "result"),
+	)
+	assert.Equal(t, &swag.Envelope{Prototype: Reply{}, Field: "result"}, api.Envelope)
+}
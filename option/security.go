@@ -66,6 +66,18 @@ func APIKeySecurity(name, in string) SecuritySchemeOption {
 	}
 }
 
+// BearerSecurity defines a security scheme for bearer tokens of the format, e.g. JWT,
+// sent in the Authorization header.
+func BearerSecurity(format string) SecuritySchemeOption {
+	return func(scheme *swag.SecurityScheme) {
+		scheme.Type = "apiKey"
+		scheme.Name = "Authorization"
+		scheme.In = "header"
+		scheme.Scheme = "bearer"
+		scheme.BearerFormat = format
+	}
+}
+
 // OAuth2Scope adds a new scope to the security scheme.
 func OAuth2Scope(scope, description string) SecuritySchemeOption {
 	return func(scheme *swag.SecurityScheme) {
@@ -77,7 +89,8 @@ func OAuth2Scope(scope, description string) SecuritySchemeOption {
 }
 
 // OAuth2Security defines a security scheme for OAuth2 authentication. Flow can
-// be one of implicit, password, application, or accessCode.
+// be one of implicit, password, application, or accessCode; swag.API.Validate reports
+// the other flows.
 func OAuth2Security(flow, authorizationURL, tokenURL string) SecuritySchemeOption {
 	return func(scheme *swag.SecurityScheme) {
 		scheme.Type = "oauth2"
@@ -15,6 +15,7 @@
 package option
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -40,6 +41,18 @@ func TestSecuritySchemeDescription(t *testing.T) {
 	assert.Equal(t, description, scheme.Description)
 }
 
+func TestBearerSecurity(t *testing.T) {
+	scheme := &swag.SecurityScheme{}
+	SecuritySchemeDescription("a jwt")(scheme)
+	BearerSecurity("JWT")(scheme)
+	assert.Equal(t, "apiKey", scheme.Type)
+	assert.Equal(t, "Authorization", scheme.Name)
+	assert.Equal(t, "header", scheme.In)
+	assert.Equal(t, "bearer", scheme.Scheme)
+	assert.Equal(t, "JWT", scheme.BearerFormat)
+	assert.Equal(t, "a jwt", scheme.Description)
+}
+
 func TestBasicSecurity(t *testing.T) {
 	scheme := &swag.SecurityScheme{}
 	BasicSecurity()(scheme)
@@ -72,6 +85,10 @@ func TestOAuth2Security(t *testing.T) {
 	assert.Equal(t, scheme.Flow, "accessCode")
 	assert.Equal(t, scheme.AuthorizationURL, authURL)
 	assert.Equal(t, scheme.TokenURL, tokenURL)
+
+	api := swag.New(SecurityScheme("oauth", OAuth2Security("code", authURL, tokenURL)))
+	assert.Equal(t, "code", api.SecurityDefinitions["oauth"].Flow)
+	assert.True(t, errors.Is(api.Validate(), swag.ErrInvalidSecurity))
 }
 
 func TestOAuth2Scope(t *testing.T) {
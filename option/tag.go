@@ -18,7 +18,8 @@ import (
 	"github.com/zc2638/swag"
 )
 
-// Tag adds a tag to the swagger api
+// Tag adds a tag to the swagger api, e.g. Tag("users", "User management", TagURL(docURL));
+// it replaces the tag of the same name already added, e.g. by api.WithTag or the inferred tags
 func Tag(name, description string, options ...TagOption) swag.Option {
 	return func(api *swag.API) {
 		t := swag.Tag{
@@ -28,6 +29,12 @@ func Tag(name, description string, options ...TagOption) swag.Option {
 		for _, opt := range options {
 			opt(&t)
 		}
+		for i := range api.Tags {
+			if api.Tags[i].Name == name {
+				api.Tags[i] = t
+				return
+			}
+		}
 		api.Tags = append(api.Tags, t)
 	}
 }
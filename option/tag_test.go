@@ -41,3 +41,13 @@ func TestTag(t *testing.T) {
 	}
 	assert.Equal(t, expected, api.Tags[0])
 }
+
+func TestTagReplace(t *testing.T) {
+	api := swag.New()
+	api.WithTag("users", "").AddEndpoint(&swag.Endpoint{Method: "GET", Path: "/users"})
+	api.AddOptions(Tag("users", "User management", TagURL("https://docs.example.com/users")))
+
+	assert.Len(t, api.Tags, 1)
+	assert.Equal(t, "User management", api.Tags[0].Description)
+	assert.Equal(t, "https://docs.example.com/users", api.Tags[0].Docs.URL)
+}
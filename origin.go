@@ -0,0 +1,213 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Origin is the host, basePath and schemes of the definition served for a request;
+// the empty fields keep the configured values
+type Origin struct {
+	Host     string
+	BasePath string
+	Schemes  []string
+	// Prefix prefixes the basePath, e.g. the path a proxy serves the api under
+	Prefix string
+}
+
+// RequestOrigin derives the host and the scheme from the request, honoring X-Forwarded-Proto;
+// it is the origin of the served definition unless the api sets ServeOrigin
+func RequestOrigin(req *http.Request) Origin {
+	scheme := ""
+	if req.TLS != nil {
+		scheme = "https"
+	}
+	if v := req.Header.Get("X-Forwarded-Proto"); v != "" {
+		scheme = v
+	}
+	if scheme == "" {
+		scheme = req.URL.Scheme
+	}
+	if scheme == "" {
+		scheme = "http"
+	}
+	return Origin{Host: req.Host, Schemes: []string{scheme}}
+}
+
+// ForwardedOrigin is RequestOrigin behind a proxy: the host is read from X-Forwarded-Host,
+// and the basePath is prefixed by X-Forwarded-Prefix
+func ForwardedOrigin(req *http.Request) Origin {
+	origin := RequestOrigin(req)
+	if v := req.Header.Get("X-Forwarded-Host"); v != "" {
+		// the proxies append the hosts they forward from
+		origin.Host = strings.TrimSpace(strings.Split(v, ",")[0])
+	}
+	if v := req.Header.Get("X-Forwarded-Prefix"); v != "" {
+		origin.Prefix = v
+	}
+	return origin
+}
+
+// ConfiguredOrigin keeps the configured host, basePath and schemes whatever the request
+func ConfiguredOrigin(*http.Request) Origin {
+	return Origin{}
+}
+
+// apply replaces the host, basePath and schemes of the definition with the non empty fields of the origin
+func (o Origin) apply(doc *API) {
+	if o.Host != "" {
+		doc.Host = o.Host
+	}
+	doc.BasePath = o.basePath(doc.BasePath)
+	if len(o.Schemes) > 0 {
+		doc.Schemes = o.Schemes
+	}
+}
+
+func (o Origin) basePath(basePath string) string {
+	if o.BasePath != "" {
+		basePath = o.BasePath
+	}
+	if o.Prefix != "" {
+		basePath = path.Join("/", o.Prefix, basePath)
+	}
+	return basePath
+}
+
+// RewriteOrigin rewrites the host, basePath and schemes of the definition served by next according to origin,
+// e.g. ForwardedOrigin, for the definitions not served by the handlers of the api, like a static file;
+// the OpenAPI 3 definitions get a single server.
+// The responses which are not a json definition are passed through.
+// next is asked for an uncompressed response, which is compressed once rewritten if the client accepts it
+func RewriteOrigin(next http.Handler, origin func(req *http.Request) Origin) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		bw := &bufferedWriter{header: make(http.Header), code: http.StatusOK}
+		plain := req.Clone(req.Context())
+		plain.Header.Del("Accept-Encoding")
+		next.ServeHTTP(bw, plain)
+
+		body := bw.buf.Bytes()
+		length := bw.header.Get("Content-Length") != ""
+		var doc map[string]interface{}
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if bw.code == http.StatusOK && decoder.Decode(&doc) == nil {
+			if data, ok := rewriteOrigin(doc, origin(req)); ok {
+				body = data
+				bw.header.Del("Content-Length")
+				bw.header.Del("ETag")
+			}
+		}
+
+		for k, v := range bw.header {
+			w.Header()[k] = v
+		}
+		var out io.Writer = w
+		if bw.code == http.StatusOK && bw.header.Get("Content-Encoding") == "" {
+			var done func()
+			out, done = compressWriter(w, req)
+			defer done()
+		}
+		switch {
+		case w.Header().Get("Content-Encoding") != bw.header.Get("Content-Encoding"):
+			// the length of the compressed body is unknown until it is written
+			w.Header().Del("Content-Length")
+		case length:
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		}
+		w.WriteHeader(bw.code)
+		_, _ = out.Write(body)
+	})
+}
+
+// rewriteOrigin applies the origin to the swagger 2.0 or OpenAPI 3 definition, and returns its encoding
+func rewriteOrigin(doc map[string]interface{}, o Origin) ([]byte, bool) {
+	switch {
+	case doc["swagger"] != nil:
+		if o.Host != "" {
+			doc["host"] = o.Host
+		}
+		basePath, _ := doc["basePath"].(string)
+		if basePath = o.basePath(basePath); basePath != "" {
+			doc["basePath"] = basePath
+		}
+		if len(o.Schemes) > 0 {
+			doc["schemes"] = o.Schemes
+		}
+	case doc["openapi"] != nil:
+		if o.Host == "" {
+			return nil, false
+		}
+		scheme := "https"
+		if len(o.Schemes) > 0 {
+			scheme = o.Schemes[0]
+		}
+		basePath := ""
+		if servers, ok := doc["servers"].([]interface{}); ok && len(servers) > 0 {
+			if server, ok := servers[0].(map[string]interface{}); ok {
+				if v, ok := server["url"].(string); ok {
+					basePath = serverPath(v)
+				}
+			}
+		}
+		basePath = o.basePath(basePath)
+		doc["servers"] = []interface{}{map[string]interface{}{"url": scheme + "://" + o.Host + strings.TrimSuffix(basePath, "/")}}
+	default:
+		return nil, false
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// serverPath returns the path of the server url, e.g. /v1 of https://api.example.com/v1
+func serverPath(u string) string {
+	if i := strings.Index(u, "://"); i >= 0 {
+		u = u[i+3:]
+		if j := strings.Index(u, "/"); j >= 0 {
+			return u[j:]
+		}
+		return ""
+	}
+	return u
+}
+
+// bufferedWriter holds the response of a handler until it is rewritten
+type bufferedWriter struct {
+	header http.Header
+	buf    bytes.Buffer
+	code   int
+}
+
+func (w *bufferedWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedWriter) Write(p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	w.code = code
+}
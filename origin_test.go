@@ -0,0 +1,140 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPI_ServeOrigin(t *testing.T) {
+	api := New()
+	api.Host = "api.example.com"
+	api.BasePath = "/v1"
+	api.Schemes = []string{"https"}
+
+	serve := func() *API {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+		r.Host = "localhost:8080"
+		api.Handler().ServeHTTP(w, r)
+		doc := &API{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), doc))
+		return doc
+	}
+
+	doc := serve()
+	assert.Equal(t, "localhost:8080", doc.Host)
+	assert.Equal(t, "/v1", doc.BasePath)
+	assert.Equal(t, []string{"http"}, doc.Schemes)
+
+	api.ServeOrigin = ConfiguredOrigin
+	doc = serve()
+	assert.Equal(t, "api.example.com", doc.Host)
+	assert.Equal(t, []string{"https"}, doc.Schemes)
+
+	api.ServeOrigin = func(req *http.Request) Origin {
+		return Origin{Host: "gateway.example.com", BasePath: "/pets/v1"}
+	}
+	doc = serve()
+	assert.Equal(t, "gateway.example.com", doc.Host)
+	assert.Equal(t, "/pets/v1", doc.BasePath)
+	assert.Equal(t, []string{"https"}, doc.Schemes)
+	// the configured values are untouched
+	assert.Equal(t, "api.example.com", api.Host)
+	assert.Equal(t, "/v1", api.BasePath)
+}
+
+func TestForwardedOrigin(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+	r.Host = "pets:8080"
+	assert.Equal(t, Origin{Host: "pets:8080", Schemes: []string{"http"}}, ForwardedOrigin(r))
+
+	r.Header.Set("X-Forwarded-Host", "api.example.com, ingress.local")
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Prefix", "/pets")
+	assert.Equal(t, Origin{Host: "api.example.com", Schemes: []string{"https"}, Prefix: "/pets"}, ForwardedOrigin(r))
+
+	api := New()
+	api.BasePath = "/v1"
+	api.ServeOrigin = ForwardedOrigin
+	w := httptest.NewRecorder()
+	api.Handler().ServeHTTP(w, r)
+	doc := &API{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), doc))
+	assert.Equal(t, "api.example.com", doc.Host)
+	assert.Equal(t, "/pets/v1", doc.BasePath)
+	assert.Equal(t, []string{"https"}, doc.Schemes)
+}
+
+func TestRewriteOrigin(t *testing.T) {
+	static := func(body string) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			_, _ = w.Write([]byte(body))
+		})
+	}
+	serve := func(h http.Handler) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+		r.Host = "pets:8080"
+		r.Header.Set("X-Forwarded-Host", "api.example.com")
+		r.Header.Set("X-Forwarded-Proto", "https")
+		r.Header.Set("X-Forwarded-Prefix", "/pets")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := serve(RewriteOrigin(static(`{"swagger":"2.0","host":"localhost","basePath":"/v1","paths":{}}`), ForwardedOrigin))
+	assert.JSONEq(t, `{"swagger":"2.0","host":"api.example.com","basePath":"/pets/v1","schemes":["https"],"paths":{}}`, w.Body.String())
+	assert.Equal(t, strconv.Itoa(w.Body.Len()), w.Header().Get("Content-Length"))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	w = serve(RewriteOrigin(static(`{"openapi":"3.0.3","servers":[{"url":"http://localhost/v1"}],"paths":{}}`), ForwardedOrigin))
+	assert.JSONEq(t, `{"openapi":"3.0.3","servers":[{"url":"https://api.example.com/pets/v1"}],"paths":{}}`, w.Body.String())
+
+	w = serve(RewriteOrigin(static(`not json`), ForwardedOrigin))
+	assert.Equal(t, "not json", w.Body.String())
+}
+
+func TestRewriteOrigin_Compressed(t *testing.T) {
+	api := New()
+	api.Host = "internal:8080"
+	api.ServeOrigin = ConfiguredOrigin
+
+	r := httptest.NewRequest(http.MethodGet, "/swagger.json", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	r.Header.Set("X-Forwarded-Host", "api.example.com")
+	r.Header.Set("X-Forwarded-Proto", "https")
+	w := httptest.NewRecorder()
+	RewriteOrigin(api.Handler(), ForwardedOrigin).ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Empty(t, w.Header().Get("Content-Length"))
+	zr, err := gzip.NewReader(w.Body)
+	assert.NoError(t, err)
+	doc := &API{}
+	assert.NoError(t, json.NewDecoder(zr).Decode(doc))
+	assert.Equal(t, "api.example.com", doc.Host)
+	assert.Equal(t, []string{"https"}, doc.Schemes)
+}
@@ -0,0 +1,160 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// OverlayInfo represents the info entity from the overlay document
+type OverlayInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// OverlayAction represents an action from the overlay document;
+// the update value is merged into every node matched by target, or the nodes are removed if remove is true
+type OverlayAction struct {
+	Target      string      `json:"target"`
+	Description string      `json:"description,omitempty"`
+	Update      interface{} `json:"update,omitempty"`
+	Remove      bool        `json:"remove,omitempty"`
+}
+
+// Overlay represents an OpenAPI Overlay 1.0 document
+type Overlay struct {
+	Overlay string          `json:"overlay"`
+	Info    OverlayInfo     `json:"info"`
+	Extends string          `json:"extends,omitempty"`
+	Actions []OverlayAction `json:"actions"`
+}
+
+// ParseOverlay parses the json encoded overlay document
+func ParseOverlay(data []byte) (*Overlay, error) {
+	var o Overlay
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, err
+	}
+	for _, action := range o.Actions {
+		if _, err := parseJSONPath(action.Target); err != nil {
+			return nil, err
+		}
+	}
+	return &o, nil
+}
+
+// removedNode marks array elements deleted by an overlay action until the array is rebuilt
+type removedNode struct{}
+
+// Apply applies the overlay actions in order to the decoded json document
+func (o *Overlay) Apply(doc interface{}) error {
+	for _, action := range o.Actions {
+		nodes, err := queryJSONPath(doc, action.Target)
+		if err != nil {
+			return err
+		}
+		for _, node := range nodes {
+			if action.Remove {
+				if m, ok := node.parent.(map[string]interface{}); ok {
+					delete(m, node.key.(string))
+					continue
+				}
+				node.set(removedNode{})
+				continue
+			}
+			if node.parent == nil && !isContainer(node.value) {
+				return fmt.Errorf("overlay target %s: root must be an object or an array", action.Target)
+			}
+			node.set(mergeJSON(node.value, copyJSON(action.Update)))
+		}
+		doc = compactRemoved(doc)
+	}
+	return nil
+}
+
+func isContainer(v interface{}) bool {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		return true
+	}
+	return false
+}
+
+// mergeJSON recursively merges the update into the target objects,
+// appends it to the target arrays and replaces any other value
+func mergeJSON(target, update interface{}) interface{} {
+	switch t := target.(type) {
+	case map[string]interface{}:
+		u, ok := update.(map[string]interface{})
+		if !ok {
+			return update
+		}
+		for k, v := range u {
+			if current, exists := t[k]; exists {
+				if _, isMap := current.(map[string]interface{}); isMap {
+					t[k] = mergeJSON(current, v)
+					continue
+				}
+			}
+			t[k] = v
+		}
+		return t
+	case []interface{}:
+		if u, ok := update.([]interface{}); ok {
+			return append(t, u...)
+		}
+		return append(t, update)
+	}
+	return update
+}
+
+func copyJSON(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(value))
+		for k, item := range value {
+			m[k] = copyJSON(item)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			list = append(list, copyJSON(item))
+		}
+		return list
+	}
+	return v
+}
+
+// compactRemoved drops the array elements marked as removed
+func compactRemoved(v interface{}) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			value[k] = compactRemoved(item)
+		}
+	case []interface{}:
+		list := make([]interface{}, 0, len(value))
+		for _, item := range value {
+			if _, ok := item.(removedNode); ok {
+				continue
+			}
+			list = append(list, compactRemoved(item))
+		}
+		return list
+	}
+	return v
+}
@@ -0,0 +1,116 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testOverlay = `{
+  "overlay": "1.0.0",
+  "info": {"title": "docs patch", "version": "1.0.0"},
+  "actions": [
+    {"target": "$.info", "update": {"description": "Patched description"}},
+    {"target": "$.paths['/pets'].get", "update": {"summary": "List all pets"}},
+    {"target": "$.paths.*.*.parameters[?(@.name == 'debug')]", "remove": true},
+    {"target": "$..tags", "update": "docs"}
+  ]
+}`
+
+func TestParseOverlay(t *testing.T) {
+	o, err := ParseOverlay([]byte(testOverlay))
+	assert.Nil(t, err)
+	assert.Equal(t, "1.0.0", o.Overlay)
+	assert.Equal(t, "docs patch", o.Info.Title)
+	assert.Len(t, o.Actions, 4)
+
+	_, err = ParseOverlay([]byte(`{"actions": [{"target": "info"}]}`))
+	assert.NotNil(t, err)
+}
+
+func TestAPI_EncodeOverlay(t *testing.T) {
+	o, err := ParseOverlay([]byte(testOverlay))
+	assert.Nil(t, err)
+
+	api := New()
+	api.Overlays = []*Overlay{o}
+	api.AddEndpoint(&Endpoint{
+		Path:   "/pets",
+		Method: http.MethodGet,
+		Tags:   []string{"pets"},
+		Parameters: []Parameter{
+			{In: "query", Name: "limit"},
+			{In: "query", Name: "debug"},
+		},
+	})
+
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		assert.Nil(t, api.Encode(&buf))
+
+		var doc struct {
+			Info  Info                  `json:"info"`
+			Paths map[string]*Endpoints `json:"paths"`
+		}
+		assert.Nil(t, json.Unmarshal(buf.Bytes(), &doc))
+		assert.Equal(t, "Patched description", doc.Info.Description)
+		assert.Equal(t, "Your API Title", doc.Info.Title)
+
+		get := doc.Paths["/pets"].Get
+		assert.Equal(t, "List all pets", get.Summary)
+		assert.Equal(t, []Parameter{{In: "query", Name: "limit"}}, get.Parameters)
+		assert.Equal(t, []string{"pets", "docs"}, get.Tags)
+	}
+	assert.Len(t, api.Paths["/pets"].Get.Parameters, 2)
+}
+
+func Test_queryJSONPath(t *testing.T) {
+	var doc interface{}
+	_ = json.Unmarshal([]byte(`{"a": {"b": [1, 2, {"c": "x"}]}, "d": {"c": "y"}}`), &doc)
+
+	tests := []struct {
+		expr string
+		want []interface{}
+	}{
+		{expr: "$.a.b[0]", want: []interface{}{float64(1)}},
+		{expr: "$.a.b[-1].c", want: []interface{}{"x"}},
+		{expr: "$['d']['c']", want: []interface{}{"y"}},
+		{expr: "$..c", want: []interface{}{"x", "y"}},
+		{expr: "$.a.b[?(@.c == 'x')].c", want: []interface{}{"x"}},
+		{expr: "$.*.c", want: []interface{}{"y"}},
+		{expr: "$.missing", want: []interface{}{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			nodes, err := queryJSONPath(doc, tt.expr)
+			assert.Nil(t, err)
+			values := make([]interface{}, 0, len(nodes))
+			for _, node := range nodes {
+				values = append(values, node.value)
+			}
+			assert.Equal(t, tt.want, values)
+		})
+	}
+
+	for _, expr := range []string{"a.b", "$.a[", "$.a[x]", "$."} {
+		_, err := queryJSONPath(doc, expr)
+		assert.NotNil(t, err, expr)
+	}
+}
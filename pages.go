@@ -0,0 +1,169 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"html"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/zc2638/swag/asserts"
+)
+
+// PagesHandler serves the documentation split by tag, for the apis too large for a single swagger ui page:
+// an index of the tags at /, the swagger ui of each tag at /{tag}/, and the spec of each tag at /{tag}.json;
+// the operations without tags are left out of the pages
+func (a *API) PagesHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		p := strings.TrimPrefix(req.URL.Path, "/")
+		tags := a.pageTags(req)
+		if p == "" {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write([]byte(a.pagesIndex(tags)))
+			return
+		}
+
+		name := p
+		if i := strings.Index(p, "/"); i >= 0 {
+			name = p[:i]
+		}
+		if strings.HasSuffix(name, ".json") && name == p {
+			tag := strings.TrimSuffix(name, ".json")
+			if !containsString(tags, tag) {
+				http.NotFound(w, req)
+				return
+			}
+			var buf bytes.Buffer
+			if err := a.requestDoc(req).RenderTag(tag).EncodeContext(req.Context(), &buf); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			_, _ = buf.WriteTo(w)
+			return
+		}
+		if !containsString(tags, name) {
+			http.NotFound(w, req)
+			return
+		}
+		if name == p {
+			// relative to the mount point, which http.Redirect cannot see behind http.StripPrefix
+			w.Header().Set("Location", url.PathEscape(name)+"/")
+			w.WriteHeader(http.StatusFound)
+			return
+		}
+		ui := uiFiles("../"+url.PathEscape(name)+".json", uiConfig{})
+		http.StripPrefix("/"+name, ui).ServeHTTP(w, req)
+	})
+}
+
+// LazyUIHandler serves the swagger ui files by their path without the mount prefix, with an index page
+// listing the spec of each tag, e.g. at prefix + "users.json" as served by PagesHandler;
+// the ui only fetches the spec of the tag selected in its top bar
+func (a *API) LazyUIHandler(prefix string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" && r.URL.Path != "index.html" {
+			serveAsset(w, r)
+			return
+		}
+		fileData, err := asserts.Dist.ReadFile(path.Join(asserts.DistDir, "index.html"))
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("index.html read exception"))
+			return
+		}
+
+		type specURL struct {
+			URL  string `json:"url"`
+			Name string `json:"name"`
+		}
+		tags := a.pageTags(r)
+		urls := make([]specURL, 0, len(tags))
+		for _, tag := range tags {
+			urls = append(urls, specURL{URL: prefix + url.PathEscape(tag) + ".json", Name: tag})
+		}
+		config, err := json.Marshal(urls)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fileData = bytes.ReplaceAll(fileData, []byte(`url: "`+asserts.URL+`"`), append([]byte("urls: "), config...))
+		_, _ = w.Write(fileData)
+	})
+}
+
+// pageTags returns the tags of the operations of the definition rendered for the request,
+// without those the filters hide, in the order of the tags section, then by name
+func (a *API) pageTags(req *http.Request) []string {
+	doc := a.requestDoc(req).rendered()
+	used := make(map[string]bool)
+	doc.Walk(func(_ string, e *Endpoint) {
+		for _, tag := range e.Tags {
+			used[tag] = true
+		}
+	})
+
+	tags := make([]string, 0, len(used))
+	for _, tag := range doc.Tags {
+		if used[tag.Name] {
+			tags = append(tags, tag.Name)
+			delete(used, tag.Name)
+		}
+	}
+	rest := make([]string, 0, len(used))
+	for tag := range used {
+		rest = append(rest, tag)
+	}
+	sort.Strings(rest)
+	return append(tags, rest...)
+}
+
+func (a *API) pagesIndex(tags []string) string {
+	descriptions := make(map[string]string, len(a.Tags))
+	for _, tag := range a.Tags {
+		descriptions[tag.Name] = tag.Description
+	}
+
+	title := a.Info.Title
+	if title == "" {
+		title = "API Reference"
+	}
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n  <title>")
+	b.WriteString(html.EscapeString(title))
+	b.WriteString("</title>\n  <meta charset=\"utf-8\"/>\n</head>\n<body>\n  <h1>")
+	b.WriteString(html.EscapeString(title))
+	b.WriteString("</h1>\n  <ul>\n")
+	for _, tag := range tags {
+		b.WriteString(`    <li><a href="`)
+		b.WriteString(html.EscapeString(url.PathEscape(tag)))
+		b.WriteString(`/">`)
+		b.WriteString(html.EscapeString(tag))
+		b.WriteString("</a>")
+		if d := descriptions[tag]; d != "" {
+			b.WriteString(" - ")
+			b.WriteString(html.EscapeString(d))
+		}
+		b.WriteString("</li>\n")
+	}
+	b.WriteString("  </ul>\n</body>\n</html>\n")
+	return b.String()
+}
@@ -0,0 +1,136 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPI_PagesHandler(t *testing.T) {
+	api := New()
+	api.AddTag("users", "user <accounts>")
+	api.AddEndpoint(
+		&Endpoint{Path: "/users", Method: http.MethodGet, Tags: []string{"users"}},
+		&Endpoint{Path: "/pets", Method: http.MethodGet, Tags: []string{"pets"}},
+		&Endpoint{Path: "/health", Method: http.MethodGet},
+	)
+
+	mux := http.NewServeMux()
+	api.Handlers().Mount(mux, "/docs")
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	w := get("/docs/pages/")
+	assert.Equal(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Contains(t, body, `<li><a href="users/">users</a> - user &lt;accounts&gt;</li>`)
+	assert.Contains(t, body, `<li><a href="pets/">pets</a></li>`)
+	// the tags section comes first
+	assert.Less(t, strings.Index(body, "users/"), strings.Index(body, "pets/"))
+
+	w = get("/docs/pages/pets")
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "pets/", w.Header().Get("Location"))
+
+	w = get("/docs/pages/pets/")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `url: "../pets.json"`)
+
+	w = get("/docs/pages/pets/swagger-ui.css")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = get("/docs/pages/pets.json")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"/pets"`)
+	assert.NotContains(t, w.Body.String(), `"/users"`)
+	assert.NotContains(t, w.Body.String(), `"/health"`)
+
+	assert.Equal(t, http.StatusNotFound, get("/docs/pages/unknown.json").Code)
+	assert.Equal(t, http.StatusNotFound, get("/docs/pages/unknown/").Code)
+
+	w = get("/docs/lazy-ui/")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `urls: [{"url":"../pages/users.json","name":"users"},{"url":"../pages/pets.json","name":"pets"}]`)
+	assert.NotContains(t, w.Body.String(), "petstore")
+
+	w = get("/docs/lazy-ui/swagger-ui.css")
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAPI_PagesHandlerFiltered(t *testing.T) {
+	api := New()
+	api.AddTag("users", "")
+	api.AddEndpoint(
+		&Endpoint{Path: "/users", Method: http.MethodGet, Tags: []string{"users"}},
+		&Endpoint{Path: "/admin", Method: http.MethodGet, Tags: []string{"admin"}},
+	)
+	visibility := NewVisibility()
+	visibility.HideTag("admin")
+	api.Filters = append(api.Filters, visibility.Filter())
+
+	h := api.PagesHandler()
+	get := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+		return w
+	}
+
+	w := get("/")
+	assert.Contains(t, w.Body.String(), `users/`)
+	assert.NotContains(t, w.Body.String(), `admin`)
+	assert.Equal(t, http.StatusNotFound, get("/admin.json").Code)
+	assert.Equal(t, http.StatusNotFound, get("/admin/").Code)
+
+	// the spec is rendered before answering, so that its errors are answered with 500
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/users.json", nil).WithContext(ctx))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestAPI_LazyUIHandlerFiltered(t *testing.T) {
+	api := New()
+	api.AddEndpoint(
+		&Endpoint{Path: "/users", Method: http.MethodGet, Tags: []string{"users"}},
+		&Endpoint{Path: "/admin", Method: http.MethodGet, Tags: []string{"admin"}},
+	)
+	visibility := NewVisibility()
+	visibility.HideTag("admin")
+	api.Filters = append(api.Filters, visibility.Filter())
+
+	h := api.LazyUIHandler("/pages/")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Contains(t, w.Body.String(), `urls: [{"url":"/pages/users.json","name":"users"}]`)
+
+	// the assets are served from their precompressed copies
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/swagger-ui.css", nil)
+	r.Header.Set("Accept-Encoding", "gzip")
+	h.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, "text/css; charset=utf-8", w.Header().Get("Content-Type"))
+}
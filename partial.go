@@ -0,0 +1,111 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"strings"
+)
+
+// RenderPath returns a mini swagger definition that only contains the specified path
+// and the definitions it references transitively
+func (a *API) RenderPath(p string) *API {
+	return a.subset(func(rawPath string, _ *Endpoint) bool {
+		return rawPath == p
+	})
+}
+
+// RenderTag returns a mini swagger definition that only contains the endpoints with the specified tag
+// and the definitions they reference transitively
+func (a *API) RenderTag(tag string) *API {
+	return a.subset(func(_ string, e *Endpoint) bool {
+		for _, v := range e.Tags {
+			if v == tag {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// subset returns a copy of the api with the operations and the versioned variants accepted by match,
+// the tags they use, and the definitions referenced transitively by what the copy renders
+func (a *API) subset(match func(rawPath string, e *Endpoint) bool) *API {
+	doc := a.Clone()
+	doc.Tags = nil
+
+	tags := make(map[string]struct{})
+	doc.Paths = subsetPaths(a.Paths, match, tags)
+	if a.Versioning != nil {
+		vs := *a.Versioning
+		vs.variants = make(map[string]map[string]*Endpoints, len(a.Versioning.variants))
+		for version, paths := range a.Versioning.variants {
+			if v := subsetPaths(paths, match, tags); v != nil {
+				vs.variants[version] = v
+			}
+		}
+		doc.Versioning = &vs
+	}
+
+	for _, tag := range a.Tags {
+		if _, ok := tags[tag.Name]; ok {
+			doc.Tags = append(doc.Tags, tag)
+		}
+	}
+
+	used := doc.referencedDefinitions()
+	doc.Definitions = nil
+	for name := range used {
+		obj, ok := a.Definitions[name]
+		if !ok {
+			continue
+		}
+		if doc.Definitions == nil {
+			doc.Definitions = make(map[string]Object)
+		}
+		doc.Definitions[name] = obj
+	}
+	return doc
+}
+
+// subsetPaths returns the endpoints of paths accepted by match, or nil if there is none,
+// and records the tags they use
+func subsetPaths(paths map[string]*Endpoints, match func(rawPath string, e *Endpoint) bool, tags map[string]struct{}) map[string]*Endpoints {
+	var subset map[string]*Endpoints
+	for rawPath, endpoints := range paths {
+		endpoints.Walk(func(e *Endpoint) {
+			if !match(rawPath, e) {
+				return
+			}
+			if subset == nil {
+				subset = make(map[string]*Endpoints)
+			}
+			v, ok := subset[rawPath]
+			if !ok {
+				v = &Endpoints{}
+				subset[rawPath] = v
+			}
+			v.set(e.Method, e)
+
+			for _, tag := range e.Tags {
+				tags[tag] = struct{}{}
+			}
+		})
+	}
+	return subset
+}
+
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, makeRef(""))
+}
@@ -0,0 +1,139 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPartialAPI() *API {
+	api := New()
+	api.WithTag("pets", "pet operations").AddEndpoint(
+		&Endpoint{
+			Path:   "/pets",
+			Method: http.MethodGet,
+			Responses: map[string]Response{
+				"200": {Schema: MakeSchema([]Pet{})},
+			},
+		},
+	)
+	api.WithTag("people", "people operations").AddEndpoint(
+		&Endpoint{
+			Path:   "/people/{id}",
+			Method: http.MethodGet,
+			Responses: map[string]Response{
+				"200": {Schema: MakeSchema(Person{})},
+			},
+		},
+	)
+	return api
+}
+
+func TestAPI_RenderPath(t *testing.T) {
+	doc := newPartialAPI().RenderPath("/pets")
+	assert.Len(t, doc.Paths, 1)
+	assert.NotNil(t, doc.Paths["/pets"].Get)
+	assert.Equal(t, []Tag{{Name: "pets", Description: "pet operations"}}, doc.Tags)
+
+	// Pet references Person and Anonymous is flattened
+	assert.Len(t, doc.Definitions, 2)
+	assert.Contains(t, doc.Definitions, "github.com_zc2638_swag.Pet")
+	assert.Contains(t, doc.Definitions, "github.com_zc2638_swag.Person")
+
+	assert.Nil(t, newPartialAPI().RenderPath("/none").Paths)
+}
+
+func TestAPI_RenderTag(t *testing.T) {
+	doc := newPartialAPI().RenderTag("people")
+	assert.Len(t, doc.Paths, 1)
+	assert.NotNil(t, doc.Paths["/people/{id}"].Get)
+	assert.Equal(t, []Tag{{Name: "people", Description: "people operations"}}, doc.Tags)
+	assert.Len(t, doc.Definitions, 1)
+	assert.Contains(t, doc.Definitions, "github.com_zc2638_swag.Person")
+}
+
+// assertResolvedRefs asserts that every definition referenced by the encoded definition is defined
+func assertResolvedRefs(t *testing.T, doc *API) {
+	var buf bytes.Buffer
+	assert.NoError(t, doc.Encode(&buf))
+	var v map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &v))
+	definitions, _ := v["definitions"].(map[string]interface{})
+	countRefs(v, func(name string) {
+		assert.Contains(t, definitions, name)
+	})
+}
+
+func TestAPI_RenderPathResponseRef(t *testing.T) {
+	api := New()
+	api.Responses = map[string]Response{
+		"NotFound": {Description: "not found", Schema: MakeSchema(Person{})},
+	}
+	api.AddDefinitions(Person{})
+	api.AddEndpoint(&Endpoint{
+		Path:      "/pets",
+		Method:    http.MethodGet,
+		Responses: map[string]Response{"404": {Ref: "#/responses/NotFound"}},
+	})
+
+	doc := api.RenderPath("/pets")
+	assert.Contains(t, doc.Definitions, "github.com_zc2638_swag.Person")
+	assertResolvedRefs(t, doc)
+}
+
+func TestAPI_RenderTagGlobalResponses(t *testing.T) {
+	api := newPartialAPI()
+	api.GlobalResponses = map[string]Response{
+		"500": {Description: "error", Schema: MakeSchema(Anonymous{})},
+	}
+	api.AddDefinitions(Anonymous{})
+
+	doc := api.RenderTag("people")
+	assert.Contains(t, doc.Definitions, "github.com_zc2638_swag.Anonymous")
+	assert.NotContains(t, doc.Definitions, "github.com_zc2638_swag.Pet")
+	assertResolvedRefs(t, doc)
+}
+
+func TestAPI_RenderTagNestedAndVariants(t *testing.T) {
+	api := New()
+	api.AddEndpoint(
+		&Endpoint{
+			Path:   "/matrix",
+			Method: http.MethodGet,
+			Tags:   []string{"pets"},
+			Responses: map[string]Response{
+				"200": {Schema: MakeSchema([][]Person{})},
+				"201": {Schema: MakeSchema(map[string]Anonymous{})},
+			},
+		},
+		&Endpoint{
+			Path:      "/pets",
+			Method:    http.MethodGet,
+			Tags:      []string{"pets"},
+			Versions:  []string{"2"},
+			Responses: map[string]Response{"200": {Schema: MakeSchema([]Pet{})}},
+		},
+	)
+
+	doc := api.RenderTag("pets")
+	assert.Contains(t, doc.Definitions, "github.com_zc2638_swag.Pet")
+	assertResolvedRefs(t, doc)
+	assertResolvedRefs(t, doc.RenderVersion("2"))
+}
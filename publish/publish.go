@@ -0,0 +1,202 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package publish pushes the rendered spec to documentation portals, so that deploy steps
+// can publish it with the library which built it:
+//
+//	err := publish.Publish(ctx, api,
+//		publish.SwaggerHub("acme", "petstore", "1.0.0", os.Getenv("SWAGGERHUB_API_KEY")),
+//		publish.HTTP("https://docs.example.com/specs/petstore.json"),
+//	)
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/zc2638/swag"
+)
+
+// Target receives the rendered spec
+type Target interface {
+	Publish(ctx context.Context, spec []byte) error
+}
+
+// TargetFunc adapts a function to the Target interface
+type TargetFunc func(ctx context.Context, spec []byte) error
+
+// Publish implements Target
+func (f TargetFunc) Publish(ctx context.Context, spec []byte) error {
+	return f(ctx, spec)
+}
+
+// Publish renders the api once and pushes it to the targets in order, stopping at the first failure
+func Publish(ctx context.Context, api *swag.API, targets ...Target) error {
+	var buf bytes.Buffer
+	if err := api.EncodeContext(ctx, &buf); err != nil {
+		return err
+	}
+	for _, target := range targets {
+		if err := target.Publish(ctx, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Option provides configuration options to the http based targets
+type Option func(t *httpTarget)
+
+// BaseURL replaces the scheme and host of the target, e.g. for a self-hosted portal
+func BaseURL(base string) Option {
+	return func(t *httpTarget) {
+		t.base = strings.TrimSuffix(base, "/")
+	}
+}
+
+// Header sets a header of the request
+func Header(key, value string) Option {
+	return func(t *httpTarget) {
+		t.header.Set(key, value)
+	}
+}
+
+// Client sets the http client sending the request; defaults to http.DefaultClient
+func Client(client *http.Client) Option {
+	return func(t *httpTarget) {
+		t.client = client
+	}
+}
+
+// Method sets the http method of the request
+func Method(method string) Option {
+	return func(t *httpTarget) {
+		t.method = method
+	}
+}
+
+type httpTarget struct {
+	method string
+	base   string
+	path   string
+	header http.Header
+	client *http.Client
+	// body returns the body of the request and its content type
+	body func(spec []byte) (io.Reader, string, error)
+}
+
+func newTarget(method, base, path string, opts []Option) *httpTarget {
+	t := &httpTarget{
+		method: method,
+		base:   base,
+		path:   path,
+		header: make(http.Header),
+		client: http.DefaultClient,
+		body: func(spec []byte) (io.Reader, string, error) {
+			return bytes.NewReader(spec), "application/json", nil
+		},
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Publish implements Target
+func (t *httpTarget) Publish(ctx context.Context, spec []byte) error {
+	body, contentType, err := t.body(spec)
+	if err != nil {
+		return err
+	}
+	target := t.base + t.path
+	req, err := http.NewRequest(t.method, target, body)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range t.header {
+		req.Header[k] = v
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("publish to %s: unexpected status %s: %s", target, resp.Status, bytes.TrimSpace(message))
+	}
+	return nil
+}
+
+// HTTP puts the spec to the url
+func HTTP(u string, opts ...Option) Target {
+	return newTarget(http.MethodPut, u, "", opts)
+}
+
+// SwaggerHub saves the spec as the version of the api of the owner on SwaggerHub
+func SwaggerHub(owner, api, version, apiKey string, opts ...Option) Target {
+	path := fmt.Sprintf("/apis/%s/%s?version=%s", url.PathEscape(owner), url.PathEscape(api), url.QueryEscape(version))
+	t := newTarget(http.MethodPost, "https://api.swaggerhub.com", path, opts)
+	t.header.Set("Authorization", apiKey)
+	return t
+}
+
+// Readme updates the api specification of the id on ReadMe, or uploads a new one if id is empty
+func Readme(apiKey, id string, opts ...Option) Target {
+	method, path := http.MethodPost, "/api/v1/api-specification"
+	if id != "" {
+		method, path = http.MethodPut, path+"/"+url.PathEscape(id)
+	}
+	t := newTarget(method, "https://dash.readme.com", path, opts)
+	t.header.Set("Authorization", "Basic "+basicAuth(apiKey))
+	t.body = func(spec []byte) (io.Reader, string, error) {
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		fw, err := mw.CreateFormFile("spec", "swagger.json")
+		if err != nil {
+			return nil, "", err
+		}
+		if _, err := fw.Write(spec); err != nil {
+			return nil, "", err
+		}
+		if err := mw.Close(); err != nil {
+			return nil, "", err
+		}
+		return &buf, mw.FormDataContentType(), nil
+	}
+	return t
+}
+
+// Stoplight puts the spec to the file of the project on Stoplight, e.g. reference/petstore.json
+func Stoplight(project, file, token string, opts ...Option) Target {
+	path := fmt.Sprintf("/api/v1/projects/%s/files/%s", url.PathEscape(project), strings.TrimPrefix(file, "/"))
+	t := newTarget(http.MethodPut, "https://stoplight.io", path, opts)
+	t.header.Set("Authorization", "Bearer "+token)
+	return t
+}
+
+func basicAuth(username string) string {
+	return base64.StdEncoding.EncodeToString([]byte(username + ":"))
+}
@@ -0,0 +1,119 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package publish
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag"
+)
+
+type captured struct {
+	method      string
+	uri         string
+	header      http.Header
+	body        string
+	contentType string
+}
+
+func newServer(t *testing.T, status int) (*httptest.Server, *captured) {
+	c := &captured{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.method = r.Method
+		c.uri = r.URL.RequestURI()
+		c.header = r.Header
+		c.contentType = r.Header.Get("Content-Type")
+		if f, _, err := r.FormFile("spec"); err == nil {
+			data, _ := ioutil.ReadAll(f)
+			c.body = string(data)
+		} else {
+			data, _ := ioutil.ReadAll(r.Body)
+			c.body = string(data)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("denied"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv, c
+}
+
+func TestPublish(t *testing.T) {
+	srv, c := newServer(t, http.StatusOK)
+	api := swag.New()
+
+	err := Publish(context.Background(), api, HTTP(srv.URL+"/specs/petstore.json", Header("X-Token", "secret")))
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, c.method)
+	assert.Equal(t, "/specs/petstore.json", c.uri)
+	assert.Equal(t, "secret", c.header.Get("X-Token"))
+	assert.Equal(t, "application/json", c.contentType)
+	assert.Contains(t, c.body, `"swagger":"2.0"`)
+
+	var calls int
+	failing := TargetFunc(func(ctx context.Context, spec []byte) error {
+		calls++
+		return errors.New("failed")
+	})
+	assert.EqualError(t, Publish(context.Background(), api, failing, failing), "failed")
+	assert.Equal(t, 1, calls)
+}
+
+func TestSwaggerHub(t *testing.T) {
+	srv, c := newServer(t, http.StatusCreated)
+	err := SwaggerHub("acme", "petstore", "1.0.0", "key", BaseURL(srv.URL)).Publish(context.Background(), []byte("{}"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, c.method)
+	assert.Equal(t, "/apis/acme/petstore?version=1.0.0", c.uri)
+	assert.Equal(t, "key", c.header.Get("Authorization"))
+	assert.Equal(t, "{}", c.body)
+}
+
+func TestReadme(t *testing.T) {
+	srv, c := newServer(t, http.StatusOK)
+	err := Readme("key", "abc", BaseURL(srv.URL)).Publish(context.Background(), []byte("{}"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, c.method)
+	assert.Equal(t, "/api/v1/api-specification/abc", c.uri)
+	assert.Equal(t, "Basic a2V5Og==", c.header.Get("Authorization"))
+	assert.Contains(t, c.contentType, "multipart/form-data")
+	assert.Equal(t, "{}", c.body)
+
+	assert.NoError(t, Readme("key", "", BaseURL(srv.URL)).Publish(context.Background(), []byte("{}")))
+	assert.Equal(t, http.MethodPost, c.method)
+	assert.Equal(t, "/api/v1/api-specification", c.uri)
+}
+
+func TestStoplight(t *testing.T) {
+	srv, c := newServer(t, http.StatusOK)
+	err := Stoplight("petstore", "/reference/petstore.json", "token", BaseURL(srv.URL)).
+		Publish(context.Background(), []byte("{}"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPut, c.method)
+	assert.Equal(t, "/api/v1/projects/petstore/files/reference/petstore.json", c.uri)
+	assert.Equal(t, "Bearer token", c.header.Get("Authorization"))
+}
+
+func TestHTTPError(t *testing.T) {
+	srv, _ := newServer(t, http.StatusForbidden)
+	err := HTTP(srv.URL, Method(http.MethodPost)).Publish(context.Background(), []byte("{}"))
+	assert.EqualError(t, err, "publish to "+srv.URL+": unexpected status 403 Forbidden: denied")
+}
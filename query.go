@@ -0,0 +1,93 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/zc2638/swag/types"
+)
+
+// SortableFields returns the names of the fields of the model tagged sortable, e.g. sortable:"true" or swag:"sortable"
+func SortableFields(prototype interface{}) []string {
+	fields := make([]string, 0)
+	modelFields(reflect.TypeOf(prototype), func(name string, _ reflect.StructField, tag fieldTag) {
+		if tag.sortable() {
+			fields = append(fields, name)
+		}
+	})
+	return fields
+}
+
+// FilterParameters returns a filter[name] query parameter for each field of the model tagged filterable,
+// e.g. filterable:"true" or swag:"filterable", typed after the field; the fields of nested models are skipped
+func FilterParameters(prototype interface{}) []Parameter {
+	params := make([]Parameter, 0)
+	modelFields(reflect.TypeOf(prototype), func(name string, field reflect.StructField, tag fieldTag) {
+		if !tag.filterable() {
+			return
+		}
+		p := inspect(field.Type, "")
+		if p.Type == "" || p.Type == "object" || p.Type == "array" || p.Ref != "" {
+			return
+		}
+		if format := tag.format(); format != "" {
+			p.Format = format
+		}
+		description := tag.description()
+		if description == "" {
+			description = "filters the results on " + name
+		}
+		params = append(params, Parameter{
+			In:          "query",
+			Name:        "filter[" + name + "]",
+			Type:        types.ParameterType(p.Type),
+			Format:      p.Format,
+			Description: description,
+			Enum:        tag.enum(),
+		})
+	})
+	return params
+}
+
+// modelFields calls fn with the json name of each exported field of the struct, embedded structs included
+func modelFields(t reflect.Type, fn func(name string, field reflect.StructField, tag fieldTag)) {
+	if t == nil {
+		return
+	}
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := newFieldTag(field.Tag)
+		name := strings.Split(tag.name(), ",")[0]
+		if field.Anonymous && name == "" {
+			modelFields(field.Type, fn)
+			continue
+		}
+		if field.PkgPath != "" || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fn(name, field, tag)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Audit struct {
+	CreatedAt time.Time `json:"createdAt" sortable:"true" filterable:"true"`
+}
+
+type Listing struct {
+	Audit
+	Name   string   `json:"name" swag:"sortable,filterable"`
+	Status string   `json:"status" filterable:"true" enum:"open,closed" description:"the listing status"`
+	Price  float64  `json:"price,omitempty" sortable:"true"`
+	Tags   []string `json:"tags" filterable:"true"`
+	Owner  *Pet     `json:"owner" filterable:"true"`
+	secret string   `sortable:"true"`
+}
+
+func TestSortableFields(t *testing.T) {
+	assert.Equal(t, []string{"createdAt", "name", "price"}, SortableFields(Listing{}))
+	assert.Equal(t, []string{"createdAt", "name", "price"}, SortableFields([]*Listing{}))
+	assert.Empty(t, SortableFields(42))
+}
+
+func TestFilterParameters(t *testing.T) {
+	assert.Equal(t, []Parameter{
+		{In: "query", Name: "filter[createdAt]", Type: "string", Format: "date-time", Description: "filters the results on createdAt"},
+		{In: "query", Name: "filter[name]", Type: "string", Description: "filters the results on name"},
+		{In: "query", Name: "filter[status]", Type: "string", Description: "the listing status", Enum: []string{"open", "closed"}},
+	}, FilterParameters(Listing{}))
+}
@@ -0,0 +1,133 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"html"
+	"net/http"
+)
+
+// RedocScriptURL is the redoc bundle loaded by default, pinned to a release of the redoc CDN
+const RedocScriptURL = "https://cdn.redoc.ly/redoc/v2.1.5/bundles/redoc.standalone.js"
+
+// RedocOption configures the redoc page served by RedocHandler
+type RedocOption func(c *redocConfig)
+
+type redocConfig struct {
+	title     string
+	script    string
+	integrity string
+	options   map[string]interface{}
+}
+
+// RedocTitle sets the title of the page; defaults to API Reference
+func RedocTitle(title string) RedocOption {
+	return func(c *redocConfig) {
+		c.title = title
+	}
+}
+
+// RedocScript loads the redoc bundle from the url instead of RedocScriptURL, e.g. a copy served by the api
+func RedocScript(url string) RedocOption {
+	return func(c *redocConfig) {
+		c.script = url
+	}
+}
+
+// RedocIntegrity sets the subresource integrity of the redoc bundle, e.g. sha384-...,
+// which the browser checks before running a bundle loaded from a CDN
+func RedocIntegrity(hash string) RedocOption {
+	return func(c *redocConfig) {
+		c.integrity = hash
+	}
+}
+
+// RedocTheme sets the theme option of redoc, e.g. {"colors": {"primary": {"main": "#32329f"}}}
+func RedocTheme(theme map[string]interface{}) RedocOption {
+	return RedocConfig("theme", theme)
+}
+
+// RedocConfig sets an option of redoc, e.g. hideDownloadButton or expandResponses
+func RedocConfig(key string, value interface{}) RedocOption {
+	return func(c *redocConfig) {
+		c.options[key] = value
+	}
+}
+
+// RedocHandler returns a http.Handler serving a redoc page rendering the spec at specURL
+func RedocHandler(specURL string, opts ...RedocOption) http.Handler {
+	c := newRedocConfig()
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	page, err := c.page(specURL)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(page)
+	})
+}
+
+func newRedocConfig() redocConfig {
+	return redocConfig{
+		title:   "API Reference",
+		script:  RedocScriptURL,
+		options: make(map[string]interface{}),
+	}
+}
+
+func (c redocConfig) page(specURL string) ([]byte, error) {
+	// the json encoding escapes <, > and &, which keeps the values inside the script
+	spec, _ := json.Marshal(specURL)
+	options, err := json.Marshal(c.options)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(`<!DOCTYPE html>
+<html>
+<head>
+  <title>` + html.EscapeString(c.title) + `</title>
+  <meta charset="utf-8"/>
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <style>body { margin: 0; padding: 0; }</style>
+</head>
+<body>
+  <div id="redoc-container"></div>
+  ` + scriptTag(c.script, c.integrity) + `
+  <script>
+    Redoc.init(` + string(spec) + `, ` + string(options) + `, document.getElementById("redoc-container"));
+  </script>
+</body>
+</html>
+`), nil
+}
+
+// scriptTag returns the script element loading the url, checked against the integrity hash if any
+func scriptTag(url, integrity string) string {
+	return `<script src="` + html.EscapeString(url) + `"` + integrityAttrs(integrity) + `></script>`
+}
+
+// integrityAttrs returns the attributes of an element checking its resource against the integrity hash, if any
+func integrityAttrs(integrity string) string {
+	if integrity == "" {
+		return ""
+	}
+	return ` integrity="` + html.EscapeString(integrity) + `" crossorigin="anonymous"`
+}
@@ -0,0 +1,52 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedocHandler(t *testing.T) {
+	h := RedocHandler("/docs/swagger.json",
+		RedocTitle("Pets <v2>"),
+		RedocScript("/assets/redoc.standalone.js"),
+		RedocTheme(map[string]interface{}{"colors": map[string]interface{}{"primary": map[string]interface{}{"main": "#32329f"}}}),
+		RedocConfig("hideDownloadButton", true),
+	)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/redoc", nil))
+
+	assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	assert.Contains(t, body, "<title>Pets &lt;v2&gt;</title>")
+	assert.Contains(t, body, `<script src="/assets/redoc.standalone.js"></script>`)
+	assert.Contains(t, body, `Redoc.init("/docs/swagger.json", {"hideDownloadButton":true,"theme":{"colors":{"primary":{"main":"#32329f"}}}}`)
+	assert.NotContains(t, body, "cdn.redoc.ly")
+}
+
+func TestRedocHandlerIntegrity(t *testing.T) {
+	w := httptest.NewRecorder()
+	RedocHandler("/docs/swagger.json").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/redoc", nil))
+	assert.Contains(t, w.Body.String(), `<script src="`+RedocScriptURL+`"></script>`)
+	assert.NotContains(t, RedocScriptURL, "latest")
+
+	w = httptest.NewRecorder()
+	RedocHandler("/docs/swagger.json", RedocIntegrity("sha384-abc")).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/redoc", nil))
+	assert.Contains(t, w.Body.String(), `<script src="`+RedocScriptURL+`" integrity="sha384-abc" crossorigin="anonymous"></script>`)
+}
@@ -15,12 +15,15 @@
 package swag
 
 import (
+	"encoding/json"
 	"reflect"
 	"strings"
 
 	"github.com/zc2638/swag/types"
 )
 
+var rawMessageType = reflect.TypeOf(json.RawMessage{})
+
 func inspect(t reflect.Type, jsonTag string) Property {
 	p := Property{
 		GoType: t,
@@ -35,6 +38,27 @@ func inspect(t reflect.Type, jsonTag string) Property {
 		p.GoType = p.GoType.Elem()
 	}
 
+	if schema, ok := lookupJSONSchema(p.GoType); ok {
+		return schema
+	}
+	if f, ok := lookupTimeFormat(p.GoType); ok {
+		p.Type = types.String.String()
+		p.Format = f.format
+		p.Layout = f.layout
+		return p
+	}
+	if isDuration(p.GoType) {
+		applyDurationFormat(&p, "")
+		return p
+	}
+	if n, ok := lookupBigNumber(p.GoType); ok {
+		applyBigNumber(&p, n, "")
+		return p
+	}
+	if schema, ok := lookupMarshaler(p.GoType); ok {
+		return schema
+	}
+
 	switch p.GoType.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
 		p.Type = types.Integer.String()
@@ -68,10 +92,44 @@ func inspect(t reflect.Type, jsonTag string) Property {
 		p.Ref = makeRef(name)
 
 	case reflect.Slice:
+		if p.GoType == rawMessageType {
+			// raw json may hold any value
+			return p
+		}
+		if p.GoType.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes byte slices as base64 strings
+			p.Type = types.String.String()
+			p.Format = "byte"
+			return p
+		}
 		p.Type = types.Array.String()
 		p.Items = &Items{}
 
 		p.GoType = t.Elem() // dereference the slice
+		if schema, ok := lookupJSONSchema(p.GoType); ok {
+			p.Items.Type = schema.Type
+			p.Items.Format = schema.Format
+			p.Items.Properties = schema.Properties
+			return p
+		}
+		if f, ok := lookupTimeFormat(p.GoType); ok {
+			p.Items.Type = types.String.String()
+			p.Items.Format = f.format
+			return p
+		}
+		if n, ok := lookupBigNumber(p.GoType); ok {
+			var item Property
+			applyBigNumber(&item, n, "")
+			p.Items.Type = item.Type
+			p.Items.Format = item.Format
+			return p
+		}
+		if schema, ok := lookupMarshaler(p.GoType); ok {
+			p.Items.Type = schema.Type
+			p.Items.Format = schema.Format
+			p.Items.Properties = schema.Properties
+			return p
+		}
 		switch p.GoType.Kind() {
 		case reflect.Ptr:
 			p.GoType = p.GoType.Elem()
@@ -113,22 +171,39 @@ func buildProperty(t reflect.Type) (map[string]Property, []string) {
 		field := t.Field(i)
 
 		// skip unexported fields
-		if strings.ToLower(field.Name[0:1]) == field.Name[0:1] {
+		if isUnexported(field) {
+			debugf("%s.%s skipped: unexported", t, field.Name)
+			continue
+		}
+		// the xml name of the struct is documented by the definition itself
+		if field.Type == xmlNameType {
+			debugf("%s.%s skipped: xml name of the definition", t, field.Name)
 			continue
 		}
 		if field.Anonymous {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() != reflect.Struct {
+				debugf("%s.%s skipped: embedded %s is not a struct", t, field.Name, field.Type)
+				continue
+			}
+			debugf("%s.%s embedded: properties of %s inlined", t, field.Name, embedded)
 			// 暂不处理匿名结构的required
-			ps, _ := buildProperty(field.Type)
+			ps, _ := buildProperty(embedded)
 			for name, p := range ps {
 				properties[name] = p
 			}
 			continue
 		}
 
+		tag := newFieldTag(field.Tag)
+
 		// determine the json name of the field
-		name := strings.TrimSpace(field.Tag.Get("json"))
+		name := strings.TrimSpace(tag.name())
 		if name == "" || strings.HasPrefix(name, ",") {
-			name = field.Name
+			name = propertyName(field.Name)
 
 		} else {
 			// strip out things like , omitempty
@@ -140,26 +215,51 @@ func buildProperty(t reflect.Type) (map[string]Property, []string) {
 		name = parts[0]
 		if name == "-" {
 			// honor json ignore tag
+			debugf("%s.%s skipped: ignored by its tag", t, field.Name)
 			continue
 		}
-		p := inspect(field.Type, field.Tag.Get("json"))
+		p := inspect(field.Type, tag.name())
 
 		// determine the extra info of the field
-		if _, ok := field.Tag.Lookup("required"); ok {
+		if tag.required() {
 			required = append(required, name)
 		}
-		if example := field.Tag.Get("example"); example != "" {
+		if example := tag.example(); example != "" {
 			p.Example = example
 		}
-		if description := field.Tag.Get("description"); description != "" {
+		if description := tag.description(); description != "" {
 			p.Description = description
 		}
-		if desc := field.Tag.Get("desc"); desc != "" {
-			p.Description = desc
+		if enum := tag.enum(); enum != nil {
+			p.Enum = enum
+		}
+		p.Audience = tag.audience()
+		p.Nullable = tag.nullable()
+		if v, ok := tag.constant(); ok {
+			p.Const = v
+			p.Enum = []string{v}
+		}
+		if tag.sensitive() {
+			// sensitive values never appear in the examples
+			p.Sensitive = true
+			p.Example = ""
 		}
-		if enum := field.Tag.Get("enum"); enum != "" {
-			p.Enum = strings.Split(enum, ",")
+		if format := field.Tag.Get("duration"); format != "" && isDuration(field.Type) {
+			applyDurationFormat(&p, DurationFormat(format))
 		}
+		if n, ok := lookupBigNumber(field.Type); ok {
+			if format := field.Tag.Get("bignum"); format != "" {
+				applyBigNumber(&p, n, BigNumberFormat(format))
+			}
+			applyPrecision(&p, field.Tag.Get("precision"))
+		}
+		if format := tag.format(); format != "" {
+			p.Format = format
+		}
+		applyXML(&p, field, name)
+		p.Extensions = tagExtensions(field.Tag)
+		applyPropertyHooks(field, &p)
+		debugProperty(t, field, name, p)
 		properties[name] = p
 	}
 	return properties, required
@@ -182,6 +282,17 @@ func defineObject(v interface{}, desc string) Object {
 		t = t.Elem()
 	}
 
+	if isScalar(t) {
+		p := inspect(t, "")
+		debugf("%s documented as a %s value", t, p.Type)
+		return Object{
+			IsArray: isArray,
+			GoType:  t,
+			Type:    p.Type,
+			Format:  p.Format,
+			Name:    makeName(t),
+		}
+	}
 	if t.Kind() != reflect.Struct {
 		p := inspect(t, "")
 		return Object{
@@ -202,7 +313,24 @@ func defineObject(v interface{}, desc string) Object {
 		Required:    required,
 		Properties:  properties,
 		Description: desc,
+		XML:         structXML(t),
+		Extensions:  typeExtensions(t),
+	}
+}
+
+// isScalar reports whether the struct type is documented as a plain value instead of an object
+func isScalar(t reflect.Type) bool {
+	if _, ok := lookupJSONSchema(t); ok {
+		return true
+	}
+	if _, ok := lookupTimeFormat(t); ok {
+		return true
 	}
+	if _, ok := lookupBigNumber(t); ok {
+		return true
+	}
+	_, ok := lookupMarshaler(t)
+	return ok
 }
 
 func define(v interface{}) map[string]Object {
@@ -217,7 +345,8 @@ func define(v interface{}) map[string]Object {
 		dirty = false
 		for _, d := range objMap {
 			for _, p := range d.Properties {
-				if p.GoType.Kind() == reflect.Struct {
+				isRef := p.Ref != "" || (p.Items != nil && p.Items.Ref != "")
+				if isRef && p.GoType.Kind() == reflect.Struct {
 					name := makeName(p.GoType)
 					if _, exists := objMap[name]; !exists {
 						child := defineObject(p.GoType, p.Description)
@@ -238,6 +367,14 @@ func MakeSchema(prototype interface{}) *Schema {
 		Prototype: prototype,
 	}
 
+	t, ok := prototype.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(prototype)
+	}
+	if t != nil && t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 && t != rawMessageType {
+		return &Schema{Type: types.String.String(), Format: "byte"}
+	}
+
 	obj := defineObject(prototype, "")
 	if obj.IsArray {
 		schema.Type = "array"
@@ -251,3 +388,81 @@ func MakeSchema(prototype interface{}) *Schema {
 
 	return schema
 }
+
+// DefinitionName returns the name of the definition reflected from the struct or pointer to a struct
+func DefinitionName(prototype interface{}) string {
+	t, ok := prototype.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(prototype)
+	}
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return makeName(t)
+}
+
+// MakeSchemaRef returns a Schema instance that references an already registered definition by name
+func MakeSchemaRef(name string) *Schema {
+	return &Schema{
+		Ref: makeRef(name),
+	}
+}
+
+// StructParameters reflects the exported fields of a struct or a pointer to struct into parameters located in "in";
+// the parameter names are read from the tag with the specified name, falling back to the field names,
+// and nested structs are skipped since they cannot be represented by non-body parameters
+func StructParameters(prototype interface{}, in, tagName string) []Parameter {
+	t, ok := prototype.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(prototype)
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	params := make([]Parameter, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous {
+			params = append(params, StructParameters(field.Type, in, tagName)...)
+			continue
+		}
+		// skip unexported fields
+		if field.PkgPath != "" {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get(tagName), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		p := inspect(field.Type, "")
+		if p.Type == "" || p.Type == "object" || p.Ref != "" || (p.Items != nil && p.Items.Ref != "") {
+			continue
+		}
+		tag := newFieldTag(field.Tag)
+		if format := tag.format(); format != "" {
+			p.Format = format
+		}
+		param := Parameter{
+			In:          in,
+			Name:        name,
+			Type:        types.ParameterType(p.Type),
+			Format:      p.Format,
+			Description: tag.description(),
+			Default:     tag.defaultValue(),
+			Required:    tag.required() || in == "path",
+			Enum:        tag.enum(),
+			Items:       p.Items,
+		}
+		params = append(params, param)
+	}
+	return params
+}
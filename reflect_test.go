@@ -143,3 +143,23 @@ func TestMakeSchemaType(t *testing.T) {
 	objSchema := MakeSchema(struct{}{})
 	assert.Equal(t, "", objSchema.Type, "expect array type but get %s", objSchema.Type)
 }
+
+func TestMakeSchemaRef(t *testing.T) {
+	schema := MakeSchemaRef("ErrorResponse")
+	assert.Equal(t, "#/definitions/ErrorResponse", schema.Ref)
+	assert.Nil(t, schema.Prototype)
+}
+
+type Attachment struct {
+	Name    string          `json:"name"`
+	Content []byte          `json:"content"`
+	Raw     []byte          `json:"raw" format:"binary"`
+	Meta    json.RawMessage `json:"meta"`
+}
+
+func TestDefineBytes(t *testing.T) {
+	obj := define(Attachment{})["github.com_zc2638_swag.Attachment"]
+	assert.Equal(t, Property{Type: "string", Format: "byte"}, withoutGoType(obj.Properties["content"]))
+	assert.Equal(t, Property{Type: "string", Format: "binary"}, withoutGoType(obj.Properties["raw"]))
+	assert.Equal(t, Property{}, withoutGoType(obj.Properties["meta"]))
+}
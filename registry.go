@@ -0,0 +1,71 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+var registry = struct {
+	sync.RWMutex
+	types   map[string]interface{}
+	schemes map[string]SecurityScheme
+}{
+	types:   make(map[string]interface{}),
+	schemes: map[string]SecurityScheme{"bearer": bearerScheme()},
+}
+
+// RegisterType registers the prototype under the specified name,
+// so that manifests, overlays and string based config can reference the model by name;
+// it panics if the name is already registered with another type
+func RegisterType(name string, prototype interface{}) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if v, ok := registry.types[name]; ok && reflect.TypeOf(v) != reflect.TypeOf(prototype) {
+		panic(fmt.Errorf("type %v is already registered as %v", name, reflect.TypeOf(v)))
+	}
+	registry.types[name] = prototype
+}
+
+// TypeByName returns the prototype registered under the specified name
+func TypeByName(name string) (interface{}, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	v, ok := registry.types[name]
+	return v, ok
+}
+
+// RegisterSecurityScheme registers the security scheme under the specified name,
+// so that the endpoints requiring the name add it to the security definitions of the api
+// unless the api defines the name
+func RegisterSecurityScheme(name string, scheme SecurityScheme) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	registry.schemes[name] = scheme
+}
+
+// SecuritySchemeByName returns the security scheme registered under the specified name
+func SecuritySchemeByName(name string) (SecurityScheme, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	v, ok := registry.schemes[name]
+	return v, ok
+}
@@ -0,0 +1,51 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterType(t *testing.T) {
+	RegisterType("RegistryPerson", Person{})
+	RegisterType("RegistryPerson", Person{First: "zc"})
+
+	v, ok := TypeByName("RegistryPerson")
+	assert.True(t, ok)
+	assert.Equal(t, Person{First: "zc"}, v)
+
+	_, ok = TypeByName("RegistryUnknown")
+	assert.False(t, ok)
+
+	assert.Panics(t, func() {
+		RegisterType("RegistryPerson", Pet{})
+	})
+}
+
+func TestRegisterSecurityScheme(t *testing.T) {
+	scheme, ok := SecuritySchemeByName("bearer")
+	assert.True(t, ok)
+	assert.Equal(t, "bearer", scheme.Scheme)
+
+	RegisterSecurityScheme("RegistryKey", SecurityScheme{Type: "apiKey", Name: "X-Key", In: "header"})
+	scheme, ok = SecuritySchemeByName("RegistryKey")
+	assert.True(t, ok)
+	assert.Equal(t, "X-Key", scheme.Name)
+
+	_, ok = SecuritySchemeByName("RegistryUnknown")
+	assert.False(t, ok)
+}
@@ -0,0 +1,374 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zc2638/swag/types"
+)
+
+// RenderOptions controls how the swagger definition is encoded
+type RenderOptions struct {
+	// Indent is the indentation applied to each nesting level; empty means compact output
+	Indent string
+	// DisableHTMLEscape keeps &, < and > unescaped inside json strings
+	DisableHTMLEscape bool
+	// OmitEmpty removes null values, empty strings and empty arrays/maps
+	OmitEmpty bool
+	// TypedEnums encodes the enum values of integer, number and boolean schemas and parameters
+	// as json numbers and booleans instead of the strings read from the enum tags
+	TypedEnums bool
+	// InlineSingleUse inlines the definitions referenced exactly once, e.g. a request model used by
+	// a single endpoint, and keeps the shared and recursive definitions as references
+	InlineSingleUse bool
+	// Unsupported decides how the swagger 2.0 rendering handles the OpenAPI 3 only features,
+	// dropping them by default
+	Unsupported UnsupportedPolicy
+}
+
+// Encode writes the json encoding of the swagger definition to w
+func (a *API) Encode(w io.Writer) error {
+	return a.EncodeContext(context.Background(), w)
+}
+
+// EncodeContext is like Encode, but stops with the error of the context once it is done,
+// so that rendering a very large api respects the deadline of the request
+func (a *API) EncodeContext(ctx context.Context, w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", a.Render.Indent)
+	encoder.SetEscapeHTML(!a.Render.DisableHTMLEscape)
+
+	doc := a.rendered()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if !a.Compact && !a.Render.OmitEmpty && !a.Render.TypedEnums && !a.Render.InlineSingleUse &&
+		len(a.Overlays) == 0 && len(a.InternalDefinitions) == 0 && a.OpenAPI == "" && !doc.unsupported() {
+		return encoder.Encode(doc)
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if a.Compact || a.Render.OmitEmpty {
+		v = pruneEmpty(v, a.Render.OmitEmpty)
+	}
+	if a.Render.TypedEnums {
+		typedEnums(v)
+	}
+	if len(a.InternalDefinitions) > 0 {
+		v = a.hideDefinitions(v)
+	}
+	if a.Render.InlineSingleUse {
+		v = inlineSingleUse(v)
+	}
+	if a.OpenAPI == "" {
+		degradations := degrade(v, a.Render.Unsupported)
+		if len(degradations) > 0 && a.Render.Unsupported == UnsupportedError {
+			d := degradations[0]
+			return &ValidationError{Field: d.Pointer, Err: ErrUnsupportedFeature, Reason: d.Feature + " has no swagger 2.0 equivalent"}
+		}
+	}
+	if m, ok := v.(map[string]interface{}); ok && a.OpenAPI != "" {
+		v = convertOpenAPI3(m, a.OpenAPI)
+		if len(a.OpenAPIHooks) > 0 {
+			if v, err = a.applyOpenAPIHooks(v.(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+	}
+	for _, overlay := range a.Overlays {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := overlay.Apply(v); err != nil {
+			return err
+		}
+	}
+	return encoder.Encode(v)
+}
+
+// compacted returns a copy of the api in which the per-operation produces/consumes
+// matching the global defaults are omitted; without global defaults, the media types most operations share
+// become the defaults, provided every operation declares its own
+func (a *API) compacted() *API {
+	doc := a.Clone()
+	if a.Paths == nil {
+		return doc
+	}
+	if len(doc.Produces) == 0 {
+		doc.Produces = a.sharedMediaTypes(func(e *Endpoint) []string { return e.Produces })
+	}
+	if len(doc.Consumes) == 0 {
+		doc.Consumes = a.sharedMediaTypes(func(e *Endpoint) []string { return e.Consumes })
+	}
+
+	doc.Paths = make(map[string]*Endpoints, len(a.Paths))
+	for p, endpoints := range a.Paths {
+		v := &Endpoints{}
+		endpoints.Walk(func(endpoint *Endpoint) {
+			e := *endpoint
+			if equalStrings(e.Produces, doc.Produces) {
+				e.Produces = nil
+			}
+			if equalStrings(e.Consumes, doc.Consumes) {
+				e.Consumes = nil
+			}
+			v.set(e.Method, &e)
+		})
+		doc.Paths[p] = v
+	}
+	return doc
+}
+
+// sharedMediaTypes returns the media types declared by the most operations, the first in lexical order on a tie,
+// or nil if an operation declares none, as it would then inherit them
+func (a *API) sharedMediaTypes(mediaTypes func(e *Endpoint) []string) []string {
+	counts := make(map[string]int)
+	lists := make(map[string][]string)
+	missing := false
+	a.Walk(func(_ string, e *Endpoint) {
+		list := mediaTypes(e)
+		if len(list) == 0 {
+			missing = true
+			return
+		}
+		key := strings.Join(list, "\n")
+		counts[key]++
+		lists[key] = list
+	})
+	if missing {
+		return nil
+	}
+	best := ""
+	for key, n := range counts {
+		if best == "" || n > counts[best] || n == counts[best] && key < best {
+			best = key
+		}
+	}
+	return lists[best]
+}
+
+// methodNotAllowed returns a copy of the api in which every operation documents a 405 response
+// listing the methods allowed on its path, unless the operation already defines one
+func (a *API) methodNotAllowed() *API {
+	doc := a.Clone()
+	if a.Paths == nil {
+		return doc
+	}
+
+	code := strconv.Itoa(http.StatusMethodNotAllowed)
+	doc.Paths = make(map[string]*Endpoints, len(a.Paths))
+	for p, endpoints := range a.Paths {
+		allow := endpoints.allow()
+		v := &Endpoints{}
+		endpoints.Walk(func(endpoint *Endpoint) {
+			e := *endpoint
+			if _, ok := e.Responses[code]; !ok {
+				responses := make(map[string]Response, len(e.Responses)+1)
+				for k, r := range e.Responses {
+					responses[k] = r
+				}
+				responses[code] = Response{
+					Description: "Method Not Allowed",
+					Headers: map[string]Header{
+						"Allow": {
+							Type:        types.String,
+							Description: "the allowed methods: " + allow,
+						},
+					},
+				}
+				e.Responses = responses
+			}
+			v.set(e.Method, &e)
+		})
+		doc.Paths[p] = v
+	}
+	return doc
+}
+
+// rendered returns the api as it is encoded: the default version, with the global responses and the envelope applied,
+// without the endpoints rejected by the filters and the properties hidden from the audience
+func (a *API) rendered() *API {
+	doc := a
+	if len(a.EnvVars) > 0 {
+		doc = doc.withEnv()
+	}
+	if a.Versioning != nil {
+		doc = doc.RenderVersion("")
+	}
+	if a.MethodNotAllowed {
+		doc = doc.methodNotAllowed()
+	}
+	if len(a.GlobalResponses) > 0 {
+		doc = doc.globalResponses()
+	}
+	if a.Envelope != nil {
+		doc = doc.enveloped()
+	}
+	if len(a.Filters) > 0 {
+		doc = doc.filtered()
+	}
+	doc = doc.forAudience()
+	if a.Compact {
+		doc = doc.compacted()
+	}
+	return doc
+}
+
+// globalResponses returns a copy of the api in which the operations document the global responses they use,
+// unless they already define a response for the code
+func (a *API) globalResponses() *API {
+	doc := a.Clone()
+	if a.Paths == nil {
+		return doc
+	}
+
+	doc.Paths = make(map[string]*Endpoints, len(a.Paths))
+	for p, endpoints := range a.Paths {
+		v := &Endpoints{}
+		endpoints.Walk(func(endpoint *Endpoint) {
+			e := *endpoint
+			if e.UseGlobalResponses || !a.GlobalResponsesOptIn {
+				responses := make(map[string]Response, len(e.Responses)+len(a.GlobalResponses))
+				for code, r := range a.GlobalResponses {
+					if e.UseGlobalResponses && len(e.GlobalResponses) > 0 && !containsString(e.GlobalResponses, code) {
+						continue
+					}
+					responses[code] = r
+				}
+				for code, r := range e.Responses {
+					responses[code] = r
+				}
+				e.Responses = responses
+			}
+			v.set(e.Method, &e)
+		})
+		doc.Paths[p] = v
+	}
+	return doc
+}
+
+// pruneEmpty removes empty arrays and maps from the decoded json value,
+// as well as null values and empty strings if scalars is true;
+// security requirements are kept since an empty list explicitly disables security,
+// and so are the keys required by the swagger 2.0 specification, see requiredKey
+func pruneEmpty(v interface{}, scalars bool) interface{} {
+	return prune(v, scalars, nil)
+}
+
+func prune(v interface{}, scalars bool, pointer []string) interface{} {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		for k, item := range value {
+			if k == "security" {
+				continue
+			}
+			item = prune(item, scalars, append(pointer[:len(pointer):len(pointer)], k))
+			if isEmpty(item, scalars) && !requiredKey(pointer, k) {
+				delete(value, k)
+				continue
+			}
+			value[k] = item
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = prune(item, scalars, append(pointer[:len(pointer):len(pointer)], strconv.Itoa(i)))
+		}
+	}
+	return v
+}
+
+// requiredKey reports whether the key of the object at the json pointer is required by the swagger 2.0 specification:
+// the version, info and paths of the definition, the title and version of its info,
+// the responses of an operation and the description of a response
+func requiredKey(pointer []string, key string) bool {
+	switch {
+	case len(pointer) == 0:
+		return key == "swagger" || key == "info" || key == "paths"
+	case len(pointer) == 1 && pointer[0] == "info":
+		return key == "title" || key == "version"
+	case len(pointer) == 2 && pointer[0] == "responses",
+		len(pointer) == 5 && pointer[0] == "paths" && pointer[3] == "responses":
+		return key == "description"
+	case len(pointer) == 3 && pointer[0] == "paths":
+		return key == "responses"
+	}
+	return false
+}
+
+// typedEnums converts the enum values of the decoded json value to the type of their schema or parameter,
+// e.g. enum:"1,2,3" on an integer field; values which cannot be converted are left as is
+func typedEnums(v interface{}) {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		if enum, ok := value["enum"].([]interface{}); ok {
+			for i, item := range enum {
+				enum[i] = typedValue(value["type"], item)
+			}
+		}
+		for _, item := range value {
+			typedEnums(item)
+		}
+	case []interface{}:
+		for _, item := range value {
+			typedEnums(item)
+		}
+	}
+}
+
+func isEmpty(v interface{}, scalars bool) bool {
+	switch value := v.(type) {
+	case map[string]interface{}:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	case string:
+		return scalars && value == ""
+	case nil:
+		return scalars
+	}
+	return false
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) == 0 || len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
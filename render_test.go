@@ -0,0 +1,339 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag/types"
+)
+
+func TestAPI_Encode(t *testing.T) {
+	api := New()
+	var buf bytes.Buffer
+	assert.Nil(t, api.Encode(&buf))
+
+	expected, _ := json.Marshal(api)
+	assert.Equal(t, string(expected)+"\n", buf.String())
+}
+
+func TestAPI_EncodeCompact(t *testing.T) {
+	api := New()
+	api.Compact = true
+	api.Produces = []string{"application/json"}
+	api.Consumes = []string{"application/json"}
+	api.AddEndpoint(
+		&Endpoint{
+			Path:     "/json",
+			Method:   http.MethodGet,
+			Produces: []string{"application/json"},
+			Consumes: []string{"application/json"},
+			Security: &SecurityRequirement{DisableSecurity: true},
+		},
+		&Endpoint{
+			Path:     "/xml",
+			Method:   http.MethodGet,
+			Produces: []string{"application/xml"},
+			Consumes: []string{"application/json"},
+			Tags:     []string{},
+		},
+	)
+
+	var buf bytes.Buffer
+	assert.Nil(t, api.Encode(&buf))
+
+	var doc map[string]interface{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &doc))
+	paths := doc["paths"].(map[string]interface{})
+
+	get := paths["/json"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.NotContains(t, get, "produces")
+	assert.NotContains(t, get, "consumes")
+	assert.Equal(t, []interface{}{}, get["security"])
+
+	get = paths["/xml"].(map[string]interface{})["get"].(map[string]interface{})
+	assert.Equal(t, []interface{}{"application/xml"}, get["produces"])
+	assert.NotContains(t, get, "consumes")
+
+	// the original definition must stay untouched
+	assert.Equal(t, []string{"application/json"}, api.Paths["/json"].Get.Produces)
+}
+
+func TestAPI_EncodeCompactDefaults(t *testing.T) {
+	api := New()
+	api.Compact = true
+	jsonTypes := []string{"application/json"}
+	api.AddEndpoint(
+		&Endpoint{Path: "/pets", Method: http.MethodGet, Produces: jsonTypes, Consumes: jsonTypes},
+		&Endpoint{Path: "/pets", Method: http.MethodPost, Produces: jsonTypes, Consumes: []string{"multipart/form-data"}},
+		&Endpoint{Path: "/export", Method: http.MethodGet, Produces: []string{"text/csv"}, Consumes: jsonTypes},
+	)
+
+	var buf bytes.Buffer
+	assert.Nil(t, api.Encode(&buf))
+	doc := &API{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), doc))
+
+	// the media types most operations share become the defaults
+	assert.Equal(t, jsonTypes, doc.Produces)
+	assert.Equal(t, jsonTypes, doc.Consumes)
+	assert.Nil(t, doc.Paths["/pets"].Get.Produces)
+	assert.Nil(t, doc.Paths["/pets"].Get.Consumes)
+	assert.Equal(t, []string{"multipart/form-data"}, doc.Paths["/pets"].Post.Consumes)
+	assert.Equal(t, []string{"text/csv"}, doc.Paths["/export"].Get.Produces)
+
+	// an operation without media types would inherit the defaults
+	api.AddEndpoint(&Endpoint{Path: "/health", Method: http.MethodGet})
+	buf.Reset()
+	assert.Nil(t, api.Encode(&buf))
+	doc = &API{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), doc))
+	assert.Nil(t, doc.Produces)
+	assert.Equal(t, jsonTypes, doc.Paths["/pets"].Get.Produces)
+}
+
+func TestAPI_EncodeRenderOptions(t *testing.T) {
+	api := New()
+	api.Info.Description = "<b>a & b</b>"
+	api.Tags = []Tag{{Name: "tag"}}
+
+	var buf bytes.Buffer
+	assert.Nil(t, api.Encode(&buf))
+	assert.Contains(t, buf.String(), `\u003cb\u003ea \u0026 b\u003c/b\u003e`)
+	assert.Contains(t, buf.String(), `"description":""`)
+
+	api.Render = RenderOptions{
+		Indent:            "  ",
+		DisableHTMLEscape: true,
+		OmitEmpty:         true,
+	}
+	buf.Reset()
+	assert.Nil(t, api.Encode(&buf))
+	assert.Contains(t, buf.String(), "\n  \"info\": {")
+	assert.Contains(t, buf.String(), `<b>a & b</b>`)
+	assert.NotContains(t, buf.String(), `"description": ""`)
+}
+
+// assertSwagger asserts the keys required by the swagger 2.0 specification are present in the document
+func assertSwagger(t *testing.T, doc map[string]interface{}) {
+	for _, k := range []string{"swagger", "info", "paths"} {
+		assert.Contains(t, doc, k)
+	}
+	paths, _ := doc["paths"].(map[string]interface{})
+	for p, item := range paths {
+		for method, op := range item.(map[string]interface{}) {
+			op, ok := op.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			responses, ok := op["responses"].(map[string]interface{})
+			if assert.True(t, ok, "responses of %s %s", method, p) {
+				for code, r := range responses {
+					if _, ok := r.(map[string]interface{})["$ref"]; ok {
+						continue
+					}
+					assert.Contains(t, r, "description", "response %s of %s %s", code, method, p)
+				}
+			}
+		}
+	}
+}
+
+func TestPruneEmpty(t *testing.T) {
+	var doc map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(`{
+		"swagger": "2.0",
+		"info": {"title": "pets", "version": "1.0"},
+		"paths": {"/pets": {"get": {"tags": [], "responses": {}}}},
+		"definitions": {}
+	}`), &doc))
+
+	pruneEmpty(doc, false)
+	assert.NotContains(t, doc, "definitions")
+	get := doc["paths"].(map[string]interface{})["/pets"].(map[string]interface{})["get"]
+	assert.Equal(t, map[string]interface{}{"responses": map[string]interface{}{}}, get)
+	assertSwagger(t, doc)
+
+	doc = map[string]interface{}{"paths": map[string]interface{}{}}
+	pruneEmpty(doc, false)
+	assert.Equal(t, map[string]interface{}{"paths": map[string]interface{}{}}, doc)
+}
+
+func TestAPI_EncodeOmitEmptyValid(t *testing.T) {
+	api := New()
+	api.Compact = true
+	api.Render.OmitEmpty = true
+	api.Info.Description = ""
+	api.Responses = map[string]Response{"NotFound": {}}
+	api.AddEndpoint(&Endpoint{
+		Path:      "/pets",
+		Method:    http.MethodGet,
+		Responses: map[string]Response{"204": {}, "404": {Ref: "#/responses/NotFound"}},
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	var doc map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+	assertSwagger(t, doc)
+	assert.Equal(t, map[string]interface{}{"description": ""}, doc["responses"].(map[string]interface{})["NotFound"])
+	assert.NotContains(t, doc["info"], "description")
+}
+
+type Ticket struct {
+	Priority int     `json:"priority" enum:"1,2,3"`
+	Weight   float64 `json:"weight" enum:"0.5,1"`
+	Urgent   bool    `json:"urgent" enum:"true"`
+	Status   string  `json:"status" enum:"open,closed"`
+	Levels   []int   `json:"levels"`
+}
+
+func TestAPI_EncodeTypedEnums(t *testing.T) {
+	api := New()
+	api.Render.TypedEnums = true
+	api.AddEndpoint(&Endpoint{
+		Path:   "/tickets",
+		Method: http.MethodGet,
+		Parameters: []Parameter{
+			{In: "query", Name: "priority", Type: types.Integer, Enum: []string{"1", "2"}},
+		},
+		Responses: map[string]Response{"200": {Description: "ok", Schema: MakeSchema(Ticket{})}},
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	var doc struct {
+		Definitions map[string]struct {
+			Properties map[string]struct {
+				Enum []interface{} `json:"enum"`
+			} `json:"properties"`
+		} `json:"definitions"`
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Enum []interface{} `json:"enum"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &doc))
+
+	properties := doc.Definitions[DefinitionName(Ticket{})].Properties
+	assert.Equal(t, []interface{}{float64(1), float64(2), float64(3)}, properties["priority"].Enum)
+	assert.Equal(t, []interface{}{0.5, float64(1)}, properties["weight"].Enum)
+	assert.Equal(t, []interface{}{true}, properties["urgent"].Enum)
+	assert.Equal(t, []interface{}{"open", "closed"}, properties["status"].Enum)
+
+	params := doc.Paths["/tickets"]["get"].Parameters
+	assert.Equal(t, []interface{}{float64(1), float64(2)}, params[0].Enum)
+}
+
+func TestAPI_EncodeMethodNotAllowed(t *testing.T) {
+	api := New()
+	api.MethodNotAllowed = true
+	api.AddEndpoint(
+		&Endpoint{Path: "/pets", Method: http.MethodPost},
+		&Endpoint{Path: "/pets", Method: http.MethodGet, Responses: map[string]Response{
+			"200": {Description: "success"},
+		}},
+	)
+
+	doc := decodeDoc(t, api)
+	for _, e := range []*Endpoint{doc.Paths["/pets"].Get, doc.Paths["/pets"].Post} {
+		r := e.Responses["405"]
+		assert.Equal(t, "Method Not Allowed", r.Description)
+		assert.Equal(t, "the allowed methods: GET, POST", r.Headers["Allow"].Description)
+	}
+	assert.Len(t, doc.Paths["/pets"].Get.Responses, 2)
+	assert.Len(t, api.Paths["/pets"].Get.Responses, 1)
+}
+
+func TestAPI_EncodeContext(t *testing.T) {
+	api := New()
+	var buf bytes.Buffer
+	assert.NoError(t, api.EncodeContext(context.Background(), &buf))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	buf.Reset()
+	assert.Equal(t, context.Canceled, api.EncodeContext(ctx, &buf))
+	assert.Empty(t, buf.String())
+
+	api.OpenAPI = OpenAPI3
+	assert.Equal(t, context.Canceled, api.EncodeContext(ctx, &buf))
+	assert.Equal(t, context.Canceled, api.EncodeYAMLContext(ctx, &buf))
+}
+
+func TestAPI_EncodeGlobalResponses(t *testing.T) {
+	api := New()
+	api.GlobalResponses = map[string]Response{
+		"401": {Description: "unauthorized"},
+		"500": {Description: "internal error"},
+	}
+	api.AddEndpoint(
+		&Endpoint{Path: "/pets", Method: http.MethodGet, Responses: map[string]Response{
+			"200": {Description: "success"},
+			"500": {Description: "pets unavailable"},
+		}},
+		&Endpoint{Path: "/pets", Method: http.MethodPost, UseGlobalResponses: true, GlobalResponses: []string{"401"}},
+	)
+
+	doc := decodeDoc(t, api)
+	get := doc.Paths["/pets"].Get.Responses
+	assert.Len(t, get, 3)
+	assert.Equal(t, "unauthorized", get["401"].Description)
+	assert.Equal(t, "pets unavailable", get["500"].Description)
+	assert.Len(t, doc.Paths["/pets"].Post.Responses, 1)
+	assert.Len(t, api.Paths["/pets"].Get.Responses, 2)
+
+	api.GlobalResponsesOptIn = true
+	doc = decodeDoc(t, api)
+	assert.Len(t, doc.Paths["/pets"].Get.Responses, 2)
+	assert.Equal(t, "unauthorized", doc.Paths["/pets"].Post.Responses["401"].Description)
+}
+
+func TestAPI_EncodeResponseRef(t *testing.T) {
+	api := New()
+	api.Responses = map[string]Response{"NotFound": {Description: "not found"}}
+	api.AddEndpoint(&Endpoint{
+		Path:      "/pets/{id}",
+		Method:    http.MethodGet,
+		Responses: map[string]Response{"404": {Ref: "#/responses/NotFound", Description: "ignored"}},
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	assert.Contains(t, buf.String(), `"responses":{"404":{"$ref":"#/responses/NotFound"}}`)
+	assert.Contains(t, buf.String(), `"responses":{"NotFound":{"description":"not found"}}`)
+}
+
+func TestAPI_EncodeParameterRef(t *testing.T) {
+	api := New()
+	api.Parameters = map[string]Parameter{"page": {In: "query", Name: "page", Type: types.Integer}}
+	api.AddEndpoint(&Endpoint{
+		Path:       "/pets",
+		Method:     http.MethodGet,
+		Parameters: []Parameter{{Ref: "#/parameters/page", Name: "ignored"}},
+	})
+
+	var buf bytes.Buffer
+	assert.NoError(t, api.Encode(&buf))
+	assert.Contains(t, buf.String(), `"parameters":[{"$ref":"#/parameters/page"}]`)
+	assert.Contains(t, buf.String(), `"parameters":{"page":{"in":"query","name":"page","required":false,"type":"integer"}}`)
+}
@@ -0,0 +1,120 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"html"
+	"net/http"
+)
+
+// Renderer renders the documentation page of the spec at specURL
+type Renderer interface {
+	Page(specURL string) ([]byte, error)
+}
+
+// RendererFunc adapts a function to a Renderer
+type RendererFunc func(specURL string) ([]byte, error)
+
+// Page calls f(specURL)
+func (f RendererFunc) Page(specURL string) ([]byte, error) {
+	return f(specURL)
+}
+
+// The assets loaded by default by the renderers, pinned to a release
+const (
+	RapiDocScriptURL      = "https://unpkg.com/rapidoc@9.3.4/dist/rapidoc-min.js"
+	ElementsScriptURL     = "https://unpkg.com/@stoplight/elements@8.0.0/web-components.min.js"
+	ElementsStylesheetURL = "https://unpkg.com/@stoplight/elements@8.0.0/styles.min.css"
+)
+
+var (
+	// RendererRedoc renders the spec with redoc, see RedocHandler to configure the page
+	RendererRedoc Renderer = RendererFunc(newRedocConfig().page)
+	// RendererRapiDoc renders the spec with RapiDoc, see RapiDoc to configure its script
+	RendererRapiDoc = RapiDoc(Asset{URL: RapiDocScriptURL})
+	// RendererElements renders the spec with Stoplight Elements, see Elements to configure its assets
+	RendererElements = Elements(Asset{URL: ElementsScriptURL}, Asset{URL: ElementsStylesheetURL})
+)
+
+// Asset is a script or a stylesheet of a renderer page, e.g. a copy served by the api;
+// Integrity is its subresource integrity hash, e.g. sha384-..., checked by the browser if set
+type Asset struct {
+	URL       string
+	Integrity string
+}
+
+// RapiDoc returns the Renderer of RapiDoc loading its script from the asset
+func RapiDoc(script Asset) Renderer {
+	return RendererFunc(func(specURL string) ([]byte, error) {
+		return rapiDocPage(specURL, script), nil
+	})
+}
+
+// Elements returns the Renderer of Stoplight Elements loading its script and its stylesheet from the assets
+func Elements(script, stylesheet Asset) Renderer {
+	return RendererFunc(func(specURL string) ([]byte, error) {
+		return elementsPage(specURL, script, stylesheet), nil
+	})
+}
+
+// RenderHandler returns a http.Handler serving the page of the renderer for the spec at specURL;
+// the api selects its renderer with option.Renderer
+func RenderHandler(specURL string, renderer Renderer) http.Handler {
+	if renderer == nil {
+		renderer = RendererRedoc
+	}
+	page, err := renderer.Page(specURL)
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write(page)
+	})
+}
+
+func rapiDocPage(specURL string, script Asset) []byte {
+	return []byte(`<!DOCTYPE html>
+<html>
+<head>
+  <title>API Reference</title>
+  <meta charset="utf-8"/>
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <script type="module" src="` + html.EscapeString(script.URL) + `"` + integrityAttrs(script.Integrity) + `></script>
+</head>
+<body>
+  <rapi-doc spec-url="` + html.EscapeString(specURL) + `" render-style="read"></rapi-doc>
+</body>
+</html>
+`)
+}
+
+func elementsPage(specURL string, script, stylesheet Asset) []byte {
+	return []byte(`<!DOCTYPE html>
+<html>
+<head>
+  <title>API Reference</title>
+  <meta charset="utf-8"/>
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  ` + scriptTag(script.URL, script.Integrity) + `
+  <link rel="stylesheet" href="` + html.EscapeString(stylesheet.URL) + `"` + integrityAttrs(stylesheet.Integrity) + `>
+</head>
+<body>
+  <elements-api apiDescriptionUrl="` + html.EscapeString(specURL) + `" router="hash" layout="sidebar"></elements-api>
+</body>
+</html>
+`)
+}
@@ -0,0 +1,77 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		renderer Renderer
+		contains string
+	}{
+		{name: "default", contains: `Redoc.init("swagger.json"`},
+		{name: "rapidoc", renderer: RendererRapiDoc, contains: `<rapi-doc spec-url="swagger.json"`},
+		{name: "elements", renderer: RendererElements, contains: `<elements-api apiDescriptionUrl="swagger.json"`},
+		{name: "rapidoc pinned", renderer: RendererRapiDoc, contains: `<script type="module" src="` + RapiDocScriptURL + `"></script>`},
+		{name: "elements pinned", renderer: RendererElements, contains: `<link rel="stylesheet" href="` + ElementsStylesheetURL + `">`},
+		{
+			name:     "rapidoc asset",
+			renderer: RapiDoc(Asset{URL: "/assets/rapidoc-min.js", Integrity: "sha384-abc"}),
+			contains: `<script type="module" src="/assets/rapidoc-min.js" integrity="sha384-abc" crossorigin="anonymous"></script>`,
+		},
+		{
+			name:     "elements assets",
+			renderer: Elements(Asset{URL: "/assets/web-components.min.js"}, Asset{URL: "/assets/styles.min.css", Integrity: "sha384-def"}),
+			contains: `<script src="/assets/web-components.min.js"></script>
+  <link rel="stylesheet" href="/assets/styles.min.css" integrity="sha384-def" crossorigin="anonymous">`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			RenderHandler("swagger.json", tt.renderer).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Body.String(), tt.contains)
+		})
+	}
+}
+
+func TestRenderHandler_Error(t *testing.T) {
+	failing := RendererFunc(func(string) ([]byte, error) { return nil, errors.New("no page") })
+	w := httptest.NewRecorder()
+	RenderHandler("swagger.json", failing).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs", nil))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestHandlers_Docs(t *testing.T) {
+	api := New()
+	api.Renderer = RendererRapiDoc
+	mux := http.NewServeMux()
+	api.Handlers().Mount(mux, "/docs")
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/docs", nil))
+	assert.Contains(t, w.Body.String(), `<rapi-doc spec-url="swagger.json"`)
+	assert.NotNil(t, api.Clone().Renderer)
+}
@@ -0,0 +1,184 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import "strings"
+
+// Resolved returns a copy of the api in which the references to definitions, parameters and responses are replaced
+// by resolved copies of them, so that validators, mock servers and exporters need not chase references;
+// a reference closing a cycle is kept, and the definitions are kept for it to point to
+func (a *API) Resolved() *API {
+	r := &resolver{
+		definitions: a.Definitions,
+		parameters:  a.Parameters,
+		responses:   a.Responses,
+		visiting:    make(map[string]bool),
+	}
+
+	doc := a.Clone()
+	if a.Paths != nil {
+		doc.Paths = make(map[string]*Endpoints, len(a.Paths))
+		for p, endpoints := range a.Paths {
+			v := &Endpoints{}
+			endpoints.Walk(func(endpoint *Endpoint) {
+				v.set(endpoint.Method, r.endpoint(endpoint))
+			})
+			doc.Paths[p] = v
+		}
+	}
+	if a.Parameters != nil {
+		doc.Parameters = make(map[string]Parameter, len(a.Parameters))
+		for name, p := range a.Parameters {
+			p.Schema = r.schema(p.Schema)
+			doc.Parameters[name] = p
+		}
+	}
+	if a.Responses != nil {
+		doc.Responses = make(map[string]Response, len(a.Responses))
+		for name, response := range a.Responses {
+			response.Schema = r.schema(response.Schema)
+			doc.Responses[name] = response
+		}
+	}
+	if a.Definitions != nil {
+		doc.Definitions = make(map[string]Object, len(a.Definitions))
+		for name, obj := range a.Definitions {
+			r.visiting[name] = true
+			obj.Properties = r.properties(obj.Properties)
+			delete(r.visiting, name)
+			doc.Definitions[name] = obj
+		}
+	}
+	return doc
+}
+
+type resolver struct {
+	definitions map[string]Object
+	parameters  map[string]Parameter
+	responses   map[string]Response
+	visiting    map[string]bool
+}
+
+func (r *resolver) endpoint(endpoint *Endpoint) *Endpoint {
+	e := *endpoint
+	if endpoint.Parameters != nil {
+		e.Parameters = make([]Parameter, len(endpoint.Parameters))
+		for i, p := range resolveParameters(endpoint.Parameters, r.parameters) {
+			p.Schema = r.schema(p.Schema)
+			e.Parameters[i] = p
+		}
+	}
+	if endpoint.Responses != nil {
+		e.Responses = make(map[string]Response, len(endpoint.Responses))
+		for code, response := range endpoint.Responses {
+			if response.Ref != "" {
+				if named, ok := r.responses[strings.TrimPrefix(response.Ref, responsesPrefix)]; ok {
+					response = named
+				}
+			}
+			response.Schema = r.schema(response.Schema)
+			e.Responses[code] = response
+		}
+	}
+	return &e
+}
+
+// resolveParameters returns the parameters in which the references to the named parameters are replaced
+// by the parameters they reference; the unknown references are kept
+func resolveParameters(params []Parameter, named map[string]Parameter) []Parameter {
+	var result []Parameter
+	for i, p := range params {
+		if p.Ref == "" {
+			continue
+		}
+		v, ok := named[strings.TrimPrefix(p.Ref, parametersPrefix)]
+		if !ok {
+			continue
+		}
+		if result == nil {
+			result = append(make([]Parameter, 0, len(params)), params...)
+		}
+		result[i] = v
+	}
+	if result == nil {
+		return params
+	}
+	return result
+}
+
+// enter resolves the definition referenced by ref, unless it is unknown or closes a cycle
+func (r *resolver) enter(ref string) (Object, bool) {
+	name := refName(ref)
+	obj, ok := r.definitions[name]
+	if !ok || r.visiting[name] {
+		return Object{}, false
+	}
+	r.visiting[name] = true
+	obj.Properties = r.properties(obj.Properties)
+	delete(r.visiting, name)
+	return obj, true
+}
+
+func (r *resolver) schema(schema *Schema) *Schema {
+	if schema == nil {
+		return nil
+	}
+	s := *schema
+	if obj, ok := r.enter(s.Ref); s.Ref != "" && ok {
+		s.Ref = ""
+		s.Type = obj.Type
+		s.Format = obj.Format
+		s.Required = obj.Required
+		s.Properties = obj.Properties
+	}
+	s.Items = r.items(s.Items)
+	return &s
+}
+
+func (r *resolver) items(items *Items) *Items {
+	if items == nil || items.Ref == "" {
+		return items
+	}
+	v := *items
+	if obj, ok := r.enter(v.Ref); ok {
+		v.Ref = ""
+		v.Type = obj.Type
+		v.Format = obj.Format
+		v.Required = obj.Required
+		v.Properties = obj.Properties
+	}
+	return &v
+}
+
+func (r *resolver) properties(properties map[string]Property) map[string]Property {
+	if properties == nil {
+		return nil
+	}
+	result := make(map[string]Property, len(properties))
+	for k, p := range properties {
+		if obj, ok := r.enter(p.Ref); p.Ref != "" && ok {
+			p.Ref = ""
+			p.Type = obj.Type
+			p.Format = obj.Format
+			p.Required = obj.Required
+			p.Properties = obj.Properties
+		} else {
+			p.Properties = r.properties(p.Properties)
+		}
+		p.Items = r.items(p.Items)
+		result[k] = p
+	}
+	return result
+}
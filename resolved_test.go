@@ -0,0 +1,100 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TreeNode struct {
+	Label    string      `json:"label" required:""`
+	Children []*TreeNode `json:"children"`
+	Home     *Home       `json:"home"`
+}
+
+func TestAPI_Resolved(t *testing.T) {
+	api := New()
+	api.AddEndpoint(&Endpoint{
+		Path:   "/trees",
+		Method: http.MethodPost,
+		Parameters: []Parameter{
+			{In: "body", Name: "body", Schema: MakeSchema(TreeNode{})},
+		},
+		Responses: map[string]Response{
+			"200": {Description: "ok", Schema: MakeSchema([]Home{})},
+		},
+	})
+
+	doc := api.Resolved()
+	body := doc.Paths["/trees"].Post.Parameters[0].Schema
+	assert.Empty(t, body.Ref)
+	assert.Equal(t, "object", body.Type)
+	assert.Equal(t, []string{"label"}, body.Required)
+	// the reference closing the cycle is kept
+	assert.Equal(t, makeRef(DefinitionName(TreeNode{})), body.Properties["children"].Items.Ref)
+	home := body.Properties["home"]
+	assert.Empty(t, home.Ref)
+	assert.Equal(t, Property{Type: "string"}, withoutGoType(home.Properties["street"].Properties["name"]))
+
+	response := doc.Paths["/trees"].Post.Responses["200"].Schema
+	assert.Equal(t, "array", response.Type)
+	assert.Empty(t, response.Items.Ref)
+	assert.Contains(t, response.Items.Properties, "street")
+
+	assert.Empty(t, doc.Definitions[DefinitionName(Home{})].Properties["street"].Ref)
+
+	// the api itself is left untouched
+	assert.NotEmpty(t, api.Paths["/trees"].Post.Parameters[0].Schema.Ref)
+	assert.NotEmpty(t, api.Definitions[DefinitionName(Home{})].Properties["street"].Ref)
+}
+
+func TestAPI_ResolvedResponses(t *testing.T) {
+	api := New()
+	api.Responses = map[string]Response{
+		"NotFound": {Description: "not found", Schema: MakeSchema(Home{})},
+	}
+	api.AddDefinitions(Home{})
+	api.AddEndpoint(&Endpoint{
+		Path:      "/homes/{id}",
+		Method:    http.MethodGet,
+		Responses: map[string]Response{"404": {Ref: "#/responses/NotFound"}},
+	})
+
+	doc := api.Resolved()
+	response := doc.Paths["/homes/{id}"].Get.Responses["404"]
+	assert.Empty(t, response.Ref)
+	assert.Equal(t, "not found", response.Description)
+	assert.Contains(t, response.Schema.Properties, "street")
+	assert.Contains(t, doc.Responses["NotFound"].Schema.Properties, "street")
+	assert.Equal(t, "#/responses/NotFound", api.Paths["/homes/{id}"].Get.Responses["404"].Ref)
+}
+
+func TestAPI_ResolvedParameters(t *testing.T) {
+	api := New()
+	api.Parameters = map[string]Parameter{"page": {In: "query", Name: "page", Type: "integer"}}
+	api.AddEndpoint(&Endpoint{
+		Path:       "/homes",
+		Method:     http.MethodGet,
+		Parameters: []Parameter{{Ref: "#/parameters/page"}, {Ref: "#/parameters/unknown"}},
+	})
+
+	params := api.Resolved().Paths["/homes"].Get.Parameters
+	assert.Equal(t, "page", params[0].Name)
+	assert.Equal(t, "#/parameters/unknown", params[1].Ref)
+	assert.Equal(t, "#/parameters/page", api.Paths["/homes"].Get.Parameters[0].Ref)
+}
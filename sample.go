@@ -0,0 +1,102 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zc2638/swag/types"
+)
+
+// SchemaFromSample infers the schema of a sample json document, e.g. a third party webhook payload;
+// nested objects are described inline, arrays by their first non-null element, null values by a nullable schema
+// without type, and the scalar values of the sample become the examples
+func SchemaFromSample(jsonBytes []byte) (*Object, error) {
+	decoder := json.NewDecoder(bytes.NewReader(jsonBytes))
+	decoder.UseNumber()
+
+	var sample interface{}
+	if err := decoder.Decode(&sample); err != nil {
+		return nil, fmt.Errorf("invalid sample: %v", err)
+	}
+
+	p := sampleProperty(sample)
+	obj := &Object{
+		Type:       p.Type,
+		Format:     p.Format,
+		Properties: p.Properties,
+	}
+	if p.Items != nil {
+		obj.IsArray = true
+		obj.Type = p.Items.Type
+		obj.Format = p.Items.Format
+		obj.Properties = p.Items.Properties
+	}
+	return obj, nil
+}
+
+func sampleProperty(v interface{}) Property {
+	var p Property
+	switch value := v.(type) {
+	case map[string]interface{}:
+		p.Type = "object"
+		p.Properties = make(map[string]Property, len(value))
+		for k, item := range value {
+			p.Properties[k] = sampleProperty(item)
+		}
+	case []interface{}:
+		p.Type = types.Array.String()
+		p.Items = &Items{}
+		for _, item := range value {
+			if item == nil {
+				continue
+			}
+			elem := sampleProperty(item)
+			p.Items.Type = elem.Type
+			p.Items.Format = elem.Format
+			p.Items.Properties = elem.Properties
+			p.Items.Items = elem.Items
+			break
+		}
+	case json.Number:
+		p.Example = value.String()
+		if strings.ContainsAny(value.String(), ".eE") {
+			p.Type = types.Number.String()
+			p.Format = "double"
+		} else {
+			p.Type = types.Integer.String()
+			p.Format = "int64"
+		}
+	case string:
+		p.Type = types.String.String()
+		p.Example = value
+		if _, err := time.Parse(time.RFC3339, value); err == nil {
+			p.Format = "date-time"
+		} else if _, err := time.Parse("2006-01-02", value); err == nil {
+			p.Format = "date"
+		}
+	case bool:
+		p.Type = types.Boolean.String()
+		p.Example = fmt.Sprint(value)
+	case nil:
+		// the type of a null value is unknown
+		p.Nullable = true
+	}
+	return p
+}
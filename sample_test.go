@@ -0,0 +1,74 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSample = `{
+	"id": 42,
+	"amount": 9.5,
+	"paid": true,
+	"created": "2022-05-01T10:00:00Z",
+	"day": "2022-05-01",
+	"customer": {"name": "Alice"},
+	"lines": [null, {"sku": "a-1"}],
+	"tags": [],
+	"matrix": [[1, 2], [3]],
+	"nulls": [null],
+	"extra": null
+}`
+
+func TestSchemaFromSample(t *testing.T) {
+	obj, err := SchemaFromSample([]byte(testSample))
+	assert.NoError(t, err)
+	assert.False(t, obj.IsArray)
+	assert.Equal(t, "object", obj.Type)
+	assert.Equal(t, map[string]Property{
+		"id":      {Type: "integer", Format: "int64", Example: "42"},
+		"amount":  {Type: "number", Format: "double", Example: "9.5"},
+		"paid":    {Type: "boolean", Example: "true"},
+		"created": {Type: "string", Format: "date-time", Example: "2022-05-01T10:00:00Z"},
+		"day":     {Type: "string", Format: "date", Example: "2022-05-01"},
+		"customer": {Type: "object", Properties: map[string]Property{
+			"name": {Type: "string", Example: "Alice"},
+		}},
+		"lines": {Type: "array", Items: &Items{Type: "object", Properties: map[string]Property{
+			"sku": {Type: "string", Example: "a-1"},
+		}}},
+		"tags": {Type: "array", Items: &Items{}},
+		"matrix": {Type: "array", Items: &Items{Type: "array", Items: &Items{
+			Type: "integer", Format: "int64",
+		}}},
+		"nulls": {Type: "array", Items: &Items{}},
+		"extra": {Nullable: true},
+	}, obj.Properties)
+
+	obj, err = SchemaFromSample([]byte(`[{"id": 1}]`))
+	assert.NoError(t, err)
+	assert.True(t, obj.IsArray)
+	assert.Equal(t, "object", obj.Type)
+	assert.Contains(t, obj.Properties, "id")
+
+	obj, err = SchemaFromSample([]byte(`null`))
+	assert.NoError(t, err)
+	assert.Equal(t, "", obj.Type)
+
+	_, err = SchemaFromSample([]byte(`{"id":`))
+	assert.Error(t, err)
+}
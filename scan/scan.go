@@ -0,0 +1,195 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package scan finds the exported structs of go packages, and generates the code
+// registering them as definitions, so that models only referenced indirectly,
+// e.g. via interfaces or raw json, still appear in the spec:
+//
+//	pkgs, _ := scan.Scan(nil, "./models/...")
+//	for _, pkg := range pkgs {
+//		f, _ := os.Create(filepath.Join(pkg.Dir, "swag_models.go"))
+//		_ = pkg.Generate(f)
+//	}
+//
+// and then
+//
+//	api := swag.New(option.Definitions(models.SwagModels()...))
+package scan
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Filter reports whether the exported struct with the specified name should be registered
+type Filter func(name string) bool
+
+// MatchRegexp returns a filter accepting the structs whose name matches the regular expression
+func MatchRegexp(expr string) Filter {
+	re := regexp.MustCompile(expr)
+	return re.MatchString
+}
+
+// Package represents a scanned go package
+type Package struct {
+	// Name is the name of the package
+	Name string
+	// Dir is the directory of the package
+	Dir string
+	// Models are the names of the exported structs accepted by the filter, sorted
+	Models []string
+}
+
+// Scan returns the packages found in the directories of the patterns,
+// where a pattern ending with /... also matches all subdirectories;
+// packages without any accepted struct are left out, and a nil filter accepts all structs
+func Scan(filter Filter, patterns ...string) ([]*Package, error) {
+	return ScanContext(context.Background(), filter, patterns...)
+}
+
+// ScanContext is like Scan, but stops with the error of the context once it is done
+func ScanContext(ctx context.Context, filter Filter, patterns ...string) ([]*Package, error) {
+	dirs := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if !strings.HasSuffix(pattern, "/...") {
+			dirs = append(dirs, pattern)
+			continue
+		}
+		root := strings.TrimSuffix(pattern, "/...")
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				return nil
+			}
+			name := info.Name()
+			if path != root && (name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")) {
+				return filepath.SkipDir
+			}
+			dirs = append(dirs, path)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pkgs := make([]*Package, 0, len(dirs))
+	for _, dir := range dirs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		pkg, err := scanDir(dir, filter)
+		if err != nil {
+			return nil, err
+		}
+		if pkg != nil && len(pkg.Models) > 0 {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return pkgs, nil
+}
+
+func scanDir(dir string, filter Filter) (*Package, error) {
+	bp, err := build.ImportDir(dir, 0)
+	if err != nil {
+		var noGo *build.NoGoError
+		if errors.As(err, &noGo) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	pkg := &Package{Name: bp.Name, Dir: dir}
+	fset := token.NewFileSet()
+	for _, name := range bp.GoFiles {
+		filename := filepath.Join(dir, name)
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		f, err := parser.ParseFile(fset, filename, src, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range f.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				ts := spec.(*ast.TypeSpec)
+				if _, ok := ts.Type.(*ast.StructType); !ok || !ts.Name.IsExported() {
+					continue
+				}
+				// generic structs can not be instantiated without type arguments
+				between := src[fset.Position(ts.Name.End()).Offset:fset.Position(ts.Type.Pos()).Offset]
+				if bytes.Contains(between, []byte("[")) {
+					continue
+				}
+				if filter == nil || filter(ts.Name.Name) {
+					pkg.Models = append(pkg.Models, ts.Name.Name)
+				}
+			}
+		}
+	}
+	sort.Strings(pkg.Models)
+	return pkg, nil
+}
+
+// Generate writes the go source of the SwagModels function of the package to w,
+// which returns a prototype of each model for use with option.Definitions
+func (p *Package) Generate(w io.Writer) error {
+	var buf bytes.Buffer
+	if err := modelsTemplate.Execute(&buf, p); err != nil {
+		return err
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(source)
+	return err
+}
+
+var modelsTemplate = template.Must(template.New("models").Parse(`// Code generated by swag/scan. DO NOT EDIT.
+
+package {{.Name}}
+
+// SwagModels returns a prototype of each model of the package, for use with option.Definitions
+func SwagModels() []interface{} {
+	return []interface{}{
+{{- range .Models}}
+		{{.}}{},
+{{- end}}
+	}
+}
+`))
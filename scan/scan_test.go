@@ -0,0 +1,73 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package scan
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScan(t *testing.T) {
+	pkgs, err := Scan(nil, "testdata/models/...")
+	assert.NoError(t, err)
+	assert.Equal(t, []*Package{
+		{Name: "models", Dir: "testdata/models", Models: []string{"Event", "Group", "User"}},
+		{Name: "billing", Dir: filepath.Join("testdata/models", "billing"), Models: []string{"Invoice", "InvoiceRequest"}},
+	}, pkgs)
+
+	pkgs, err = Scan(MatchRegexp("^Invoice$"), "testdata/models/billing", "testdata/models")
+	assert.NoError(t, err)
+	assert.Len(t, pkgs, 1)
+	assert.Equal(t, []string{"Invoice"}, pkgs[0].Models)
+
+	_, err = Scan(nil, "testdata/missing")
+	assert.Error(t, err)
+}
+
+func TestScanContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pkgs, err := ScanContext(ctx, nil, "testdata/models")
+	assert.NoError(t, err)
+	assert.Len(t, pkgs, 1)
+
+	cancel()
+	_, err = ScanContext(ctx, nil, "testdata/models/...")
+	assert.Equal(t, context.Canceled, err)
+	_, err = ScanContext(ctx, nil, "testdata/models")
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestGenerate(t *testing.T) {
+	pkg := &Package{Name: "billing", Models: []string{"Invoice", "Refund"}}
+
+	var buf bytes.Buffer
+	assert.NoError(t, pkg.Generate(&buf))
+	assert.Equal(t, `// Code generated by swag/scan. DO NOT EDIT.
+
+package billing
+
+// SwagModels returns a prototype of each model of the package, for use with option.Definitions
+func SwagModels() []interface{} {
+	return []interface{}{
+		Invoice{},
+		Refund{},
+	}
+}
+`, buf.String())
+}
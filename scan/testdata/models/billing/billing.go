@@ -0,0 +1,9 @@
+package billing
+
+type Invoice struct {
+	Total float64 `json:"total"`
+}
+
+type InvoiceRequest struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,2 @@
+// Package empty has no models
+package empty
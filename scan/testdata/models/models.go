@@ -0,0 +1,24 @@
+package models
+
+import "encoding/json"
+
+type User struct {
+	Name string `json:"name"`
+}
+
+type (
+	Group struct {
+		Users []User `json:"users"`
+	}
+	Event struct {
+		Payload json.RawMessage `json:"payload"`
+	}
+)
+
+type Page[T any] struct {
+	Items []T `json:"items"`
+}
+
+type internal struct{}
+
+type ID string
@@ -0,0 +1,76 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// OperationSummary represents the summary of an operation from the swagger definition
+type OperationSummary struct {
+	OperationID string   `json:"operationId"`
+	Method      string   `json:"method"`
+	Path        string   `json:"path"`
+	Summary     string   `json:"summary,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Deprecated  bool     `json:"deprecated,omitempty"`
+	// Version is the version of a versioned variant, empty for the default operation
+	Version string `json:"version,omitempty"`
+}
+
+// match reports whether the operation contains the keyword, ignoring case
+func (s OperationSummary) match(keyword string) bool {
+	keyword = strings.ToLower(keyword)
+	values := append([]string{s.OperationID, s.Path, s.Summary}, s.Tags...)
+	for _, v := range values {
+		if strings.Contains(strings.ToLower(v), keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// summaries returns the summaries of all operations and versioned variants sorted by path, method and version
+func (a *API) summaries() []OperationSummary {
+	list := make([]OperationSummary, 0)
+	a.walkOperations(func(version string, e *Endpoint) {
+		list = append(list, OperationSummary{
+			OperationID: e.OperationID,
+			Method:      strings.ToUpper(e.Method),
+			Path:        e.Path,
+			Summary:     e.Summary,
+			Tags:        e.Tags,
+			Deprecated:  e.Deprecated,
+			Version:     version,
+		})
+	})
+	return list
+}
+
+// SearchHandler returns a http.HandlerFunc that serves an index of the operations matching the query parameter q;
+// all operations are returned when q is empty. The operations are those of the definition served for the request,
+// so that the endpoints hidden by the filters are not listed
+func (a *API) SearchHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		keyword := strings.TrimSpace(req.URL.Query().Get("q"))
+		result := a.requestDoc(req).rendered().Operations(FilterKeyword(keyword))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(result)
+	}
+}
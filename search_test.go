@@ -0,0 +1,77 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPI_SearchHandler(t *testing.T) {
+	api := New()
+	api.WithTag("billing", "").AddEndpoint(
+		&Endpoint{Path: "/invoices", Method: http.MethodGet, Summary: "List invoices"},
+		&Endpoint{Path: "/invoices", Method: http.MethodPost, Summary: "Create invoice"},
+	)
+	api.AddEndpoint(&Endpoint{Path: "/users", Method: http.MethodGet, Summary: "List users"})
+
+	tests := []struct {
+		name string
+		url  string
+		want []string
+	}{
+		{name: "all", url: "/docs/search", want: []string{"getInvoices", "postInvoices", "getUsers"}},
+		{name: "summary", url: "/docs/search?q=list", want: []string{"getInvoices", "getUsers"}},
+		{name: "tag", url: "/docs/search?q=BILLING", want: []string{"getInvoices", "postInvoices"}},
+		{name: "none", url: "/docs/search?q=nothing", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			api.SearchHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.Equal(t, http.StatusOK, w.Code)
+
+			var result []OperationSummary
+			assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &result))
+			ids := make([]string, 0, len(result))
+			for _, v := range result {
+				ids = append(ids, v.OperationID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestAPI_SearchHandlerFiltered(t *testing.T) {
+	api := New()
+	api.AddEndpoint(
+		&Endpoint{Path: "/users", Method: http.MethodGet, Summary: "List users"},
+		&Endpoint{Path: "/admin/users", Method: http.MethodGet, Summary: "List all users"},
+	)
+	visibility := NewVisibility()
+	visibility.HideEndpoint(http.MethodGet, "/admin/users")
+	api.Filters = append(api.Filters, visibility.Filter())
+
+	w := httptest.NewRecorder()
+	api.SearchHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/docs/search?q=users", nil))
+	var result []OperationSummary
+	assert.Nil(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Len(t, result, 1)
+	assert.Equal(t, "/users", result[0].Path)
+}
@@ -0,0 +1,68 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Redacted replaces the values of the sensitive fields
+const Redacted = "[REDACTED]"
+
+// RedactSample replaces the values of the fields tagged sensitive:"true" in the json sample of the prototype,
+// e.g. a request or response body harvested from traffic, before it is used as an example
+func RedactSample(prototype interface{}, sample []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(sample))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	root := defineObject(prototype, "")
+	redact(v, root.Name, root.IsArray, define(prototype))
+	return json.Marshal(v)
+}
+
+func redact(v interface{}, name string, isArray bool, definitions map[string]Object) {
+	if isArray {
+		list, _ := v.([]interface{})
+		for _, item := range list {
+			redact(item, name, false, definitions)
+		}
+		return
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return
+	}
+	for k, p := range definitions[name].Properties {
+		value, ok := m[k]
+		if !ok || value == nil {
+			continue
+		}
+		switch {
+		case p.Sensitive:
+			m[k] = Redacted
+		case p.Ref != "":
+			redact(value, refName(p.Ref), false, definitions)
+		case p.Items != nil && p.Items.Ref != "":
+			redact(value, refName(p.Items.Ref), true, definitions)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Card struct {
+	Number string `json:"number" sensitive:"true" example:"4111111111111111"`
+	Brand  string `json:"brand" example:"visa"`
+}
+
+type Patient struct {
+	Name  string  `json:"name" swag:"sensitive"`
+	Email string  `json:"email" sensitive:"false"`
+	Card  *Card   `json:"card"`
+	Cards []Card  `json:"cards"`
+	Age   float64 `json:"age" sensitive:""`
+}
+
+func TestSensitive(t *testing.T) {
+	definitions := define(Patient{})
+	patient := definitions[DefinitionName(Patient{})]
+	assert.True(t, patient.Properties["name"].Sensitive)
+	assert.False(t, patient.Properties["email"].Sensitive)
+	assert.True(t, patient.Properties["age"].Sensitive)
+
+	card := definitions[DefinitionName(Card{})]
+	assert.True(t, card.Properties["number"].Sensitive)
+	assert.Empty(t, card.Properties["number"].Example)
+	assert.Equal(t, "visa", card.Properties["brand"].Example)
+
+	data, err := json.Marshal(card)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"x-sensitive":true`)
+}
+
+func TestRedactSample(t *testing.T) {
+	sample := `{
+		"name": "Alice",
+		"email": "alice@example.com",
+		"age": 42,
+		"card": {"number": "4111111111111111", "brand": "visa"},
+		"cards": [{"number": "5500000000000004", "brand": "mastercard"}, null]
+	}`
+	data, err := RedactSample(Patient{}, []byte(sample))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "[REDACTED]",
+		"email": "alice@example.com",
+		"age": "[REDACTED]",
+		"card": {"number": "[REDACTED]", "brand": "visa"},
+		"cards": [{"number": "[REDACTED]", "brand": "mastercard"}, null]
+	}`, string(data))
+
+	data, err = RedactSample([]Card{}, []byte(`[{"number": "1", "brand": "visa"}]`))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"number": "[REDACTED]", "brand": "visa"}]`, string(data))
+
+	_, err = RedactSample(Patient{}, []byte(`{`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,104 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ServeMuxPattern returns the method-aware pattern of the Go 1.22 http.ServeMux matching the swagger path,
+// e.g. GET /v1/pets/{id}; the characters of the parameter names which are not valid in a go identifier
+// are replaced with _, e.g. {pet-id} as {pet_id}
+func ServeMuxPattern(method, path string) string {
+	path = rePath.ReplaceAllStringFunc(path, func(param string) string {
+		name := []rune(param[1 : len(param)-1])
+		for i, r := range name {
+			if r != '_' && !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' && i > 0) {
+				name[i] = '_'
+			}
+		}
+		return "{" + string(name) + "}"
+	})
+	return strings.ToUpper(method) + " " + path
+}
+
+// ParsePattern splits a pattern of the Go 1.22 http.ServeMux, e.g. GET /pets/{id}, into its method and its swagger path;
+// the host is dropped, as well as the {$} end anchor, and the {name...} wildcards are documented as {name}.
+// The method is empty if the pattern has none
+func ParsePattern(pattern string) (method, path string) {
+	path = strings.TrimSpace(pattern)
+	if i := strings.IndexAny(path, " \t"); i >= 0 {
+		method, path = path[:i], strings.TrimSpace(path[i+1:])
+	}
+	if i := strings.Index(path, "/"); i > 0 {
+		path = path[i:]
+	}
+	path = strings.TrimSuffix(path, "{$}")
+	path = strings.ReplaceAll(path, "...}", "}")
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+	return strings.ToUpper(method), path
+}
+
+// ServeMuxOption configures RegisterServeMux
+type ServeMuxOption func(c *serveMuxConfig)
+
+type serveMuxConfig struct {
+	limits bool
+}
+
+// ServeMuxLimits wraps the handler of each endpoint with Limit, enforcing its body size, timeout and file limits
+func ServeMuxLimits() ServeMuxOption {
+	return func(c *serveMuxConfig) {
+		c.limits = true
+	}
+}
+
+// RegisterServeMux registers the handlers of the endpoints on the mux with the method-aware patterns of Go 1.22,
+// see ServeMuxPattern, so that the path parameters are read with http.Request.PathValue.
+// The patterns require Go 1.22 or later and a main module declaring go 1.22 or later, or GODEBUG=httpmuxgo121=0:
+// the legacy http.ServeMux silently registers them as literal paths, e.g. "GET /pets/{id}".
+// The versioned variants of an operation share its pattern, see API.VariantHandler.
+// The handler of an endpoint is a http.Handler or a func(http.ResponseWriter, *http.Request);
+// an endpoint without handler, e.g. documentation only, answers 404, and RegisterServeMux panics on any other handler
+func (a *API) RegisterServeMux(mux *http.ServeMux, opts ...ServeMuxOption) {
+	var c serveMuxConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	a.WalkVersions(func(path, method string, endpoints map[string]*Endpoint) {
+		mux.Handle(ServeMuxPattern(method, path), a.VariantHandler(endpoints, func(e *Endpoint) http.Handler {
+			var h http.Handler
+			switch v := e.Handler.(type) {
+			case nil:
+				h = http.NotFoundHandler()
+			case http.Handler:
+				h = v
+			case func(http.ResponseWriter, *http.Request):
+				h = http.HandlerFunc(v)
+			default:
+				panic(fmt.Sprintf("swag: unsupported handler %T of %s %s", e.Handler, e.Method, e.Path))
+			}
+			if c.limits {
+				h = Limit(e, h)
+			}
+			return h
+		}))
+	})
+}
@@ -0,0 +1,138 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build go1.22
+// +build go1.22
+
+//go:debug httpmuxgo121=0
+
+package swag
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServeMuxPattern(t *testing.T) {
+	assert.Equal(t, "GET /pets", ServeMuxPattern("get", "/pets"))
+	assert.Equal(t, "DELETE /pets/{pet_id}/toys/{toy}", ServeMuxPattern("DELETE", "/pets/{pet-id}/toys/{toy}"))
+	assert.Equal(t, "GET /{_id}", ServeMuxPattern("GET", "/{1id}"))
+}
+
+func TestParsePattern(t *testing.T) {
+	cases := []struct {
+		pattern, method, path string
+	}{
+		{pattern: "GET /pets/{id}", method: "GET", path: "/pets/{id}"},
+		{pattern: "post  /pets/", method: "POST", path: "/pets"},
+		{pattern: "GET example.com/pets/{$}", method: "GET", path: "/pets"},
+		{pattern: "GET /files/{path...}", method: "GET", path: "/files/{path}"},
+		{pattern: "/pets", path: "/pets"},
+		{pattern: "GET /", method: "GET", path: "/"},
+	}
+	for _, c := range cases {
+		method, path := ParsePattern(c.pattern)
+		assert.Equal(t, c.method, method, c.pattern)
+		assert.Equal(t, c.path, path, c.pattern)
+	}
+}
+
+func TestAPI_RegisterServeMux(t *testing.T) {
+	api := New()
+	api.BasePath = "/v1"
+	api.AddEndpoint(
+		&Endpoint{Method: http.MethodGet, Path: "/pets/{pet-id}", Handler: func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "get "+r.PathValue("pet_id"))
+		}},
+		&Endpoint{Method: http.MethodDelete, Path: "/pets/{pet-id}", Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		})},
+	)
+	mux := http.NewServeMux()
+	api.RegisterServeMux(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/pets/12", nil))
+	assert.Equal(t, "get 12", w.Body.String())
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/v1/pets/12", nil))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/v1/pets/12", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	// the endpoints without handler are documentation only
+	api.AddEndpoint(&Endpoint{Method: http.MethodGet, Path: "/docs-only"})
+	mux = http.NewServeMux()
+	api.RegisterServeMux(mux)
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/docs-only", nil))
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	api.AddEndpoint(&Endpoint{Method: http.MethodPost, Path: "/pets", Handler: "nope"})
+	assert.Panics(t, func() { api.RegisterServeMux(http.NewServeMux()) })
+}
+
+func TestAPI_RegisterServeMuxVersions(t *testing.T) {
+	api := New()
+	api.Versioning = &Versioning{Header: "Accept", Versions: []string{"1", "2"}}
+	for _, v := range []string{"", "2"} {
+		version := v
+		e := &Endpoint{Method: http.MethodGet, Path: "/pets", Handler: func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "version "+version)
+		}}
+		if version != "" {
+			e.Versions = []string{version}
+		}
+		api.AddEndpoint(e)
+	}
+	mux := http.NewServeMux()
+	api.RegisterServeMux(mux)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets", nil))
+	assert.Equal(t, "version ", w.Body.String())
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets?version=2", nil))
+	assert.Equal(t, "version 2", w.Body.String())
+}
+
+func TestAPI_RegisterServeMuxLimits(t *testing.T) {
+	api := New()
+	api.AddEndpoint(&Endpoint{Method: http.MethodPost, Path: "/pets/{id}", MaxBodyBytes: 4,
+		Handler: func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			_, _ = io.WriteString(w, r.PathValue("id"))
+		},
+	})
+	mux := http.NewServeMux()
+	api.RegisterServeMux(mux, ServeMuxLimits())
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/pets/42", strings.NewReader("{}")))
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "42", w.Body.String())
+
+	w = httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/pets/42", strings.NewReader("too large")))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
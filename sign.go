@@ -0,0 +1,152 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"hash"
+)
+
+// Signer signs the exported spec
+type Signer interface {
+	// Algorithm returns the jws alg header value, e.g. RS256
+	Algorithm() string
+	// Sign returns the signature of the jws signing input
+	Sign(input []byte) ([]byte, error)
+}
+
+type hmacSigner struct {
+	key []byte
+}
+
+// NewHMACSigner returns a Signer using HMAC SHA-256 (HS256) with the shared key
+func NewHMACSigner(key []byte) Signer {
+	return &hmacSigner{key: key}
+}
+
+func (s *hmacSigner) Algorithm() string {
+	return "HS256"
+}
+
+func (s *hmacSigner) Sign(input []byte) ([]byte, error) {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(input)
+	return mac.Sum(nil), nil
+}
+
+type rsaSigner struct {
+	key *rsa.PrivateKey
+}
+
+// NewRSASigner returns a Signer using RSASSA-PKCS1-v1_5 SHA-256 (RS256) with the private key
+func NewRSASigner(key *rsa.PrivateKey) Signer {
+	return &rsaSigner{key: key}
+}
+
+func (s *rsaSigner) Algorithm() string {
+	return "RS256"
+}
+
+func (s *rsaSigner) Sign(input []byte) ([]byte, error) {
+	digest := sha256.Sum256(input)
+	return rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+}
+
+type ecdsaSigner struct {
+	key       *ecdsa.PrivateKey
+	algorithm string
+	hash      func() hash.Hash
+}
+
+// NewECDSASigner returns a Signer using ECDSA with the private key;
+// the algorithm is ES256, ES384 or ES512 depending on the curve of the key
+func NewECDSASigner(key *ecdsa.PrivateKey) (Signer, error) {
+	switch key.Curve.Params().BitSize {
+	case 256:
+		return &ecdsaSigner{key: key, algorithm: "ES256", hash: sha256.New}, nil
+	case 384:
+		return &ecdsaSigner{key: key, algorithm: "ES384", hash: sha512.New384}, nil
+	case 521:
+		return &ecdsaSigner{key: key, algorithm: "ES512", hash: sha512.New}, nil
+	}
+	return nil, fmt.Errorf("unsupported curve %s", key.Curve.Params().Name)
+}
+
+func (s *ecdsaSigner) Algorithm() string {
+	return s.algorithm
+}
+
+func (s *ecdsaSigner) Sign(input []byte) ([]byte, error) {
+	h := s.hash()
+	h.Write(input)
+	r, sv, err := ecdsa.Sign(rand.Reader, s.key, h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	// jws encodes the signature as the fixed size concatenation of r and s
+	size := (s.key.Curve.Params().BitSize + 7) / 8
+	signature := make([]byte, 2*size)
+	r.FillBytes(signature[:size])
+	sv.FillBytes(signature[size:])
+	return signature, nil
+}
+
+// SignedSpec represents the exported spec together with its detached jws signature
+type SignedSpec struct {
+	// Spec is the json encoding of the swagger definition
+	Spec []byte
+	// Signature is the compact jws with a detached payload, i.e. header..signature;
+	// it is verified by putting the base64url encoded spec between the two dots
+	Signature string
+}
+
+// ExportSigned encodes the swagger definition and signs it with the signer,
+// producing a detached signature that can be verified before importing the spec
+func ExportSigned(api *API, signer Signer) (*SignedSpec, error) {
+	var buf bytes.Buffer
+	if err := api.Encode(&buf); err != nil {
+		return nil, err
+	}
+	spec := buf.Bytes()
+
+	header, err := json.Marshal(map[string]string{
+		"alg": signer.Algorithm(),
+		"cty": "json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	encodedHeader := base64.RawURLEncoding.EncodeToString(header)
+	input := encodedHeader + "." + base64.RawURLEncoding.EncodeToString(spec)
+
+	signature, err := signer.Sign([]byte(input))
+	if err != nil {
+		return nil, err
+	}
+	return &SignedSpec{
+		Spec:      spec,
+		Signature: encodedHeader + ".." + base64.RawURLEncoding.EncodeToString(signature),
+	}, nil
+}
@@ -0,0 +1,95 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// signingInput splits the detached signature and rebuilds the signing input from the spec
+func signingInput(t *testing.T, signed *SignedSpec) (map[string]string, []byte, []byte) {
+	parts := strings.Split(signed.Signature, ".")
+	assert.Len(t, parts, 3)
+	assert.Empty(t, parts[1])
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	assert.NoError(t, err)
+	var header map[string]string
+	assert.NoError(t, json.Unmarshal(headerJSON, &header))
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	assert.NoError(t, err)
+	input := parts[0] + "." + base64.RawURLEncoding.EncodeToString(signed.Spec)
+	return header, []byte(input), signature
+}
+
+func TestExportSigned(t *testing.T) {
+	api := New()
+
+	t.Run("HS256", func(t *testing.T) {
+		key := []byte("secret")
+		signed, err := ExportSigned(api, NewHMACSigner(key))
+		assert.NoError(t, err)
+		assert.Contains(t, string(signed.Spec), `"swagger":"2.0"`)
+
+		header, input, signature := signingInput(t, signed)
+		assert.Equal(t, "HS256", header["alg"])
+		mac := hmac.New(sha256.New, key)
+		mac.Write(input)
+		assert.True(t, hmac.Equal(mac.Sum(nil), signature))
+	})
+
+	t.Run("RS256", func(t *testing.T) {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		assert.NoError(t, err)
+		signed, err := ExportSigned(api, NewRSASigner(key))
+		assert.NoError(t, err)
+
+		header, input, signature := signingInput(t, signed)
+		assert.Equal(t, "RS256", header["alg"])
+		digest := sha256.Sum256(input)
+		assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+	})
+
+	t.Run("ES256", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		signer, err := NewECDSASigner(key)
+		assert.NoError(t, err)
+		signed, err := ExportSigned(api, signer)
+		assert.NoError(t, err)
+
+		header, input, signature := signingInput(t, signed)
+		assert.Equal(t, "ES256", header["alg"])
+		assert.Len(t, signature, 64)
+		digest := sha256.Sum256(input)
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		assert.True(t, ecdsa.Verify(&key.PublicKey, digest[:], r, s))
+	})
+}
@@ -14,6 +14,9 @@
 
 package swag
 
+// DefaultDescription is the placeholder info.description of the api built by New
+const DefaultDescription = "Describe your API"
+
 // New constructs a new api builder
 func New(options ...Option) *API {
 	api := &API{
@@ -21,7 +24,7 @@ func New(options ...Option) *API {
 		Swagger:  "2.0",
 		Schemes:  []string{"http"},
 		Info: Info{
-			Description:    "Describe your API",
+			Description:    DefaultDescription,
 			Title:          "Your API Title",
 			Version:        "SNAPSHOT",
 			TermsOfService: "https://swagger.io/terms/",
@@ -0,0 +1,157 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package swagtest boots test servers serving a documented api
+package swagtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+
+	"github.com/zc2638/swag"
+)
+
+// DocsPrefix is the path prefix the documentation suite of the api is mounted under
+const DocsPrefix = "/docs"
+
+// Bindings maps the operationId of the endpoints to their handlers,
+// a http.Handler or a func(http.ResponseWriter, *http.Request);
+// the endpoints without binding are served by their Handler if it is either, else with 501
+type Bindings map[string]interface{}
+
+type paramsKey struct{}
+
+type route struct {
+	method  string
+	path    string
+	handler http.Handler
+}
+
+// NewServer starts a httptest.Server serving the endpoints of the api with their bindings,
+// enforcing their limits and validating their required and enum parameters, with the docs mounted under DocsPrefix;
+// the other methods of their paths are answered by the fallbacks of the api.
+// The caller closes the server, e.g.
+//
+//	srv := swagtest.NewServer(api, swagtest.Bindings{"getPet": getPet})
+//	defer srv.Close()
+func NewServer(api *swag.API, bindings Bindings) *httptest.Server {
+	return httptest.NewServer(Handler(api, bindings))
+}
+
+// Handler returns the http.Handler of the servers of NewServer;
+// it panics if a binding is neither a http.Handler nor a func(http.ResponseWriter, *http.Request)
+func Handler(api *swag.API, bindings Bindings) http.Handler {
+	var routes []route
+	api.WalkVersions(func(path, method string, endpoints map[string]*swag.Endpoint) {
+		routes = append(routes, route{
+			method: method,
+			path:   path,
+			handler: api.VariantHandler(endpoints, func(e *swag.Endpoint) http.Handler {
+				var h http.Handler
+				if v, ok := bindings[e.OperationID]; ok {
+					if h, ok = handler(v); !ok {
+						panic(fmt.Sprintf("swagtest: unsupported binding %T of %s", v, e.OperationID))
+					}
+				} else if h, ok = handler(e.Handler); !ok {
+					h = http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+						http.Error(w, "no handler bound to "+e.OperationID, http.StatusNotImplemented)
+					})
+				}
+				return swag.Limit(e, Validate(api, e, h))
+			}),
+		})
+	})
+	// the literal segments win over the path parameters, e.g. /pets/mine over /pets/{id}
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].path != routes[j].path {
+			return precedes(routes[i].path, routes[j].path)
+		}
+		return routes[i].method < routes[j].method
+	})
+
+	fallbacks := api.Fallbacks()
+	sort.SliceStable(fallbacks.Paths, func(i, j int) bool {
+		return precedes(fallbacks.Paths[i].Path, fallbacks.Paths[j].Path)
+	})
+	mux := http.NewServeMux()
+	api.Handlers().Mount(mux, DocsPrefix)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		allowed := false
+		for _, rt := range routes {
+			params, ok := swag.MatchPath(rt.path, r.URL.Path)
+			if !ok {
+				continue
+			}
+			if !strings.EqualFold(rt.method, r.Method) {
+				allowed = true
+				continue
+			}
+			rt.handler.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), paramsKey{}, params)))
+			return
+		}
+		if !allowed {
+			if r.URL.Path == DocsPrefix || strings.HasPrefix(r.URL.Path, DocsPrefix+"/") {
+				mux.ServeHTTP(w, r)
+				return
+			}
+			fallbacks.NotFound.ServeHTTP(w, r)
+			return
+		}
+		for _, p := range fallbacks.Paths {
+			if _, ok := swag.MatchPath(p.Path, r.URL.Path); ok {
+				p.Handler.ServeHTTP(w, r)
+				return
+			}
+		}
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	})
+}
+
+// PathParam returns the value of the path parameter of the request served by NewServer
+func PathParam(r *http.Request, name string) string {
+	params, _ := r.Context().Value(paramsKey{}).(map[string]string)
+	return params[name]
+}
+
+func handler(v interface{}) (http.Handler, bool) {
+	switch h := v.(type) {
+	case http.Handler:
+		return h, true
+	case func(http.ResponseWriter, *http.Request):
+		return http.HandlerFunc(h), true
+	}
+	return nil, false
+}
+
+// precedes reports whether the swagger path a is matched before b:
+// at the first segment they differ, a literal segment precedes a path parameter
+func precedes(a, b string) bool {
+	as := strings.Split(strings.Trim(a, "/"), "/")
+	bs := strings.Split(strings.Trim(b, "/"), "/")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if as[i] == bs[i] {
+			continue
+		}
+		ap, bp := strings.HasPrefix(as[i], "{"), strings.HasPrefix(bs[i], "{")
+		if ap != bp {
+			return bp
+		}
+		return as[i] < bs[i]
+	}
+	return len(as) < len(bs)
+}
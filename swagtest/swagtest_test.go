@@ -0,0 +1,149 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swagtest
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zc2638/swag"
+	"github.com/zc2638/swag/endpoint"
+	"github.com/zc2638/swag/option"
+	"github.com/zc2638/swag/types"
+)
+
+func TestNewServer(t *testing.T) {
+	get := endpoint.New(http.MethodGet, "/pets/{id}",
+		endpoint.Path("id", types.String, "the pet", true),
+		endpoint.Query("view", types.String, "the view", false, endpoint.Enum("short", "full")),
+		endpoint.Response(http.StatusOK, "the pet"),
+	)
+	post := endpoint.New(http.MethodPost, "/pets",
+		endpoint.HeaderParam("X-Request-Id", types.String, "", "the request id", true),
+		endpoint.Response(http.StatusCreated, "created"),
+		endpoint.Handler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+		})),
+	)
+	del := endpoint.New(http.MethodDelete, "/pets/{id}",
+		endpoint.Path("id", types.String, "the pet", true),
+		endpoint.Response(http.StatusNoContent, "deleted"),
+	)
+	mine := endpoint.New(http.MethodGet, "/pets/mine", endpoint.Response(http.StatusOK, "my pets"))
+	api := swag.New(option.BasePath("/v1"), option.Endpoints(get, post, del, mine))
+	api.GlobalResponses = map[string]swag.Response{"404": {Description: "no such resource"}}
+
+	srv := NewServer(api, Bindings{
+		get.OperationID: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, PathParam(r, "id"))
+		}),
+		mine.OperationID: func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = io.WriteString(w, "mine")
+		},
+	})
+	defer srv.Close()
+
+	do := func(method, path string, header http.Header) (int, string) {
+		req, err := http.NewRequest(method, srv.URL+path, nil)
+		require.NoError(t, err)
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	code, body := do(http.MethodGet, "/v1/pets/42", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "42", body)
+
+	for i := 0; i < 20; i++ {
+		code, body = do(http.MethodGet, "/v1/pets/mine", nil)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, "mine", body)
+	}
+
+	code, body = do(http.MethodGet, "/v1/pets/42?view=tiny", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, body, "invalid value of query parameter view: tiny")
+
+	code, _ = do(http.MethodPost, "/v1/pets", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code, _ = do(http.MethodPost, "/v1/pets", http.Header{"X-Request-Id": {"1"}})
+	assert.Equal(t, http.StatusCreated, code)
+
+	code, _ = do(http.MethodDelete, "/v1/pets/42", nil)
+	assert.Equal(t, http.StatusNotImplemented, code)
+
+	code, body = do(http.MethodPut, "/v1/pets/42", nil)
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+	assert.Equal(t, "Method Not Allowed\n", body)
+
+	code, body = do(http.MethodGet, "/v1/owners", nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	assert.Equal(t, "no such resource\n", body)
+
+	code, body = do(http.MethodGet, DocsPrefix+"/swagger.json", nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, `"/pets/{id}"`)
+}
+
+func TestHandler_Binding(t *testing.T) {
+	api := swag.New(option.Endpoints(endpoint.New(http.MethodGet, "/pets")))
+	assert.Panics(t, func() {
+		Handler(api, Bindings{"getPets": "nope"})
+	})
+}
+
+func TestHandler_Versions(t *testing.T) {
+	api := swag.New()
+	api.Versioning = &swag.Versioning{Header: "Accept", Versions: []string{"1", "2"}}
+	api.AddEndpoint(
+		endpoint.New(http.MethodGet, "/pets", endpoint.Handler(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("v1"))
+		})),
+		endpoint.New(http.MethodGet, "/pets", endpoint.Version("2"), endpoint.Handler(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("v2"))
+		})),
+	)
+	h := Handler(api, nil)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/pets", nil))
+	assert.Equal(t, "v1", w.Body.String())
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/pets", nil)
+	r.Header.Set("Accept", "application/json; version=2")
+	h.ServeHTTP(w, r)
+	assert.Equal(t, "v2", w.Body.String())
+}
+
+func TestPrecedes(t *testing.T) {
+	assert.True(t, precedes("/pets/mine", "/pets/{id}"))
+	assert.False(t, precedes("/pets/{id}", "/pets/mine"))
+	assert.True(t, precedes("/pets/{id}", "/pets/{id}/toys"))
+	assert.True(t, precedes("/owners/{id}", "/pets/mine"))
+}
@@ -0,0 +1,106 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swagtest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/zc2638/swag"
+)
+
+// Validate returns a middleware answering 400 to the requests missing a required parameter of the endpoint,
+// or whose value is not in the enum of the parameter; the references are resolved against the api
+func Validate(api *swag.API, e *swag.Endpoint, next http.Handler) http.Handler {
+	params := make([]swag.Parameter, 0, len(e.Parameters))
+	for _, p := range e.Parameters {
+		if p.Ref != "" {
+			var ok bool
+			if p, ok = api.Parameters[strings.TrimPrefix(p.Ref, "#/parameters/")]; !ok {
+				continue
+			}
+		}
+		params = append(params, p)
+	}
+	if len(params) == 0 {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, p := range params {
+			if p.In == "body" {
+				if p.Required && r.ContentLength == 0 {
+					http.Error(w, "missing required body parameter "+p.Name, http.StatusBadRequest)
+					return
+				}
+				continue
+			}
+			values := lookup(r, p)
+			if len(values) == 0 {
+				if p.Required {
+					http.Error(w, "missing required "+p.In+" parameter "+p.Name, http.StatusBadRequest)
+					return
+				}
+				continue
+			}
+			if len(p.Enum) == 0 {
+				continue
+			}
+			for _, v := range values {
+				if !contains(p.Enum, v) {
+					http.Error(w, "invalid value of "+p.In+" parameter "+p.Name+": "+v, http.StatusBadRequest)
+					return
+				}
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func lookup(r *http.Request, p swag.Parameter) []string {
+	switch p.In {
+	case "path":
+		if v := PathParam(r, p.Name); v != "" {
+			return []string{v}
+		}
+	case "query":
+		return r.URL.Query()[p.Name]
+	case "header":
+		return r.Header.Values(p.Name)
+	case "cookie":
+		if c, err := r.Cookie(p.Name); err == nil {
+			return []string{c.Value}
+		}
+	case "formData":
+		if r.Form == nil {
+			_ = r.ParseMultipartForm(32 << 20)
+		}
+		if vs := r.Form[p.Name]; len(vs) > 0 {
+			return vs
+		}
+		if r.MultipartForm != nil && len(r.MultipartForm.File[p.Name]) > 0 {
+			return []string{p.Name}
+		}
+	}
+	return nil
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
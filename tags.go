@@ -0,0 +1,264 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TagOptions represents the struct tag keys read when reflecting models
+type TagOptions struct {
+	// Name lists the keys the field name is read from, the first present key wins; defaults to json
+	Name []string
+	// Description is the key of the field description; defaults to description, with desc as an alias
+	Description string
+	// Example is the key of the field example; defaults to example
+	Example string
+	// Required is the key marking the field as required; defaults to required
+	Required string
+	// Enum is the key of the comma separated field enum; defaults to enum
+	Enum string
+	// Format is the key overriding the field format; defaults to format
+	Format string
+	// Composite is the key of the composite tag, e.g. swag:"desc=User ID,example=42,required";
+	// defaults to swag
+	Composite string
+}
+
+var defaultTagOptions = TagOptions{
+	Name:        []string{"json"},
+	Description: "description",
+	Example:     "example",
+	Required:    "required",
+	Enum:        "enum",
+	Format:      "format",
+	Composite:   "swag",
+}
+
+var tagOptions = struct {
+	sync.RWMutex
+	opts TagOptions
+}{opts: defaultTagOptions}
+
+// SetTagOptions sets the struct tag keys read when reflecting models, e.g. bson or mapstructure
+// instead of json for the field names; empty keys keep their defaults
+func SetTagOptions(opts TagOptions) {
+	if len(opts.Name) == 0 {
+		opts.Name = defaultTagOptions.Name
+	}
+	if opts.Description == "" {
+		opts.Description = defaultTagOptions.Description
+	}
+	if opts.Example == "" {
+		opts.Example = defaultTagOptions.Example
+	}
+	if opts.Required == "" {
+		opts.Required = defaultTagOptions.Required
+	}
+	if opts.Enum == "" {
+		opts.Enum = defaultTagOptions.Enum
+	}
+	if opts.Format == "" {
+		opts.Format = defaultTagOptions.Format
+	}
+	if opts.Composite == "" {
+		opts.Composite = defaultTagOptions.Composite
+	}
+
+	tagOptions.Lock()
+	defer tagOptions.Unlock()
+	tagOptions.opts = opts
+}
+
+// fieldTag reads the struct tag of a field with the configured keys;
+// the entries of the composite tag take precedence over the separate tags
+type fieldTag struct {
+	tag       reflect.StructTag
+	opts      TagOptions
+	composite map[string]string
+}
+
+func newFieldTag(tag reflect.StructTag) fieldTag {
+	tagOptions.RLock()
+	opts := tagOptions.opts
+	tagOptions.RUnlock()
+
+	return fieldTag{
+		tag:       tag,
+		opts:      opts,
+		composite: parseCompositeTag(tag.Get(opts.Composite)),
+	}
+}
+
+// parseCompositeTag parses the comma separated entries of the composite tag,
+// e.g. desc=User ID,example=42,required,enum=a|b|c,format=uuid;
+// a comma inside a value is escaped as \,
+func parseCompositeTag(tag string) map[string]string {
+	if tag == "" {
+		return nil
+	}
+
+	entries := make(map[string]string)
+	var entry strings.Builder
+	flush := func() {
+		kv := strings.TrimSpace(entry.String())
+		entry.Reset()
+		if kv == "" {
+			return
+		}
+		key, value := kv, ""
+		if i := strings.Index(kv, "="); i >= 0 {
+			key, value = strings.TrimSpace(kv[:i]), strings.TrimSpace(kv[i+1:])
+		}
+		if key == "description" {
+			key = "desc"
+		}
+		entries[key] = value
+	}
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			entry.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			flush()
+		default:
+			entry.WriteByte(tag[i])
+		}
+	}
+	flush()
+	return entries
+}
+
+func (f fieldTag) lookup(key string) (string, bool) {
+	v, ok := f.composite[key]
+	return v, ok
+}
+
+// name returns the value of the first present name key, e.g. foo,omitempty
+func (f fieldTag) name() string {
+	for _, key := range f.opts.Name {
+		if v, ok := f.tag.Lookup(key); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+func (f fieldTag) description() string {
+	if desc, ok := f.lookup("desc"); ok {
+		return desc
+	}
+	if f.opts.Description == defaultTagOptions.Description {
+		if desc := f.tag.Get("desc"); desc != "" {
+			return desc
+		}
+	}
+	return f.tag.Get(f.opts.Description)
+}
+
+func (f fieldTag) example() string {
+	if example, ok := f.lookup("example"); ok {
+		return example
+	}
+	return f.tag.Get(f.opts.Example)
+}
+
+func (f fieldTag) required() bool {
+	if _, ok := f.lookup("required"); ok {
+		return true
+	}
+	_, ok := f.tag.Lookup(f.opts.Required)
+	return ok
+}
+
+func (f fieldTag) enum() []string {
+	if enum, ok := f.lookup("enum"); ok {
+		return strings.Split(enum, "|")
+	}
+	if enum := f.tag.Get(f.opts.Enum); enum != "" {
+		return strings.Split(enum, ",")
+	}
+	return nil
+}
+
+func (f fieldTag) format() string {
+	if format, ok := f.lookup("format"); ok {
+		return format
+	}
+	return f.tag.Get(f.opts.Format)
+}
+
+func (f fieldTag) defaultValue() string {
+	if v, ok := f.lookup("default"); ok {
+		return v
+	}
+	return f.tag.Get("default")
+}
+
+// audience returns the audiences the field is restricted to, e.g. audience:"internal,partner"
+func (f fieldTag) audience() []string {
+	if audience, ok := f.lookup("audience"); ok {
+		return strings.Split(audience, "|")
+	}
+	if audience := f.tag.Get("audience"); audience != "" {
+		return strings.Split(audience, ",")
+	}
+	return nil
+}
+
+// nullable reports whether the field accepts null, e.g. nullable:"true"
+func (f fieldTag) nullable() bool {
+	return f.flag("nullable")
+}
+
+// constant returns the only value the field accepts, e.g. const:"v1"
+func (f fieldTag) constant() (string, bool) {
+	if v, ok := f.lookup("const"); ok {
+		return v, true
+	}
+	return f.tag.Lookup("const")
+}
+
+// sensitive reports whether the field holds sensitive data, e.g. sensitive:"true"
+func (f fieldTag) sensitive() bool {
+	return f.flag("sensitive")
+}
+
+// sortable reports whether the results can be sorted on the field, e.g. sortable:"true"
+func (f fieldTag) sortable() bool {
+	return f.flag("sortable")
+}
+
+// filterable reports whether the results can be filtered on the field, e.g. filterable:"true"
+func (f fieldTag) filterable() bool {
+	return f.flag("filterable")
+}
+
+// flag reports whether the boolean entry is set, either empty or true
+func (f fieldTag) flag(key string) bool {
+	v, ok := f.lookup(key)
+	if !ok {
+		v, ok = f.tag.Lookup(key)
+	}
+	if !ok {
+		return false
+	}
+	enabled, err := strconv.ParseBool(v)
+	return v == "" || (err == nil && enabled)
+}
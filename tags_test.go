@@ -0,0 +1,74 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Document struct {
+	ID      string `bson:"_id" note:"the document id" mandatory:""`
+	Title   string `mapstructure:"title" bson:"name"`
+	Body    string `json:"body"`
+	Ignored string `bson:"-"`
+}
+
+func TestSetTagOptions(t *testing.T) {
+	SetTagOptions(TagOptions{Name: []string{"mapstructure", "bson"}, Description: "note", Required: "mandatory"})
+	defer SetTagOptions(TagOptions{})
+
+	obj := define(Document{})["github.com_zc2638_swag.Document"]
+	assert.Equal(t, []string{"_id"}, obj.Required)
+	assert.Equal(t, "the document id", obj.Properties["_id"].Description)
+	assert.Contains(t, obj.Properties, "title")
+	assert.Contains(t, obj.Properties, "Body")
+	assert.NotContains(t, obj.Properties, "Ignored")
+
+	SetTagOptions(TagOptions{})
+	obj = define(Document{})["github.com_zc2638_swag.Document"]
+	assert.Contains(t, obj.Properties, "body")
+	assert.Contains(t, obj.Properties, "Ignored")
+	assert.Empty(t, obj.Required)
+}
+
+type Account struct {
+	ID     int    `json:"id" swag:"desc=User ID,example=42,required"`
+	Kind   string `json:"kind" swag:"enum=a|b|c,format=uuid" desc:"kind" format:"ignored"`
+	Note   string `json:"note" swag:"description=first\\, second"`
+	Legacy string `json:"legacy" desc:"still works" required:""`
+}
+
+func TestCompositeTag(t *testing.T) {
+	obj := define(Account{})["github.com_zc2638_swag.Account"]
+	assert.Equal(t, []string{"id", "legacy"}, obj.Required)
+	assert.Equal(t, "User ID", obj.Properties["id"].Description)
+	assert.Equal(t, "42", obj.Properties["id"].Example)
+	assert.Equal(t, []string{"a", "b", "c"}, obj.Properties["kind"].Enum)
+	assert.Equal(t, "uuid", obj.Properties["kind"].Format)
+	assert.Equal(t, "kind", obj.Properties["kind"].Description)
+	assert.Equal(t, "first, second", obj.Properties["note"].Description)
+	assert.Equal(t, "still works", obj.Properties["legacy"].Description)
+}
+
+func TestParseCompositeTag(t *testing.T) {
+	assert.Nil(t, parseCompositeTag(""))
+	assert.Equal(t, map[string]string{
+		"desc":     "a=b",
+		"required": "",
+		"default":  "1",
+	}, parseCompositeTag(" desc = a=b , required,,default=1"))
+}
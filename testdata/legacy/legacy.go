@@ -0,0 +1,23 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package legacy holds models whose json tags are out of sync with their fields, for the diagnostics tests;
+// it lives in testdata since go vet rejects json tags on unexported fields
+package legacy
+
+// Customer has an unexported field carrying a json tag
+type Customer struct {
+	Name  string `json:"name"`
+	email string `json:"email"`
+}
@@ -0,0 +1,59 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+type timeFormat struct {
+	format string
+	layout string
+}
+
+var timeFormats = struct {
+	sync.RWMutex
+	types map[reflect.Type]timeFormat
+}{
+	types: map[reflect.Type]timeFormat{
+		reflect.TypeOf(time.Time{}): {format: "date-time"},
+	},
+}
+
+// RegisterTimeFormat documents the custom time type as a string with the specified format,
+// e.g. date or date-time, and its layout as x-format-layout
+func RegisterTimeFormat(prototype interface{}, format, layout string) {
+	t := reflect.TypeOf(prototype)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	timeFormats.Lock()
+	defer timeFormats.Unlock()
+	timeFormats.types[t] = timeFormat{format: format, layout: layout}
+}
+
+func lookupTimeFormat(t reflect.Type) (timeFormat, bool) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	timeFormats.RLock()
+	defer timeFormats.RUnlock()
+	f, ok := timeFormats.types[t]
+	return f, ok
+}
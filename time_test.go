@@ -0,0 +1,59 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Date struct {
+	time.Time
+}
+
+type Event struct {
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt *time.Time  `json:"updatedAt"`
+	Day       time.Time   `json:"day" format:"date"`
+	Birthday  Date        `json:"birthday"`
+	History   []time.Time `json:"history"`
+}
+
+func TestTimeFormat(t *testing.T) {
+	RegisterTimeFormat(&Date{}, "date", "2006-01-02")
+
+	v := define(Event{})
+	assert.Len(t, v, 1, "time types must not be defined as objects")
+	obj := v["github.com_zc2638_swag.Event"]
+
+	assert.Equal(t, Property{Type: "string", Format: "date-time"}, withoutGoType(obj.Properties["createdAt"]))
+	assert.Equal(t, Property{Type: "string", Format: "date-time"}, withoutGoType(obj.Properties["updatedAt"]))
+	assert.Equal(t, Property{Type: "string", Format: "date"}, withoutGoType(obj.Properties["day"]))
+	assert.Equal(t, Property{Type: "string", Format: "date", Layout: "2006-01-02"}, withoutGoType(obj.Properties["birthday"]))
+	assert.Equal(t, Property{Type: "array", Items: &Items{Type: "string", Format: "date-time"}}, withoutGoType(obj.Properties["history"]))
+
+	v = define(time.Time{})
+	obj, ok := v["time.Time"]
+	assert.True(t, ok)
+	assert.Equal(t, "string", obj.Type)
+	assert.Equal(t, "date-time", obj.Format)
+}
+
+func withoutGoType(p Property) Property {
+	p.GoType = nil
+	return p
+}
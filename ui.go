@@ -0,0 +1,142 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// UIOption configures the swagger ui served by SwaggerUI
+type UIOption func(c *uiConfig)
+
+type uiConfig struct {
+	prefix     string
+	autoDomain bool
+	settings   map[string]interface{}
+	oauth      map[string]interface{}
+}
+
+// UIPrefix serves the ui under the path prefix, redirecting the prefix without its trailing slash
+func UIPrefix(prefix string) UIOption {
+	return func(c *uiConfig) {
+		c.prefix = prefix
+	}
+}
+
+// UIAutoDomain resolves the spec path against the scheme and the host of the request
+func UIAutoDomain() UIOption {
+	return func(c *uiConfig) {
+		c.autoDomain = true
+	}
+}
+
+// UIDocExpansion sets the default expansion of the operations and tags: list, full or none
+func UIDocExpansion(mode string) UIOption {
+	return UISetting("docExpansion", mode)
+}
+
+// UIDeepLinking enables or disables the deep links of the tags and operations; enabled by default
+func UIDeepLinking(enabled bool) UIOption {
+	return UISetting("deepLinking", enabled)
+}
+
+// UIPersistAuthorization keeps the authorization data across the reloads of the page
+func UIPersistAuthorization() UIOption {
+	return UISetting("persistAuthorization", true)
+}
+
+// UIOAuth2RedirectURL sets the url of the oauth2 redirect page of the authorization code flow
+func UIOAuth2RedirectURL(url string) UIOption {
+	return UISetting("oauth2RedirectUrl", url)
+}
+
+// UIOAuthClientID prefills the client id of the oauth2 authorization dialog
+func UIOAuthClientID(clientID string) UIOption {
+	return UIOAuth("clientId", clientID)
+}
+
+// UISetting sets a parameter of the initialization of swagger ui, e.g. filter or tryItOutEnabled
+func UISetting(key string, value interface{}) UIOption {
+	return func(c *uiConfig) {
+		if c.settings == nil {
+			c.settings = make(map[string]interface{})
+		}
+		c.settings[key] = value
+	}
+}
+
+// UIOAuth sets a parameter of the oauth2 configuration of swagger ui, e.g. appName or scopes
+func UIOAuth(key string, value interface{}) UIOption {
+	return func(c *uiConfig) {
+		if c.oauth == nil {
+			c.oauth = make(map[string]interface{})
+		}
+		c.oauth[key] = value
+	}
+}
+
+// apply adds the settings to the initialization of swagger ui in the index page
+func (c uiConfig) apply(index []byte) []byte {
+	if len(c.settings) > 0 {
+		settings := map[string]interface{}{"deepLinking": true}
+		for k, v := range c.settings {
+			settings[k] = v
+		}
+		var lines []string
+		for _, k := range sortedKeys(settings) {
+			v, err := json.Marshal(settings[k])
+			if err != nil {
+				// a function or a channel can't be set on swagger ui
+				continue
+			}
+			lines = append(lines, k+": "+string(v)+",")
+		}
+		index = bytes.Replace(index, []byte("deepLinking: true,"), []byte(strings.Join(lines, "\n        ")), 1)
+	}
+	if len(c.oauth) > 0 {
+		if oauth, err := json.Marshal(c.oauth); err == nil {
+			index = bytes.Replace(index, []byte("window.ui = ui;"), []byte("window.ui = ui;\n      ui.initOAuth("+string(oauth)+");"), 1)
+		}
+	}
+	return index
+}
+
+// SwaggerUI returns a http.Handler serving the swagger ui embedded in the binary,
+// loading the spec at specPath; no asset is fetched from a CDN.
+// The files are served by their path without the mount prefix unless UIPrefix is set, e.g.
+//
+//	mux.Handle("/docs/", swag.SwaggerUI("/swagger.json", swag.UIPrefix("/docs")))
+func SwaggerUI(specPath string, opts ...UIOption) http.Handler {
+	var c uiConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	files := uiFiles(specPath, c)
+	if c.prefix == "" {
+		return files
+	}
+	return http.StripPrefix(c.prefix, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "" {
+			url := strings.TrimSuffix(c.prefix, "/") + "/"
+			http.Redirect(w, r, url, http.StatusFound)
+			return
+		}
+		files.ServeHTTP(w, r)
+	}))
+}
@@ -0,0 +1,79 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwaggerUI(t *testing.T) {
+	get := func(h http.Handler, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		r.Host = "api.example.com"
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/docs/", SwaggerUI("/swagger.json", UIPrefix("/docs")))
+	mux.Handle("/docs", SwaggerUI("/swagger.json", UIPrefix("/docs")))
+
+	w := get(mux, "/docs/")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `url: "/swagger.json"`)
+	assert.Contains(t, w.Body.String(), `src="./swagger-ui-bundle.js"`)
+
+	w = get(mux, "/docs/swagger-ui-bundle.js")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	w = get(mux, "/docs")
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/docs/", w.Header().Get("Location"))
+
+	// without a prefix, the files are served by their path
+	w = get(SwaggerUI("/swagger.json", UIAutoDomain()), "/")
+	assert.Contains(t, w.Body.String(), `url: "http://api.example.com/swagger.json"`)
+}
+
+func TestSwaggerUI_Settings(t *testing.T) {
+	h := SwaggerUI("/swagger.json",
+		UIDocExpansion("none"),
+		UIDeepLinking(false),
+		UIPersistAuthorization(),
+		UIOAuth2RedirectURL("https://api.example.com/docs/oauth2-redirect.html"),
+		UIOAuthClientID("docs"),
+	)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	body := w.Body.String()
+	assert.Contains(t, body, `deepLinking: false,`)
+	assert.NotContains(t, body, `deepLinking: true,`)
+	assert.Contains(t, body, `docExpansion: "none",`)
+	assert.Contains(t, body, `persistAuthorization: true,`)
+	assert.Contains(t, body, `oauth2RedirectUrl: "https://api.example.com/docs/oauth2-redirect.html",`)
+	assert.Contains(t, body, `ui.initOAuth({"clientId":"docs"});`)
+	assert.Contains(t, body, `url: "/swagger.json"`)
+
+	// without settings, the page is left as is
+	w = httptest.NewRecorder()
+	SwaggerUI("/swagger.json").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Contains(t, w.Body.String(), `deepLinking: true,`)
+	assert.NotContains(t, w.Body.String(), `initOAuth`)
+}
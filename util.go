@@ -41,6 +41,27 @@ func ColonPath(path string) string {
 	return path
 }
 
+// MatchPath matches the request path against the swagger path,
+// e.g. /pets/{id} and /pets/42, and returns the values of the path parameters, e.g. id=42
+func MatchPath(pattern, path string) (map[string]string, bool) {
+	patterns := strings.Split(strings.Trim(pattern, "/"), "/")
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patterns) != len(segments) {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, s := range patterns {
+		if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+			params[s[1:len(s)-1]] = segments[i]
+			continue
+		}
+		if s != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
 func camel(v string) string {
 	segments := strings.Split(v, "/")
 	results := make([]string, 0, len(segments))
@@ -62,6 +83,9 @@ func makeRef(name string) string {
 }
 
 func makeName(t reflect.Type) string {
+	if t.Kind() == reflect.Struct && t.Implements(namerType) {
+		return reflect.Zero(t).Interface().(Namer).SwaggerName()
+	}
 	name := t.Name()
 	if name == "" {
 		ptr := reflect2.PtrOf(t)
@@ -29,6 +29,17 @@ func TestColonPath(t *testing.T) {
 	assert.Equal(t, "/api/:a/:b/:c", ColonPath("/api/{a}/{b}/{c}"))
 }
 
+func TestMatchPath(t *testing.T) {
+	params, ok := MatchPath("/pets/{id}/toys/{toy}", "/pets/42/toys/ball")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"id": "42", "toy": "ball"}, params)
+
+	_, ok = MatchPath("/pets/{id}", "/pets/42/toys")
+	assert.False(t, ok)
+	_, ok = MatchPath("/pets/mine", "/pets/42")
+	assert.False(t, ok)
+}
+
 func TestCamel(t *testing.T) {
 	assert.Equal(t, "HelloWorld", camel("hello/world"))
 	assert.Equal(t, "HelloWorld", camel("/hello/world"))
@@ -0,0 +1,382 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zc2638/swag/types"
+)
+
+var pathParamRegexp = regexp.MustCompile(`{([^{}]+)}`)
+
+var parameterLocations = map[string]bool{
+	"path":     true,
+	"query":    true,
+	"header":   true,
+	"body":     true,
+	"formData": true,
+	// cookie parameters are OpenAPI 3 only, see RenderOptions.Unsupported
+	"cookie": true,
+}
+
+// ValidateEndpoint checks that the endpoint can be represented by the swagger definition;
+// the returned error is a *ValidationError wrapping ErrInvalidMethod, ErrInvalidParameter or ErrUnsupportedType.
+// References to named parameters are invalid here, since they are only resolved by API.Validate and API.TryAddEndpoint
+func ValidateEndpoint(e *Endpoint) error {
+	fail := func(field string, err error, format string, args ...interface{}) error {
+		return &ValidationError{
+			Method: e.Method,
+			Path:   e.Path,
+			Field:  field,
+			Err:    err,
+			Reason: fmt.Sprintf(format, args...),
+		}
+	}
+
+	switch strings.ToUpper(e.Method) {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch,
+		http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodConnect:
+	default:
+		return fail("", ErrInvalidMethod, "%q is not a http method", e.Method)
+	}
+
+	templated := make(map[string]bool)
+	for _, match := range pathParamRegexp.FindAllStringSubmatch(e.Path, -1) {
+		templated[match[1]] = true
+	}
+
+	declared := make(map[string]bool)
+	var body, form bool
+	for _, p := range e.Parameters {
+		if p.Ref != "" {
+			return fail(p.Ref, ErrInvalidParameter, "unresolved parameter reference")
+		}
+		if p.Name == "" {
+			return fail("", ErrInvalidParameter, "a %s parameter has no name", p.In)
+		}
+		if !parameterLocations[p.In] {
+			return fail(p.Name, ErrInvalidParameter, "unknown location %q", p.In)
+		}
+		key := p.In + ":" + p.Name
+		if declared[key] {
+			return fail(p.Name, ErrInvalidParameter, "declared twice in %s", p.In)
+		}
+		declared[key] = true
+
+		switch p.In {
+		case "body":
+			if body {
+				return fail(p.Name, ErrInvalidParameter, "only one body parameter is allowed")
+			}
+			body = true
+			if p.Schema == nil {
+				return fail(p.Name, ErrInvalidParameter, "body parameter requires a schema")
+			}
+			if err := validatePrototype(p.Schema.Prototype); err != nil {
+				return fail(p.Name, ErrUnsupportedType, "%v", err)
+			}
+			continue
+		case "formData":
+			form = true
+		case "path":
+			if !templated[p.Name] {
+				return fail(p.Name, ErrInvalidParameter, "not found in the path template")
+			}
+			if !p.Required {
+				return fail(p.Name, ErrInvalidParameter, "path parameters must be required")
+			}
+		}
+
+		if p.Schema != nil {
+			return fail(p.Name, ErrInvalidParameter, "only body parameters may declare a schema")
+		}
+		if reason := constraintsError(p); reason != "" {
+			return fail(p.Name, ErrInvalidParameter, "%s", reason)
+		}
+		switch p.Type {
+		case types.String, types.Number, types.Integer, types.Boolean:
+		case types.Array:
+			if p.Items == nil {
+				return fail(p.Name, ErrInvalidParameter, "array parameter requires items")
+			}
+			switch p.CollectionFormat {
+			case "", "csv", "ssv", "tsv", "pipes":
+			case "multi":
+				if p.In != "query" && p.In != "formData" {
+					return fail(p.Name, ErrInvalidParameter, "the multi collectionFormat is only valid in query and formData")
+				}
+			default:
+				return fail(p.Name, ErrInvalidParameter, "unknown collectionFormat %q", p.CollectionFormat)
+			}
+		case types.File:
+			if p.In != "formData" {
+				return fail(p.Name, ErrInvalidParameter, "file parameters must be located in formData")
+			}
+		default:
+			return fail(p.Name, ErrUnsupportedType, "parameter type %q", p.Type)
+		}
+	}
+	if body && form {
+		return fail("", ErrInvalidParameter, "body and formData parameters cannot be mixed")
+	}
+	for name := range templated {
+		if !declared["path:"+name] {
+			return fail(name, ErrInvalidParameter, "path template parameter is not declared")
+		}
+	}
+
+	codes := make([]string, 0, len(e.Responses))
+	for code := range e.Responses {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	for _, code := range codes {
+		if schema := e.Responses[code].Schema; schema != nil {
+			if err := validatePrototype(schema.Prototype); err != nil {
+				return fail(code, ErrUnsupportedType, "%v", err)
+			}
+		}
+	}
+	return nil
+}
+
+// constraintsError returns why the validation keywords of the parameter are inconsistent with its type
+// or with one another, or an empty string if they are not
+func constraintsError(p Parameter) string {
+	numeric := p.Type == types.Integer || p.Type == types.Number
+	if !numeric && (p.Minimum != nil || p.Maximum != nil || p.MultipleOf != nil) {
+		return "minimum, maximum and multipleOf only apply to numeric parameters"
+	}
+	if p.Type != types.String && (p.MinLength != nil || p.MaxLength != nil || p.Pattern != "") {
+		return "minLength, maxLength and pattern only apply to string parameters"
+	}
+	if p.Type != types.Array && (p.MinItems != nil || p.MaxItems != nil || p.UniqueItems) {
+		return "minItems, maxItems and uniqueItems only apply to array parameters"
+	}
+	if p.Minimum != nil && p.Maximum != nil && *p.Minimum > *p.Maximum {
+		return "minimum is greater than maximum"
+	}
+	if p.MultipleOf != nil && *p.MultipleOf <= 0 {
+		return "multipleOf must be greater than 0"
+	}
+	if p.MinLength != nil && p.MaxLength != nil && *p.MinLength > *p.MaxLength {
+		return "minLength is greater than maxLength"
+	}
+	if p.MinItems != nil && p.MaxItems != nil && *p.MinItems > *p.MaxItems {
+		return "minItems is greater than maxItems"
+	}
+	if p.Pattern != "" {
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return fmt.Sprintf("invalid pattern: %v", err)
+		}
+	}
+	return ""
+}
+
+// validatePrototype checks that every type reachable from the prototype can be encoded as json
+func validatePrototype(prototype interface{}) error {
+	if prototype == nil {
+		return nil
+	}
+	t, ok := prototype.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(prototype)
+	}
+	return validateType(t, t.String(), make(map[reflect.Type]bool))
+}
+
+func validateType(t reflect.Type, field string, seen map[reflect.Type]bool) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Complex64, reflect.Complex128, reflect.UnsafePointer:
+		return fmt.Errorf("%s of kind %s", field, t.Kind())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return validateType(t.Elem(), field, seen)
+	case reflect.Struct:
+		if isScalar(t) {
+			return nil
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" && !f.Anonymous {
+				continue
+			}
+			if strings.Split(f.Tag.Get("json"), ",")[0] == "-" {
+				continue
+			}
+			if err := validateType(f.Type, t.Name()+"."+f.Name, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// equivalentPath returns the path with its template parameters unnamed,
+// as /users/{id} and /users/{name} describe the same path
+func equivalentPath(p string) string {
+	return pathParamRegexp.ReplaceAllString(path.Clean("/"+p), "{}")
+}
+
+// Validate checks every endpoint of the api and every versioned variant, as well as the paths which differ only
+// by the names of their template parameters, and the security schemes and the requirements referencing them;
+// it returns the first failure found in path, method and version order
+func (a *API) Validate() error {
+	if err := a.validateSecurity(); err != nil {
+		return err
+	}
+
+	var err error
+	registered := make(map[string]string)
+	a.walkOperations(func(_ string, e *Endpoint) {
+		if err != nil {
+			return
+		}
+		v := *e
+		v.Parameters = resolveParameters(e.Parameters, a.Parameters)
+		if err = ValidateEndpoint(&v); err != nil {
+			return
+		}
+		if field := a.undefinedScheme(e.Security); field != "" {
+			err = &ValidationError{Method: e.Method, Path: e.Path, Field: field, Err: ErrInvalidSecurity, Reason: "undefined security scheme"}
+			return
+		}
+		// the variants of an operation share its path
+		key := strings.ToUpper(e.Method) + " " + equivalentPath(e.Path)
+		if other, ok := registered[key]; ok && other != e.Path {
+			err = &ValidationError{Method: e.Method, Path: e.Path, Err: ErrDuplicatePath, Reason: "conflicts with " + other}
+			return
+		}
+		registered[key] = e.Path
+	})
+	return err
+}
+
+// oauth2URLs tells whether each oauth2 flow requires the authorization url and the token url
+var oauth2URLs = map[string][2]bool{
+	"implicit":    {true, false},
+	"password":    {false, true},
+	"application": {false, true},
+	"accessCode":  {true, true},
+}
+
+// validateSecurity checks that the security schemes are complete, and that the global requirements reference them
+func (a *API) validateSecurity() error {
+	names := make([]string, 0, len(a.SecurityDefinitions))
+	for name := range a.SecurityDefinitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		scheme := a.SecurityDefinitions[name]
+		fail := func(format string, args ...interface{}) error {
+			return &ValidationError{Field: name, Err: ErrInvalidSecurity, Reason: fmt.Sprintf(format, args...)}
+		}
+		switch scheme.Type {
+		case "basic":
+		case "apiKey":
+			if scheme.Name == "" {
+				return fail("apiKey scheme requires a name")
+			}
+			if scheme.In != "header" && scheme.In != "query" {
+				return fail("apiKey scheme must be located in header or query")
+			}
+		case "oauth2":
+			urls, ok := oauth2URLs[scheme.Flow]
+			if !ok {
+				return fail("unknown oauth2 flow %q", scheme.Flow)
+			}
+			if urls[0] && scheme.AuthorizationURL == "" {
+				return fail("the %s flow requires an authorization url", scheme.Flow)
+			}
+			if urls[1] && scheme.TokenURL == "" {
+				return fail("the %s flow requires a token url", scheme.Flow)
+			}
+		default:
+			return fail("unknown type %q", scheme.Type)
+		}
+	}
+	if field := a.undefinedScheme(a.Security); field != "" {
+		return &ValidationError{Field: field, Err: ErrInvalidSecurity, Reason: "undefined security scheme"}
+	}
+	return nil
+}
+
+// undefinedScheme returns the first scheme name of the requirement which is not defined by the api, if any
+func (a *API) undefinedScheme(security *SecurityRequirement) string {
+	if security == nil {
+		return ""
+	}
+	var names []string
+	for _, requirement := range security.Requirements {
+		for name := range requirement {
+			if _, ok := a.SecurityDefinitions[name]; !ok {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	sort.Strings(names)
+	return names[0]
+}
+
+// TryAddEndpoint validates the endpoints before adding them to the API definition, and adds none of them
+// if one is invalid or is already registered with the same method and an equivalent path
+func (a *API) TryAddEndpoint(es ...*Endpoint) error {
+	registered := make(map[string]string)
+	for p, endpoints := range a.Paths {
+		endpoints.Walk(func(e *Endpoint) {
+			registered[strings.ToUpper(e.Method)+" "+equivalentPath(p)] = p
+		})
+	}
+
+	for _, e := range es {
+		v := *e
+		v.Path = path.Join(a.prefixPath, e.Path)
+		v.Parameters = resolveParameters(e.Parameters, a.Parameters)
+		if err := ValidateEndpoint(&v); err != nil {
+			a.clean()
+			return err
+		}
+		if len(v.Versions) > 0 {
+			continue
+		}
+		key := strings.ToUpper(v.Method) + " " + equivalentPath(v.Path)
+		if other, ok := registered[key]; ok {
+			a.clean()
+			return &ValidationError{Method: v.Method, Path: v.Path, Err: ErrDuplicatePath, Reason: "conflicts with " + other}
+		}
+		registered[key] = v.Path
+	}
+	a.AddEndpoint(es...)
+	return nil
+}
@@ -0,0 +1,258 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zc2638/swag/types"
+)
+
+type unsupportedModel struct {
+	Name     string
+	Callback func() `json:"callback"`
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	pathID := Parameter{In: "path", Name: "id", Type: types.Integer, Required: true}
+	tests := []struct {
+		name     string
+		endpoint *Endpoint
+		want     error
+	}{
+		{
+			name:     "valid",
+			endpoint: &Endpoint{Method: http.MethodGet, Path: "/pets/{id}", Parameters: []Parameter{pathID}},
+		},
+		{
+			name:     "invalid method",
+			endpoint: &Endpoint{Method: "FETCH", Path: "/pets"},
+			want:     ErrInvalidMethod,
+		},
+		{
+			name:     "undeclared path parameter",
+			endpoint: &Endpoint{Method: http.MethodGet, Path: "/pets/{id}"},
+			want:     ErrInvalidParameter,
+		},
+		{
+			name:     "path parameter missing from the template",
+			endpoint: &Endpoint{Method: http.MethodGet, Path: "/pets", Parameters: []Parameter{pathID}},
+			want:     ErrInvalidParameter,
+		},
+		{
+			name: "unknown location",
+			endpoint: &Endpoint{Method: http.MethodGet, Path: "/pets", Parameters: []Parameter{
+				{In: "matrix", Name: "session", Type: types.String},
+			}},
+			want: ErrInvalidParameter,
+		},
+		{
+			name: "body without schema",
+			endpoint: &Endpoint{Method: http.MethodPost, Path: "/pets", Parameters: []Parameter{
+				{In: "body", Name: "body"},
+			}},
+			want: ErrInvalidParameter,
+		},
+		{
+			name: "body mixed with form data",
+			endpoint: &Endpoint{Method: http.MethodPost, Path: "/pets", Parameters: []Parameter{
+				{In: "body", Name: "body", Schema: MakeSchema(Pet{})},
+				{In: "formData", Name: "name", Type: types.String},
+			}},
+			want: ErrInvalidParameter,
+		},
+		{
+			name: "multi collection format in a header",
+			endpoint: &Endpoint{Method: http.MethodGet, Path: "/pets", Parameters: []Parameter{
+				{In: "header", Name: "X-Tags", Type: types.Array, Items: &Items{Type: "string"}, CollectionFormat: "multi"},
+			}},
+			want: ErrInvalidParameter,
+		},
+		{
+			name: "inconsistent bounds",
+			endpoint: &Endpoint{Method: http.MethodGet, Path: "/pets", Parameters: []Parameter{
+				{In: "query", Name: "limit", Type: types.Integer, Minimum: float64Ptr(10), Maximum: float64Ptr(1)},
+			}},
+			want: ErrInvalidParameter,
+		},
+		{
+			name: "length constraint on a number",
+			endpoint: &Endpoint{Method: http.MethodGet, Path: "/pets", Parameters: []Parameter{
+				{In: "query", Name: "limit", Type: types.Integer, Pattern: "^[0-9]+$"},
+			}},
+			want: ErrInvalidParameter,
+		},
+		{
+			name: "unsupported parameter type",
+			endpoint: &Endpoint{Method: http.MethodGet, Path: "/pets", Parameters: []Parameter{
+				{In: "query", Name: "filter", Type: "object"},
+			}},
+			want: ErrUnsupportedType,
+		},
+		{
+			name: "unsupported response model",
+			endpoint: &Endpoint{Method: http.MethodGet, Path: "/pets", Responses: map[string]Response{
+				"200": {Description: "ok", Schema: MakeSchema(unsupportedModel{})},
+			}},
+			want: ErrUnsupportedType,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEndpoint(tt.endpoint)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, tt.want), "%v", err)
+
+			var verr *ValidationError
+			assert.True(t, errors.As(err, &verr))
+			assert.Equal(t, tt.endpoint.Path, verr.Path)
+		})
+	}
+}
+
+func float64Ptr(v float64) *float64 {
+	return &v
+}
+
+func TestValidationError(t *testing.T) {
+	err := &ValidationError{
+		Method: http.MethodGet,
+		Path:   "/pets",
+		Field:  "filter",
+		Err:    ErrUnsupportedType,
+		Reason: `parameter type "object"`,
+	}
+	assert.EqualError(t, err, `GET /pets: filter: unsupported type: parameter type "object"`)
+	assert.Equal(t, ErrUnsupportedType, errors.Unwrap(err))
+}
+
+func TestAPI_TryAddEndpoint(t *testing.T) {
+	api := New()
+	get := func(p, name string) *Endpoint {
+		return &Endpoint{Method: http.MethodGet, Path: p, Parameters: []Parameter{
+			{In: "path", Name: name, Type: types.String, Required: true},
+		}}
+	}
+
+	assert.NoError(t, api.TryAddEndpoint(get("/pets/{id}", "id")))
+	err := api.TryAddEndpoint(
+		&Endpoint{Method: http.MethodGet, Path: "/owners"},
+		get("/pets/{name}", "name"),
+	)
+	assert.True(t, errors.Is(err, ErrDuplicatePath))
+	assert.EqualError(t, err, "GET /pets/{name}: duplicate path: conflicts with /pets/{id}")
+	assert.NotContains(t, api.Paths, "/owners")
+
+	// the group prefix is applied before checking the paths
+	api.WithGroup("/v2")
+	assert.NoError(t, api.TryAddEndpoint(get("/pets/{name}", "name")))
+	assert.Contains(t, api.Paths, "/v2/pets/{name}")
+
+	err = api.TryAddEndpoint(&Endpoint{Method: http.MethodGet, Path: "/pets/{id}"})
+	assert.True(t, errors.Is(err, ErrInvalidParameter))
+}
+
+func TestAPI_Validate(t *testing.T) {
+	api := New()
+	assert.NoError(t, api.Validate())
+
+	api.AddEndpoint(
+		&Endpoint{Method: http.MethodDelete, Path: "/pets/{id}", Parameters: []Parameter{
+			{In: "path", Name: "id", Type: types.Integer, Required: true},
+		}},
+		&Endpoint{Method: http.MethodDelete, Path: "/pets/{name}", Parameters: []Parameter{
+			{In: "path", Name: "name", Type: types.String, Required: true},
+		}},
+	)
+	err := api.Validate()
+	assert.True(t, errors.Is(err, ErrDuplicatePath))
+
+	api = New()
+	api.AddEndpoint(&Endpoint{Method: http.MethodGet, Path: "/pets/{id}"})
+	assert.True(t, errors.Is(api.Validate(), ErrInvalidParameter))
+}
+
+func TestAPI_ValidateVersions(t *testing.T) {
+	api := New()
+	api.Versioning = &Versioning{Header: "Accept", Versions: []string{"1", "2"}}
+	api.AddEndpoint(
+		&Endpoint{Method: http.MethodGet, Path: "/pets/{id}", Parameters: []Parameter{
+			{In: "path", Name: "id", Type: types.Integer, Required: true},
+		}},
+		&Endpoint{Method: http.MethodGet, Path: "/pets/{id}", Versions: []string{"2"}, Parameters: []Parameter{
+			{In: "path", Name: "id", Type: types.String, Required: true},
+		}},
+	)
+	assert.NoError(t, api.Validate())
+
+	api.AddEndpoint(&Endpoint{Method: http.MethodDelete, Path: "/pets/{id}", Versions: []string{"2"}})
+	err := api.Validate()
+	assert.True(t, errors.Is(err, ErrInvalidParameter), "%v", err)
+}
+
+func TestAPI_ValidateParameterRefs(t *testing.T) {
+	api := New()
+	api.Parameters = map[string]Parameter{
+		"id": {In: "path", Name: "id", Type: types.Integer, Required: true},
+	}
+	e := &Endpoint{Method: http.MethodGet, Path: "/pets/{id}", Parameters: []Parameter{{Ref: "#/parameters/id"}}}
+	assert.True(t, errors.Is(ValidateEndpoint(e), ErrInvalidParameter))
+	assert.NoError(t, api.TryAddEndpoint(e))
+	assert.NoError(t, api.Validate())
+
+	err := api.TryAddEndpoint(&Endpoint{Method: http.MethodGet, Path: "/owners", Parameters: []Parameter{{Ref: "#/parameters/page"}}})
+	var verr *ValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, "#/parameters/page", verr.Field)
+	}
+}
+
+func TestAPI_ValidateSecurity(t *testing.T) {
+	api := New()
+	api.SecurityDefinitions = map[string]SecurityScheme{
+		"basic":  {Type: "basic"},
+		"apiKey": {Type: "apiKey", Name: "X-API-Key", In: "header"},
+		"oauth":  {Type: "oauth2", Flow: "accessCode", AuthorizationURL: "https://example.com/authorize", TokenURL: "https://example.com/token"},
+	}
+	api.Security = &SecurityRequirement{Requirements: []map[string][]string{{"basic": {}}}}
+	api.AddEndpoint(&Endpoint{Method: http.MethodGet, Path: "/pets", Security: &SecurityRequirement{
+		Requirements: []map[string][]string{{"oauth": {"read"}, "apiKey": {}}},
+	}})
+	assert.NoError(t, api.Validate())
+
+	api.Paths["/pets"].Get.Security.Requirements[0]["jwt"] = []string{}
+	err := api.Validate()
+	var verr *ValidationError
+	if assert.True(t, errors.As(err, &verr)) {
+		assert.Equal(t, ErrInvalidSecurity, verr.Err)
+		assert.Equal(t, "jwt", verr.Field)
+		assert.Equal(t, "/pets", verr.Path)
+	}
+
+	api = New()
+	api.SecurityDefinitions = map[string]SecurityScheme{"oauth": {Type: "oauth2", Flow: "password"}}
+	assert.EqualError(t, api.Validate(), "oauth: invalid security: the password flow requires a token url")
+
+	api = New()
+	api.Security = &SecurityRequirement{Requirements: []map[string][]string{{"basic": {}}}}
+	assert.True(t, errors.Is(api.Validate(), ErrInvalidSecurity))
+}
@@ -0,0 +1,247 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"mime"
+	"net/http"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/zc2638/swag/types"
+)
+
+// Versioning represents header based api versioning;
+// the version is read from the header value, or from its version parameter for media types such as
+// Accept: application/json; version=2
+type Versioning struct {
+	Header   string
+	Versions []string
+
+	// variants holds the operations documented for a specific version, by version and path
+	variants map[string]map[string]*Endpoints
+}
+
+// addVariant registers the endpoint as the variant of the operation for each of its versions;
+// variants are only rendered in the document of the matching version
+func (a *API) addVariant(e *Endpoint) {
+	if a.Versioning == nil {
+		a.Versioning = &Versioning{}
+	}
+	if a.Versioning.variants == nil {
+		a.Versioning.variants = make(map[string]map[string]*Endpoints)
+	}
+	for _, version := range e.Versions {
+		paths, ok := a.Versioning.variants[version]
+		if !ok {
+			paths = make(map[string]*Endpoints)
+			a.Versioning.variants[version] = paths
+		}
+		v, ok := paths[e.Path]
+		if !ok {
+			v = &Endpoints{}
+			paths[e.Path] = v
+		}
+		v.set(e.Method, e)
+	}
+}
+
+// known reports whether the version is one of the versions of the api, or has variants
+func (vs *Versioning) known(version string) bool {
+	if version == "" {
+		return false
+	}
+	_, ok := vs.variants[version]
+	return ok || containsString(vs.Versions, version)
+}
+
+// requestVersion returns the version requested by the version query parameter or the version header,
+// or the empty default version if the requested one is unknown
+func (a *API) requestVersion(req *http.Request) string {
+	if a.Versioning == nil {
+		return ""
+	}
+	version := req.URL.Query().Get("version")
+	if version == "" && a.Versioning.Header != "" {
+		version = req.Header.Get(a.Versioning.Header)
+		if _, params, err := mime.ParseMediaType(version); err == nil && params["version"] != "" {
+			version = params["version"]
+		}
+	}
+	if !a.Versioning.known(version) {
+		return ""
+	}
+	return version
+}
+
+// RenderVersion returns the swagger definition documenting the specified version;
+// the operation variants of the version replace the default ones,
+// and every operation declares the version header parameter.
+// An empty or unknown version renders the default operations
+func (a *API) RenderVersion(version string) *API {
+	doc := a.Clone()
+	vs := a.Versioning
+	if vs == nil {
+		return doc
+	}
+	if !vs.known(version) {
+		version = ""
+	}
+	doc.Versioning = nil
+	doc.Paths = make(map[string]*Endpoints, len(a.Paths))
+
+	header := Parameter{
+		In:          "header",
+		Name:        vs.Header,
+		Description: "the requested api version",
+		Type:        types.String,
+		Enum:        vs.Versions,
+		Default:     version,
+	}
+	add := func(paths map[string]*Endpoints) {
+		for p, endpoints := range paths {
+			v, ok := doc.Paths[p]
+			if !ok {
+				v = &Endpoints{}
+				doc.Paths[p] = v
+			}
+			endpoints.Walk(func(endpoint *Endpoint) {
+				e := *endpoint
+				if vs.Header != "" {
+					e.Parameters = append(append(make([]Parameter, 0, len(e.Parameters)+1), e.Parameters...), header)
+				}
+				v.set(e.Method, &e)
+			})
+		}
+	}
+	add(a.Paths)
+	if version != "" {
+		add(vs.variants[version])
+	}
+	return doc
+}
+
+// WalkVersions invokes the callback once per operation to bind, with the endpoints serving it by version:
+// the default operation under the empty version and its variants under their versions.
+// Unlike Walk, which only visits the default operations, it lets a router bind the variants of an operation
+// on a single route, serving each request with the endpoint returned by Variant
+func (a *API) WalkVersions(callback func(path, method string, endpoints map[string]*Endpoint)) {
+	operations := make(map[string]map[string]*Endpoint)
+	add := func(version string, paths map[string]*Endpoints) {
+		for rawPath, endpoints := range paths {
+			u := path.Join(a.BasePath, rawPath)
+			endpoints.Walk(func(endpoint *Endpoint) {
+				key := strings.ToUpper(endpoint.Method) + " " + u
+				if operations[key] == nil {
+					operations[key] = make(map[string]*Endpoint)
+				}
+				operations[key][version] = endpoint
+			})
+		}
+	}
+	add("", a.Paths)
+	if a.Versioning != nil {
+		for version, paths := range a.Versioning.variants {
+			add(version, paths)
+		}
+	}
+
+	keys := make([]string, 0, len(operations))
+	for k := range operations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		i := strings.Index(k, " ")
+		callback(k[i+1:], k[:i], operations[k])
+	}
+}
+
+// walkOperations invokes the callback for each default operation and each versioned variant,
+// sorted by path, method and version; the version of the default operations is empty
+func (a *API) walkOperations(callback func(version string, e *Endpoint)) {
+	type operation struct {
+		version string
+		e       *Endpoint
+	}
+	var operations []operation
+	a.WalkVersions(func(_, _ string, endpoints map[string]*Endpoint) {
+		for version, e := range endpoints {
+			operations = append(operations, operation{version: version, e: e})
+		}
+	})
+	sort.Slice(operations, func(i, j int) bool {
+		a, b := operations[i], operations[j]
+		if a.e.Path != b.e.Path {
+			return a.e.Path < b.e.Path
+		}
+		if m, n := strings.ToUpper(a.e.Method), strings.ToUpper(b.e.Method); m != n {
+			return m < n
+		}
+		return a.version < b.version
+	})
+	for _, op := range operations {
+		callback(op.version, op.e)
+	}
+}
+
+// Variant returns the endpoint serving the request among the endpoints of an operation given by WalkVersions:
+// the variant of the requested version, else the default operation, or nil if the operation has neither
+func (a *API) Variant(req *http.Request, endpoints map[string]*Endpoint) *Endpoint {
+	if e, ok := endpoints[a.requestVersion(req)]; ok {
+		return e
+	}
+	return endpoints[""]
+}
+
+// VariantHandler returns the http.Handler of an operation given by WalkVersions,
+// serving each request with the handler of the endpoint returned by Variant, or 404 if there is none
+func (a *API) VariantHandler(endpoints map[string]*Endpoint, handler func(e *Endpoint) http.Handler) http.Handler {
+	if e, ok := endpoints[""]; ok && len(endpoints) == 1 {
+		return handler(e)
+	}
+	handlers := make(map[*Endpoint]http.Handler, len(endpoints))
+	for _, e := range endpoints {
+		handlers[e] = handler(e)
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, ok := handlers[a.Variant(r, endpoints)]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// routes returns the endpoints bound by the routers by path, the default operations merged with their variants
+func (a *API) routes() map[string]*Endpoints {
+	if a.Versioning == nil || len(a.Versioning.variants) == 0 {
+		return a.Paths
+	}
+	routes := make(map[string]*Endpoints, len(a.Paths))
+	a.WalkVersions(func(_, _ string, endpoints map[string]*Endpoint) {
+		for _, e := range endpoints {
+			v, ok := routes[e.Path]
+			if !ok {
+				v = &Endpoints{}
+				routes[e.Path] = v
+			}
+			v.set(e.Method, e)
+		}
+	})
+	return routes
+}
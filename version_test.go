@@ -0,0 +1,151 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newVersionedAPI() *API {
+	api := New()
+	api.Versioning = &Versioning{Header: "Accept", Versions: []string{"1", "2"}}
+	api.AddEndpoint(
+		&Endpoint{Path: "/pets", Method: http.MethodGet, Summary: "v1"},
+		&Endpoint{Path: "/pets", Method: http.MethodPost},
+		&Endpoint{Path: "/pets", Method: http.MethodGet, Summary: "v2", Versions: []string{"2"}},
+	)
+	return api
+}
+
+func TestAPI_RenderVersion(t *testing.T) {
+	api := newVersionedAPI()
+
+	doc := api.RenderVersion("")
+	assert.Nil(t, doc.Versioning)
+	assert.Equal(t, "v1", doc.Paths["/pets"].Get.Summary)
+	header := doc.Paths["/pets"].Get.Parameters[0]
+	assert.Equal(t, "header", header.In)
+	assert.Equal(t, "Accept", header.Name)
+	assert.Equal(t, []string{"1", "2"}, header.Enum)
+
+	doc = api.RenderVersion("2")
+	assert.Equal(t, "v2", doc.Paths["/pets"].Get.Summary)
+	assert.Equal(t, "2", doc.Paths["/pets"].Get.Parameters[0].Default)
+	assert.NotNil(t, doc.Paths["/pets"].Post)
+
+	doc = api.RenderVersion("3")
+	assert.Equal(t, "v1", doc.Paths["/pets"].Get.Summary)
+	assert.Empty(t, doc.Paths["/pets"].Get.Parameters[0].Default)
+
+	// the original definition must stay untouched
+	assert.Len(t, api.Paths["/pets"].Get.Parameters, 0)
+}
+
+func TestAPI_HandlerVersion(t *testing.T) {
+	api := newVersionedAPI()
+	tests := []struct {
+		name   string
+		url    string
+		accept string
+		want   string
+	}{
+		{name: "default", url: "/", want: "v1"},
+		{name: "query", url: "/?version=2", want: "v2"},
+		{name: "accept profile", url: "/", accept: "application/json; version=2", want: "v2"},
+		{name: "unknown", url: "/", accept: "application/json; version=3", want: "v1"},
+		{name: "plain", url: "/", accept: "2", want: "v2"},
+		{name: "unknown query", url: "/?version=%3Cscript%3E", want: "v1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			r.Header.Set("Accept", tt.accept)
+			api.Handler().ServeHTTP(w, r)
+
+			doc := &API{}
+			assert.Nil(t, json.Unmarshal(w.Body.Bytes(), doc))
+			assert.Equal(t, tt.want, doc.Paths["/pets"].Get.Summary)
+			// only the known versions are copied into the definition
+			assert.Contains(t, []string{"", "2"}, doc.Paths["/pets"].Get.Parameters[0].Default)
+		})
+	}
+}
+
+func TestAPI_WalkVersions(t *testing.T) {
+	api := newVersionedAPI()
+	api.BasePath = "/v1"
+	api.AddEndpoint(&Endpoint{Path: "/pets", Method: http.MethodDelete, Versions: []string{"2"}})
+
+	var operations []string
+	api.WalkVersions(func(path, method string, endpoints map[string]*Endpoint) {
+		operations = append(operations, method+" "+path)
+		switch method {
+		case http.MethodGet:
+			assert.Equal(t, "v1", endpoints[""].Summary)
+			assert.Equal(t, "v2", endpoints["2"].Summary)
+		case http.MethodDelete:
+			assert.Len(t, endpoints, 1)
+			assert.NotNil(t, endpoints["2"])
+		}
+	})
+	assert.Equal(t, []string{"DELETE /v1/pets", "GET /v1/pets", "POST /v1/pets"}, operations)
+
+	f := api.Fallbacks()
+	assert.Len(t, f.Paths, 1)
+	assert.NotContains(t, f.Paths[0].Methods, http.MethodDelete)
+}
+
+func TestAPI_VariantHandler(t *testing.T) {
+	api := newVersionedAPI()
+	api.AddEndpoint(&Endpoint{Path: "/pets", Method: http.MethodDelete, Versions: []string{"2"}})
+
+	handlers := make(map[string]http.Handler)
+	api.WalkVersions(func(path, method string, endpoints map[string]*Endpoint) {
+		handlers[method] = api.VariantHandler(endpoints, func(e *Endpoint) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(e.Summary))
+			})
+		})
+	})
+	tests := []struct {
+		name   string
+		method string
+		accept string
+		code   int
+		want   string
+	}{
+		{name: "default", method: http.MethodGet, code: http.StatusOK, want: "v1"},
+		{name: "variant", method: http.MethodGet, accept: "application/json; version=2", code: http.StatusOK, want: "v2"},
+		{name: "unknown version", method: http.MethodGet, accept: "3", code: http.StatusOK, want: "v1"},
+		{name: "variant only", method: http.MethodDelete, accept: "2", code: http.StatusOK},
+		{name: "variant only default", method: http.MethodDelete, code: http.StatusNotFound, want: "404 page not found\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(tt.method, "/pets", nil)
+			r.Header.Set("Accept", tt.accept)
+			handlers[tt.method].ServeHTTP(w, r)
+			assert.Equal(t, tt.code, w.Code)
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+)
+
+var xmlNameType = reflect.TypeOf(xml.Name{})
+
+// XML represents the xml naming of a definition, property or array items
+type XML struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+	Attribute bool   `json:"attribute,omitempty"`
+	Wrapped   bool   `json:"wrapped,omitempty"`
+}
+
+// parseXMLName splits the name of a xml tag into its namespace and local name, e.g. "http://a.com/ns item"
+func parseXMLName(name string) *XML {
+	x := &XML{Name: name}
+	if i := strings.LastIndex(name, " "); i >= 0 {
+		x.Namespace, x.Name = name[:i], name[i+1:]
+	}
+	return x
+}
+
+// applyXML documents the xml naming of the field read from its xml tag, when it differs from the json naming;
+// a tag such as xml:"tags>tag" on a slice documents a wrapped array
+func applyXML(p *Property, field reflect.StructField, jsonName string) {
+	tag, ok := field.Tag.Lookup("xml")
+	if !ok || tag == "-" {
+		return
+	}
+	parts := strings.Split(tag, ",")
+	attribute := false
+	for _, opt := range parts[1:] {
+		switch opt {
+		case "attr":
+			attribute = true
+		case "chardata", "innerxml", "comment":
+			// the field is not an element of its own
+			return
+		}
+	}
+
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	if p.Type == "array" && p.Items != nil {
+		if i := strings.Index(name, ">"); i >= 0 {
+			p.XML = &XML{Name: name[:i], Wrapped: true}
+			name = name[strings.LastIndex(name, ">")+1:]
+		}
+		if item := parseXMLName(name); *item != (XML{Name: jsonName}) || p.XML != nil {
+			p.Items.XML = item
+		}
+		return
+	}
+
+	if i := strings.LastIndex(name, ">"); i >= 0 {
+		name = name[i+1:]
+	}
+	x := parseXMLName(name)
+	x.Attribute = attribute
+	if *x != (XML{Name: jsonName}) {
+		p.XML = x
+	}
+}
+
+// structXML returns the xml naming of the struct read from the tag of its XMLName field, if any
+func structXML(t reflect.Type) *XML {
+	field, ok := t.FieldByName("XMLName")
+	if !ok || field.Type != xmlNameType {
+		return nil
+	}
+	name := strings.Split(field.Tag.Get("xml"), ",")[0]
+	if name == "" {
+		return nil
+	}
+	return parseXMLName(name)
+}
@@ -0,0 +1,55 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type Book struct {
+	XMLName  xml.Name `json:"-" xml:"urn:books book"`
+	ID       string   `json:"id" xml:"id,attr"`
+	Title    string   `json:"title" xml:"title"`
+	Author   string   `json:"author" xml:"written-by"`
+	Tags     []string `json:"tags" xml:"tags>tag"`
+	Chapters []string `json:"chapters" xml:"chapter"`
+	Notes    []string `json:"notes" xml:"notes"`
+	Summary  string   `json:"summary" xml:",chardata"`
+	Plain    string   `json:"plain"`
+}
+
+func TestXMLNaming(t *testing.T) {
+	obj := defineObject(Book{}, "")
+	assert.Equal(t, &XML{Name: "book", Namespace: "urn:books"}, obj.XML)
+	assert.NotContains(t, obj.Properties, "XMLName")
+
+	assert.Equal(t, &XML{Name: "id", Attribute: true}, obj.Properties["id"].XML)
+	assert.Nil(t, obj.Properties["title"].XML)
+	assert.Equal(t, &XML{Name: "written-by"}, obj.Properties["author"].XML)
+	assert.Nil(t, obj.Properties["summary"].XML)
+	assert.Nil(t, obj.Properties["plain"].XML)
+
+	tags := obj.Properties["tags"]
+	assert.Equal(t, &XML{Name: "tags", Wrapped: true}, tags.XML)
+	assert.Equal(t, &XML{Name: "tag"}, tags.Items.XML)
+
+	chapters := obj.Properties["chapters"]
+	assert.Nil(t, chapters.XML)
+	assert.Equal(t, &XML{Name: "chapter"}, chapters.Items.XML)
+	assert.Nil(t, obj.Properties["notes"].Items.XML)
+}
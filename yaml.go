@@ -0,0 +1,60 @@
+// Copyright © 2022 zc2638 <zc2638@qq.com>.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package swag
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EncodeYAML writes the yaml encoding of the swagger definition to w,
+// keeping the key order of the json encoding
+func (a *API) EncodeYAML(w io.Writer) error {
+	return a.EncodeYAMLContext(context.Background(), w)
+}
+
+// EncodeYAMLContext is like EncodeYAML, but stops with the error of the context once it is done
+func (a *API) EncodeYAMLContext(ctx context.Context, w io.Writer) error {
+	var buf bytes.Buffer
+	if err := a.EncodeContext(ctx, &buf); err != nil {
+		return err
+	}
+
+	// json is valid yaml, so decoding it into a node keeps the key order
+	var node yaml.Node
+	if err := yaml.Unmarshal(buf.Bytes(), &node); err != nil {
+		return err
+	}
+	blockStyle(&node)
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// blockStyle drops the flow style and quotes of the decoded json;
+// the encoder still quotes the strings that would otherwise be read back as another type
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}